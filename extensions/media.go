@@ -19,6 +19,12 @@ var MediaExtensions = []string{
 	".vob",
 	".ts",
 	".strm",
+	".iso",
+}
+
+// 光盘镜像文件扩展名
+var DiscImageExtensions = []string{
+	".iso",
 }
 
 // MediaExtensionsExtended 包含所有媒体扩展名以及额外的 .strm 扩展名
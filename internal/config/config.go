@@ -19,6 +19,9 @@ var (
 	Log      LogConfig
 	TMDB     TMDBConfig
 	Fanart   FanartConfig
+	TVDB     TVDBConfig
+	Bangumi  BangumiConfig
+	Douban   DoubanConfig
 	Storages []StorageConfig
 	Media    MediaConfig
 )
@@ -45,6 +48,9 @@ func WriteConfig() error {
 		Log:      Log,
 		TMDB:     TMDB,
 		Fanart:   Fanart,
+		TVDB:     TVDB,
+		Bangumi:  Bangumi,
+		Douban:   Douban,
 		Storages: Storages,
 		Media:    Media,
 	}
@@ -15,13 +15,44 @@ var defaultConfig = Configuration{
 		FileDir:      getLogsPath(),
 	},
 	TMDB: TMDBConfig{
-		ApiKey:   "YOUR_TMDB_API_KEY", // 请替换为您的 TMDB API Key
-		ApiURL:   "https://api.themoviedb.org",
-		ImageURL: "https://image.tmdb.org",
+		ApiKey:               "YOUR_TMDB_API_KEY", // 请替换为您的 TMDB API Key
+		ApiURL:               "https://api.themoviedb.org",
+		ImageURL:             "https://image.tmdb.org",
+		CertificationCountry: "US",
+		Cache: CacheConfig{
+			Type:                 "sqlite",
+			StaleWhileRevalidate: true,
+		},
 	},
 	Fanart: FanartConfig{
 		ApiKey: "YOUR_FANART_API_KEY", // 请替换为您的 Fanart API Key
 		ApiURL: "https://webservice.fanart.tv",
+		Cache: CacheConfig{
+			Type:                 "sqlite",
+			StaleWhileRevalidate: true,
+		},
+	},
+	TVDB: TVDBConfig{
+		ApiURL: "https://api4.thetvdb.com",
+		Cache: CacheConfig{
+			Type:                 "sqlite",
+			StaleWhileRevalidate: true,
+		},
+	},
+	Bangumi: BangumiConfig{
+		ApiURL: "https://api.bgm.tv",
+		Cache: CacheConfig{
+			Type:                 "sqlite",
+			StaleWhileRevalidate: true,
+		},
+	},
+	Douban: DoubanConfig{
+		ApiURL:    "https://m.douban.com",
+		SearchURL: "https://movie.douban.com",
+		Cache: CacheConfig{
+			Type:                 "sqlite",
+			StaleWhileRevalidate: true,
+		},
 	},
 	Storages: []StorageConfig{
 		{
@@ -31,8 +62,10 @@ var defaultConfig = Configuration{
 	},
 	Media: MediaConfig{
 		Format: FormatConfig{
-			Movie: "{{.Title}} ({{.Year}})/{{.Title}} ({{.Year}}){{if .Part}} -{{.Part}}{{end}}{{if .Version}} -v{{.Version}}{{end}}{{if .ReleaseGroups}} -{{end}}{{range .ReleaseGroups}}@{{.}}{{end}}{{if .ResourcePix}} -{{.ResourcePix}}{{end}}{{if .ResourceType}} -{{.ResourceType}}{{end}}{{if .ResourceEffect}} -{{end}}{{range .ResourceEffect}}@{{.}}{{end}}{{if .Platform}} -{{.Platform}}{{end}}{{if .VideoEncode}} -{{.VideoEncode}}{{end}}{{if .AudioEncode}} -{{.AudioEncode}}{{end}}{{.FileExtension}}",
-			TV:    "{{.Title}} ({{.Year}})/Season {{.Season}}/{{.Title}} {{.SeasonStr}}{{.EpisodeStr}}{{if .EpisodeTitle}} {{.EpisodeTitle}}{{end}}{{if .Part}} -{{.Part}}{{end}}{{if .Version}} -v{{.Version}}{{end}}{{if .ReleaseGroups}} -{{end}}{{range .ReleaseGroups}}@{{.}}{{end}}{{if .ResourcePix}} -{{.ResourcePix}}{{end}}{{if .ResourceType}} -{{.ResourceType}}{{end}}{{if .ResourceEffect}} -{{end}}{{range .ResourceEffect}}@{{.}}{{end}}{{if .Platform}} -{{.Platform}}{{end}}{{if .VideoEncode}} -{{.VideoEncode}}{{end}}{{if .AudioEncode}} -{{.AudioEncode}}{{end}}{{.FileExtension}}",
+			Movie: "{{.Title}} ({{.Year}})/{{.Title}} ({{.Year}}){{if .Edition}} {edition-{{.Edition}}}{{end}}{{if .Part}} -{{.Part}}{{end}}{{if .Version}} -v{{.Version}}{{end}}{{if .ReleaseGroups}} -{{end}}{{range .ReleaseGroups}}@{{.}}{{end}}{{if .ResourcePix}} -{{.ResourcePix}}{{end}}{{if .ResourceType}} -{{.ResourceType}}{{end}}{{if .ResourceEffect}} -{{end}}{{range .ResourceEffect}}@{{.}}{{end}}{{if .Platform}} -{{.Platform}}{{end}}{{if .VideoEncode}} -{{.VideoEncode}}{{end}}{{if .AudioEncode}} -{{.AudioEncode}}{{end}}{{.FileExtension}}",
+			TV:    "{{.Title}} ({{.Year}})/Season {{.Season}}/{{.Title}} {{.SeasonStr}}{{.EpisodeStr}}{{if and (eq .Episode -1) .EpisodeDate}} {{.EpisodeDate}}{{end}}{{if .EpisodeTitle}} {{.EpisodeTitle}}{{end}}{{if .Part}} -{{.Part}}{{end}}{{if .Version}} -v{{.Version}}{{end}}{{if .ReleaseGroups}} -{{end}}{{range .ReleaseGroups}}@{{.}}{{end}}{{if .ResourcePix}} -{{.ResourcePix}}{{end}}{{if .ResourceType}} -{{.ResourceType}}{{end}}{{if .ResourceEffect}} -{{end}}{{range .ResourceEffect}}@{{.}}{{end}}{{if .Platform}} -{{.Platform}}{{end}}{{if .VideoEncode}} -{{.VideoEncode}}{{end}}{{if .AudioEncode}} -{{.AudioEncode}}{{end}}{{.FileExtension}}",
+			Extra: "{{.ExtraName}}{{.FileExtension}}",
+			Music: "{{.AlbumArtist}}/{{.Album}}{{if .Year}} ({{.Year}}){{end}}/{{if .Track}}{{.Disc}}-{{.Track}} {{end}}{{.Title}}{{.FileExtension}}",
 		},
 	},
 }
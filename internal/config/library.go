@@ -0,0 +1,43 @@
+package config
+
+import (
+	"MediaTools/internal/schemas/storage"
+	"strings"
+)
+
+// MatchSrcLibrary 查找源文件所在的媒体库，不属于任何媒体库时返回 nil
+func MatchSrcLibrary(path storage.StoragePath) *LibraryConfig {
+	return matchLibrary(path, func(lib *LibraryConfig) (storage.StorageType, string) {
+		return lib.SrcType, lib.SrcPath
+	})
+}
+
+// MatchDstLibrary 查找目标文件所在的媒体库，不属于任何媒体库时返回 nil
+func MatchDstLibrary(path storage.StoragePath) *LibraryConfig {
+	return matchLibrary(path, func(lib *LibraryConfig) (storage.StorageType, string) {
+		return lib.DstType, lib.DstPath
+	})
+}
+
+// matchLibrary 按目录边界匹配媒体库（/media/tv 不匹配 /media/tv2），多个媒体库的路径嵌套时使用最长的匹配
+func matchLibrary(path storage.StoragePath, get func(*LibraryConfig) (storage.StorageType, string)) *LibraryConfig {
+	var (
+		matched    *LibraryConfig
+		matchedLen int
+	)
+	for i := range Media.Libraries {
+		lib := &Media.Libraries[i]
+		storageType, libPath := get(lib)
+		if storageType != path.GetStorageType() || libPath == "" {
+			continue
+		}
+		libPath = strings.TrimSuffix(libPath, "/")
+		if path.GetPath() != libPath && !strings.HasPrefix(path.GetPath(), libPath+"/") {
+			continue
+		}
+		if matched == nil || len(libPath) > matchedLen {
+			matched, matchedLen = lib, len(libPath)
+		}
+	}
+	return matched
+}
@@ -11,28 +11,101 @@ type LogConfig struct {
 }
 
 type TMDBConfig struct {
-	ApiURL               string `json:"api_url" yaml:"api_url"`                               // TMDB API URL
-	ImageURL             string `json:"image_url" yaml:"image_url"`                           // 图片 API URL
-	ApiKey               string `json:"api_key" yaml:"api_key"`                               // API Key
-	Language             string `json:"language" yaml:"language"`                             // 语言
-	IncludeImageLanguage string `json:"include_image_language" yaml:"include_image_language"` // 包含的图片语言
+	ApiURL               string           `json:"api_url" yaml:"api_url"`                               // TMDB API URL
+	ImageURL             string           `json:"image_url" yaml:"image_url"`                           // 图片 API URL
+	ApiKey               string           `json:"api_key" yaml:"api_key"`                               // API Key
+	Language             string           `json:"language" yaml:"language"`                             // 语言
+	FallbackLanguages    []string         `json:"fallback_languages" yaml:"fallback_languages"`         // 回退语言链（如 zh-TW、ja-JP、en-US），主语言缺少标题、简介等文本时依次使用其翻译
+	IncludeImageLanguage string           `json:"include_image_language" yaml:"include_image_language"` // 包含的图片语言
+	CertificationCountry string           `json:"certification_country" yaml:"certification_country"`   // NFO 分级使用的国家/地区（ISO 3166-1），为空时使用 US
+	Cache                CacheConfig      `json:"cache" yaml:"cache"`                                   // 缓存配置
+	TitleIndex           TitleIndexConfig `json:"title_index" yaml:"title_index"`                       // 本地标题索引配置
+}
+
+// 基于 TMDB 每日 ID 导出文件的本地标题索引
+// 识别时先在索引中筛选候选条目，只通过 API 获取详情
+type TitleIndexConfig struct {
+	Enable        bool    `json:"enable" yaml:"enable"`                 // 是否启用
+	MovieFile     string  `json:"movie_file" yaml:"movie_file"`         // 电影导出文件（movie_ids_MM_DD_YYYY.json.gz）路径，为目录时使用其中日期最新的文件
+	TVFile        string  `json:"tv_file" yaml:"tv_file"`               // 电视剧导出文件（tv_series_ids_MM_DD_YYYY.json.gz）路径，为目录时使用其中日期最新的文件
+	MinPopularity float64 `json:"min_popularity" yaml:"min_popularity"` // 热度低于该值的条目不加入索引，用于减少内存占用
 }
 
 type FanartConfig struct {
-	ApiKey    string   `json:"api_key" yaml:"api_key"`
-	ApiURL    string   `json:"api_url" yaml:"api_url"`
-	Languages []string `json:"languages" yaml:"languages"` // 语言顺序
+	ApiKey    string      `json:"api_key" yaml:"api_key"`
+	ApiURL    string      `json:"api_url" yaml:"api_url"`
+	Languages []string    `json:"languages" yaml:"languages"` // 语言顺序
+	Cache     CacheConfig `json:"cache" yaml:"cache"`         // 缓存配置
+}
+
+type TVDBConfig struct {
+	ApiKey   string      `json:"api_key" yaml:"api_key"`   // API Key（v4）
+	Pin      string      `json:"pin" yaml:"pin"`           // 订阅者 PIN，使用用户订阅的 API Key 时需要
+	ApiURL   string      `json:"api_url" yaml:"api_url"`   // API URL
+	Language string      `json:"language" yaml:"language"` // 翻译语言（ISO 639-2，如 zho、eng），为空时使用原始语言
+	Cache    CacheConfig `json:"cache" yaml:"cache"`       // 缓存配置
+}
+
+type BangumiConfig struct {
+	AccessToken string      `json:"access_token" yaml:"access_token"` // 个人令牌，可选，用于获取 NSFW 条目
+	ApiURL      string      `json:"api_url" yaml:"api_url"`           // API URL
+	Cache       CacheConfig `json:"cache" yaml:"cache"`               // 缓存配置
+}
+
+type DoubanConfig struct {
+	ApiURL    string      `json:"api_url" yaml:"api_url"`       // 移动版接口地址
+	SearchURL string      `json:"search_url" yaml:"search_url"` // 搜索建议接口地址
+	Cookie    string      `json:"cookie" yaml:"cookie"`         // 登录 Cookie，可选
+	Cache     CacheConfig `json:"cache" yaml:"cache"`           // 缓存配置
+}
+
+type CacheConfig struct {
+	Type                 string `json:"type" yaml:"type"`                                     // 缓存类型：memory / sqlite
+	StaleWhileRevalidate bool   `json:"stale_while_revalidate" yaml:"stale_while_revalidate"` // 缓存过期后先返回旧数据并在后台刷新
 }
 
 type MediaConfig struct {
 	Libraries  []LibraryConfig  `json:"libraries" yaml:"libraries"`     // 媒体库路径列表
 	Format     FormatConfig     `json:"format" yaml:"format"`           // 媒体格式配置
 	CustomWord CustomWordConfig `json:"custom_word" yaml:"custom_word"` // 自定义识别词配置
+	Subtitle   SubtitleConfig   `json:"subtitle" yaml:"subtitle"`       // 字幕处理配置
+	Artwork    ArtworkConfig    `json:"artwork" yaml:"artwork"`         // 刮削图片配置
+	ActorThumb ActorThumbConfig `json:"actor_thumb" yaml:"actor_thumb"` // 演员头像本地化配置
+	Collection CollectionConfig `json:"collection" yaml:"collection"`   // 电影合集配置
+}
+
+type CollectionConfig struct {
+	Artwork bool   `json:"artwork" yaml:"artwork"` // 是否下载合集海报和背景图
+	Dir     string `json:"dir" yaml:"dir"`         // 合集图片目录（相对于媒体库目标路径），为空时使用按合集整理的电影目录的上级目录
+}
+
+type ActorThumbConfig struct {
+	Enable     bool   `json:"enable" yaml:"enable"`           // 是否下载演员头像到本地
+	Location   string `json:"location" yaml:"location"`       // 保存位置：library 媒体库根目录 .actors（Kodi 约定）、jellyfin Jellyfin 元数据 People 目录，为空时使用 library
+	PeoplePath string `json:"people_path" yaml:"people_path"` // Jellyfin 元数据 People 目录，与媒体库目标路径位于同一存储
+	Limit      int    `json:"limit" yaml:"limit"`             // 下载前 N 位演员的头像，0 使用默认值 15
+}
+
+type ArtworkConfig struct {
+	Languages []string `json:"languages" yaml:"languages"`   // 图片语言优先级，null 表示无文字图片，为空时依次使用 TMDB 语言、英文、无文字
+	MinWidth  int      `json:"min_width" yaml:"min_width"`   // 最小宽度，0 不限制
+	MinHeight int      `json:"min_height" yaml:"min_height"` // 最小高度，0 不限制
+	MaxSize   int      `json:"max_size" yaml:"max_size"`     // 最大边长，超过时等比缩小，0 不限制
+	Format    string   `json:"format" yaml:"format"`         // 重新编码格式：jpeg、png（不支持输出 WebP），为空时保持原格式
+	Quality   int      `json:"quality" yaml:"quality"`       // 有损编码质量（1-100），0 使用默认值 90
+}
+
+type SubtitleConfig struct {
+	Enable  bool   `json:"enable" yaml:"enable"`   // 整理时是否处理字幕（转换为 UTF-8 等）
+	Chinese string `json:"chinese" yaml:"chinese"` // 简繁转换：s2t 简转繁、t2s 繁转简，为空不转换
+	Format  string `json:"format" yaml:"format"`   // 目标格式：srt、ass，为空保持原格式
 }
 
 type FormatConfig struct {
 	Movie string `json:"movie" yaml:"movie"` // 电影格式
 	TV    string `json:"tv" yaml:"tv"`       // 电视剧格式
+	Extra string `json:"extra" yaml:"extra"` // 附加内容（预告片、特辑等）格式，相对于所属媒体的附加内容目录
+	Music string `json:"music" yaml:"music"` // 音乐格式
 }
 
 type LibraryConfig struct {
@@ -46,6 +119,8 @@ type LibraryConfig struct {
 	OrganizeByCategory bool                 `json:"organize_by_category" yaml:"organize_by_category"` // 是否按分类分文件夹
 	Scrape             bool                 `json:"scrape" yaml:"scrape"`                             // 是否刮削
 	Notify             bool                 `json:"notify" yaml:"notify"`                             // 是否通知
+	ScrapeProfile      string               `json:"scrape_profile" yaml:"scrape_profile"`             // 刮削输出方案：kodi、jellyfin、emby、plex，为空时使用 kodi
+	Providers          []string             `json:"providers" yaml:"providers"`                       // 元数据来源优先级：tmdb、tvdb、bangumi、douban，为空时只使用 tmdb
 }
 
 type CustomWordConfig struct {
@@ -55,8 +130,9 @@ type CustomWordConfig struct {
 }
 
 type StorageConfig struct {
-	Type storage.StorageType `json:"type" yaml:"type"`
-	Data map[string]string   `json:"data" yaml:"data"`
+	Type    storage.StorageType `json:"type" yaml:"type"`
+	Data    map[string]string   `json:"data" yaml:"data"`
+	StrmURL string              `json:"strm_url,omitempty" yaml:"strm_url,omitempty"` // strm 文件中的播放地址模板，为空时不支持 Strm 转移方式
 }
 
 type DataBaseConfig struct {
@@ -74,6 +150,10 @@ type Configuration struct {
 	TMDB   TMDBConfig   `json:"tmdb" yaml:"tmdb"`
 	Fanart FanartConfig `json:"fanart" yaml:"fanart"`
 
+	TVDB    TVDBConfig    `json:"tvdb" yaml:"tvdb"`
+	Bangumi BangumiConfig `json:"bangumi" yaml:"bangumi"`
+	Douban  DoubanConfig  `json:"douban" yaml:"douban"`
+
 	// 存储设置
 	Storages []StorageConfig `json:"storages" yaml:"storages"`
 
@@ -39,6 +39,9 @@ func (c *Configuration) applyConfig() {
 	Log = c.Log
 	TMDB = c.TMDB
 	Fanart = c.Fanart
+	TVDB = c.TVDB
+	Bangumi = c.Bangumi
+	Douban = c.Douban
 	Storages = c.Storages
 	Media = c.Media
 }
@@ -103,12 +106,61 @@ func (c *Configuration) check() {
 		needSave = true
 	}
 
+	if c.TMDB.Cache.Type == "" {
+		logrus.Warning("TMDB 缓存类型未设置，使用默认配置")
+		c.TMDB.Cache = defaultConfig.TMDB.Cache
+		needSave = true
+	}
+
 	if c.Fanart.ApiURL == "" {
 		logrus.Warning("Fanart API URL 配置未设置，使用默认配置")
 		c.Fanart.ApiURL = defaultConfig.Fanart.ApiURL
 		needSave = true
 	}
 
+	if c.Fanart.Cache.Type == "" {
+		logrus.Warning("Fanart 缓存类型未设置，使用默认配置")
+		c.Fanart.Cache = defaultConfig.Fanart.Cache
+		needSave = true
+	}
+
+	if c.TVDB.ApiURL == "" {
+		logrus.Warning("TheTVDB API URL 配置未设置，使用默认配置")
+		c.TVDB.ApiURL = defaultConfig.TVDB.ApiURL
+		needSave = true
+	}
+
+	if c.TVDB.Cache.Type == "" {
+		logrus.Warning("TheTVDB 缓存类型未设置，使用默认配置")
+		c.TVDB.Cache = defaultConfig.TVDB.Cache
+		needSave = true
+	}
+
+	if c.Bangumi.ApiURL == "" {
+		logrus.Warning("Bangumi API URL 配置未设置，使用默认配置")
+		c.Bangumi.ApiURL = defaultConfig.Bangumi.ApiURL
+		needSave = true
+	}
+
+	if c.Bangumi.Cache.Type == "" {
+		logrus.Warning("Bangumi 缓存类型未设置，使用默认配置")
+		c.Bangumi.Cache = defaultConfig.Bangumi.Cache
+		needSave = true
+	}
+
+	if c.Douban.ApiURL == "" || c.Douban.SearchURL == "" {
+		logrus.Warning("豆瓣接口地址配置未设置，使用默认配置")
+		c.Douban.ApiURL = defaultConfig.Douban.ApiURL
+		c.Douban.SearchURL = defaultConfig.Douban.SearchURL
+		needSave = true
+	}
+
+	if c.Douban.Cache.Type == "" {
+		logrus.Warning("豆瓣缓存类型未设置，使用默认配置")
+		c.Douban.Cache = defaultConfig.Douban.Cache
+		needSave = true
+	}
+
 	if len(c.Storages) == 0 {
 		logrus.Warning("存储配置未设置，使用默认配置")
 		c.Storages = defaultConfig.Storages
@@ -120,6 +172,16 @@ func (c *Configuration) check() {
 		c.Media.Format = defaultConfig.Media.Format
 		needSave = true
 	}
+	if c.Media.Format.Extra == "" {
+		logrus.Warning("附加内容格式配置未设置，使用默认配置")
+		c.Media.Format.Extra = defaultConfig.Media.Format.Extra
+		needSave = true
+	}
+	if c.Media.Format.Music == "" {
+		logrus.Warning("音乐格式配置未设置，使用默认配置")
+		c.Media.Format.Music = defaultConfig.Media.Format.Music
+		needSave = true
+	}
 
 	if needSave {
 		logrus.Info("需要更新配置文件")
@@ -0,0 +1,17 @@
+package fanart_controller
+
+import "MediaTools/internal/pkg/cache"
+
+// CacheStats 获取缓存统计信息
+func CacheStats() (*cache.Stats, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	return client.CacheStats()
+}
+
+// PurgeCache 清理缓存，expiredOnly 为 true 时只清理已过期的条目
+func PurgeCache(expiredOnly bool) (int64, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	return client.PurgeCache(expiredOnly)
+}
@@ -3,17 +3,18 @@ package fanart_controller
 import (
 	"MediaTools/internal/config"
 	"MediaTools/internal/pkg/fanart/v3"
+	"context"
 	"encoding/json"
 	"image"
 
 	"github.com/sirupsen/logrus"
 )
 
-func GetMovieImagesData(imdbID string) (*fanart.MovieImagesData, error) {
+func GetMovieImagesData(ctx context.Context, imdbID string) (*fanart.MovieImagesData, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
-	data, err := client.GetMovieImagesData(imdbID)
+	data, err := client.GetMovieImagesData(ctx, imdbID)
 	if err != nil {
 		return nil, err
 	}
@@ -30,11 +31,11 @@ func GetMovieImagesData(imdbID string) (*fanart.MovieImagesData, error) {
 	return data, nil
 }
 
-func GetTVImagesData(thetvdbID int) (*fanart.TVImagesData, error) {
+func GetTVImagesData(ctx context.Context, thetvdbID int) (*fanart.TVImagesData, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
-	data, err := client.GetTVImagesData(thetvdbID)
+	data, err := client.GetTVImagesData(ctx, thetvdbID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +61,17 @@ func GetTVImagesData(thetvdbID int) (*fanart.TVImagesData, error) {
 
 }
 
-func DownloadImage(url string) (image.Image, error) {
+func DownloadImage(ctx context.Context, url string) (image.Image, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
-	return client.DownloadImage(url)
+	return client.DownloadImage(ctx, url)
+}
+
+// DownloadImageData 下载图片原始数据
+func DownloadImageData(ctx context.Context, url string) ([]byte, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return client.DownloadImageData(ctx, url)
 }
@@ -2,8 +2,10 @@ package fanart_controller
 
 import (
 	"MediaTools/internal/config"
+	"MediaTools/internal/database"
 	"MediaTools/internal/outbound"
 	"MediaTools/internal/pkg/fanart/v3"
+	"fmt"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -25,6 +27,15 @@ func Init() error {
 	}
 	opts = append(opts, fanart.CustomHTTPClient(outbound.GetHTTPClient()))
 
+	cache, err := database.NewCache(config.Fanart.Cache, "fanart")
+	if err != nil {
+		return fmt.Errorf("创建 Fanart 缓存失败: %w", err)
+	}
+	opts = append(opts,
+		fanart.CustomCache(cache),
+		fanart.CustomStaleWhileRevalidate(config.Fanart.Cache.StaleWhileRevalidate),
+	)
+
 	c, err := fanart.NewClient(config.Fanart.ApiKey, opts...)
 	if err != nil {
 		return err
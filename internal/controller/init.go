@@ -3,6 +3,7 @@ package controller
 import (
 	"MediaTools/internal/controller/fanart_controller"
 	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/controller/provider_controller"
 	"MediaTools/internal/controller/recognize_controller"
 	"MediaTools/internal/controller/scrape_controller"
 	"MediaTools/internal/controller/storage_controller"
@@ -15,6 +16,7 @@ type InitFunc func() error
 var initFuncs = []InitFunc{
 	tmdb_controller.Init,
 	fanart_controller.Init,
+	provider_controller.Init,
 	scrape_controller.Init,
 	storage_controller.Init,
 	library_controller.Init,
@@ -0,0 +1,137 @@
+package library_controller
+
+import (
+	"MediaTools/extensions"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/subtitle"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 常见的字幕子目录名称
+var subtitleFolderRe = regexp.MustCompile(`(?i)^(subs?|subtitles?|字幕)$`)
+
+// FindCompanions 查找视频文件的字幕/音轨伴随文件
+// 扫描视频所在目录、其中的字幕子目录（Subs、Subtitles 等）以及字幕子目录下以视频/剧集命名的目录，
+// 文件名以视频文件名开头的直接匹配，否则按解析到的季集号匹配（电影匹配所有不含集号的文件）
+func FindCompanions(ctx context.Context, srcFile storage.StoragePath) ([]storage.StoragePath, error) {
+	videoBase := strings.TrimSuffix(srcFile.GetName(), srcFile.GetExt())
+	videoMeta := meta.ParseVideoMeta(srcFile.GetName())
+	exts := append(slices.Clone(extensions.SubtitleExtensions), extensions.AudioTrackExtensions...)
+
+	var companions []storage.StoragePath
+	var scan func(dir storage.StoragePath, hint string, depth int) error
+	scan = func(dir storage.StoragePath, hint string, depth int) error {
+		entries, err := storage_controller.List(dir)
+		if err != nil {
+			return err
+		}
+		for entry, err := range entries {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("查找字幕/音轨文件操作被取消: %v", ctx.Err())
+			default:
+			}
+			if err != nil {
+				logrus.Warningf("遍历目录 %s 失败：%v", dir, err)
+				continue
+			}
+			if entry.GetPath() == dir.GetPath() {
+				continue // 跳过目录本身
+			}
+
+			if entry.GetFileType() == storage.FileTypeDirectory {
+				switch {
+				case depth == 0 && subtitleFolderRe.MatchString(entry.GetName()): // 字幕子目录
+					err = scan(entry, "", depth+1)
+				case depth == 1 && matchCompanion(videoBase, videoMeta, entry.GetName()): // 字幕子目录下以视频命名的目录
+					err = scan(entry, entry.GetName(), depth+1)
+				}
+				if err != nil {
+					logrus.Warningf("读取字幕目录 %s 失败：%v", entry, err)
+				}
+				continue
+			}
+
+			if !slices.Contains(exts, entry.LowerExt()) {
+				continue
+			}
+			if hint != "" || matchCompanion(videoBase, videoMeta, strings.TrimSuffix(entry.GetName(), entry.GetExt())) {
+				companions = append(companions, entry)
+			}
+		}
+		return nil
+	}
+
+	if err := scan(srcFile.Parent(), "", 0); err != nil {
+		return nil, err
+	}
+	return companions, nil
+}
+
+// matchCompanion 判断文件（或目录）名称是否属于该视频
+func matchCompanion(videoBase string, videoMeta *meta.VideoMeta, name string) bool {
+	if strings.HasPrefix(name, videoBase) {
+		return true
+	}
+	m := meta.ParseVideoMeta(name)
+	if videoMeta.Episode == -1 { // 电影：不含集号的文件都属于该视频
+		return m.Episode == -1
+	}
+	return m.Episode == videoMeta.Episode &&
+		(m.Season == -1 || videoMeta.Season == -1 || m.Season == videoMeta.Season)
+}
+
+// CompanionName 生成伴随文件的目标文件名：<视频文件名>.<语言>[.forced][.sdh].<扩展名>
+// ext 为目标扩展名，used 记录已使用的文件名，重名时在语言前添加序号
+func CompanionName(dstVideo storage.StoragePath, companion storage.StoragePath, ext string, used map[string]struct{}) string {
+	videoBase := strings.TrimSuffix(dstVideo.GetName(), dstVideo.GetExt())
+	suffix := subtitle.Parse(strings.TrimSuffix(companion.GetName(), companion.GetExt())).Suffix()
+
+	name := videoBase + suffix + ext
+	for i := 2; ; i++ {
+		if _, ok := used[name]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s.%d%s%s", videoBase, i, suffix, ext)
+	}
+	used[name] = struct{}{}
+	return name
+}
+
+// TransferCompanions 查找并转移视频文件的字幕/音轨伴随文件，单个文件转移失败不影响其他文件
+// 启用字幕处理时，文本字幕按配置处理后写入目标目录
+func TransferCompanions(ctx context.Context, srcFile storage.StoragePath, dstFile storage.StoragePath, transferType storage.TransferType) error {
+	companions, err := FindCompanions(ctx, srcFile)
+	if err != nil {
+		return err
+	}
+	transferType = companionTransferType(transferType)
+	opts, process := SubtitleOptionsFromConfig()
+	used := make(map[string]struct{})
+	for _, companion := range companions {
+		ext := companion.LowerExt()
+		if process && opts.Format != "" && subtitle.ParseFormat(ext) != "" {
+			ext = opts.Format.Ext()
+		}
+		dstPath := dstFile.Parent().Join(CompanionName(dstFile, companion, ext, used))
+		logrus.Debugf("转移字幕/音轨文件：%s -> %s", companion, dstPath)
+		transfer := storage_controller.TransferFile
+		if process {
+			transfer = func(src, dst storage.StoragePath, transferType storage.TransferType) error {
+				return transferSubtitle(src, dst, transferType, opts)
+			}
+		}
+		if err := transfer(companion, dstPath, transferType); err != nil {
+			logrus.Warningf("转移字幕/音轨文件失败：%v", err)
+		}
+	}
+	return nil
+}
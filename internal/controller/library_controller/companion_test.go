@@ -0,0 +1,69 @@
+package library_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCompanions(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+
+	root := filepath.ToSlash(t.TempDir())
+	files := []string{
+		"Show.S01E01.1080p.mkv",
+		"Show.S01E01.1080p.chs.ass",
+		"Show.S01E01.1080p.cht.ass",
+		"Show.S01E02.1080p.mkv",
+		"Show.S01E02.1080p.chs.ass",
+		"Subs/Show.S01E01.eng.srt",
+		"Subs/Show.S01E02.eng.srt",
+		"Subs/Show.S01E01.1080p/2_English.srt",
+		"Other/Show.S01E01.jpn.srt",
+	}
+	for _, f := range files {
+		p := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	srcFile := storage.NewStoragePath(storage.StorageLocal, root+"/Show.S01E01.1080p.mkv")
+	companions, err := library_controller.FindCompanions(context.Background(), srcFile)
+	require.NoError(t, err)
+
+	var got []string
+	for _, c := range companions {
+		got = append(got, strings.TrimPrefix(c.GetPath(), root+"/"))
+	}
+	slices.Sort(got)
+	require.Equal(t, []string{
+		"Show.S01E01.1080p.chs.ass",
+		"Show.S01E01.1080p.cht.ass",
+		"Subs/Show.S01E01.1080p/2_English.srt",
+		"Subs/Show.S01E01.eng.srt",
+	}, got)
+
+	dstFile := storage.NewStoragePath(storage.StorageLocal, "/media/Show (2020)/Season 1/Show S01E01.mkv")
+	used := make(map[string]struct{})
+	var names []string
+	for _, c := range companions {
+		names = append(names, library_controller.CompanionName(dstFile, c, c.LowerExt(), used))
+	}
+	slices.Sort(names)
+	require.Equal(t, []string{
+		"Show S01E01.2.en.srt",
+		"Show S01E01.en.srt",
+		"Show S01E01.zh-CN.ass",
+		"Show S01E01.zh-TW.ass",
+	}, names)
+}
@@ -0,0 +1,191 @@
+package library_controller
+
+import (
+	"MediaTools/extensions"
+	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/scrape_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/controller/task_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"fmt"
+	pathlib "path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DiscType 光盘结构类型
+type DiscType uint8
+
+const (
+	DiscTypeNone   DiscType = iota // 非光盘结构
+	DiscTypeBluRay                 // 蓝光原盘文件夹（BDMV）
+	DiscTypeDVD                    // DVD 文件夹（VIDEO_TS）
+	DiscTypeISO                    // 光盘镜像文件
+)
+
+func (t DiscType) String() string {
+	switch t {
+	case DiscTypeBluRay:
+		return "BluRay"
+	case DiscTypeDVD:
+		return "DVD"
+	case DiscTypeISO:
+		return "ISO"
+	default:
+		return "None"
+	}
+}
+
+// IsFolder 是否为文件夹形式的光盘结构
+func (t DiscType) IsFolder() bool {
+	return t == DiscTypeBluRay || t == DiscTypeDVD
+}
+
+// 光盘文件夹的标志目录及其索引文件
+var discMarkers = []struct {
+	dir      string
+	index    string
+	discType DiscType
+}{
+	{"BDMV", "index.bdmv", DiscTypeBluRay},
+	{"VIDEO_TS", "VIDEO_TS.IFO", DiscTypeDVD},
+}
+
+// DetectDisc 检测路径是否属于光盘结构
+// path 可以是光盘文件夹、ISO 文件或光盘结构内部的任意文件（如 BDMV/STREAM/00001.m2ts）
+// 返回光盘根路径（包含 BDMV/VIDEO_TS 的文件夹或 ISO 文件）和光盘类型，非光盘结构时返回 DiscTypeNone
+func DetectDisc(path storage.StoragePath) (storage.StoragePath, DiscType, error) {
+	if slices.Contains(extensions.DiscImageExtensions, path.LowerExt()) {
+		return path, DiscTypeISO, nil
+	}
+
+	for p := path; p.GetPath() != p.Parent().GetPath(); p = p.Parent() {
+		for _, marker := range discMarkers {
+			if strings.EqualFold(p.GetName(), marker.dir) { // 位于光盘结构内部
+				exist, err := storage_controller.Exist(p.Join(marker.index))
+				if err != nil {
+					return nil, DiscTypeNone, fmt.Errorf("检查光盘索引文件失败：%v", err)
+				}
+				if exist {
+					return p.Parent(), marker.discType, nil
+				}
+			}
+		}
+	}
+
+	detail, err := storage_controller.GetDetail(path)
+	if err != nil {
+		return nil, DiscTypeNone, fmt.Errorf("获取 %s 详情失败：%v", path, err)
+	}
+	if detail.Type != storage.FileTypeDirectory {
+		return path, DiscTypeNone, nil
+	}
+	for _, marker := range discMarkers { // 光盘文件夹本身
+		exist, err := storage_controller.Exist(path.Join(marker.dir, marker.index))
+		if err != nil {
+			return nil, DiscTypeNone, fmt.Errorf("检查光盘索引文件失败：%v", err)
+		}
+		if exist {
+			return path, marker.discType, nil
+		}
+	}
+	return path, DiscTypeNone, nil
+}
+
+var (
+	discTaskLock sync.Mutex
+	discTasks    = make(map[string]string) // 光盘根路径 -> 正在整理该光盘的任务 ID
+)
+
+// submitDiscTask 提交光盘文件夹的整理任务，同一光盘已有未完成的任务时返回错误
+// 光盘内的多个文件（如 BDMV/STREAM 下的各个 m2ts）都定位到同一光盘根路径，只提交一次，避免并发转移同一目录树
+func submitDiscTask(root storage.StoragePath, fn task.TaskFunc) (*task.Task, error) {
+	discTaskLock.Lock()
+	defer discTaskLock.Unlock()
+
+	for key, id := range discTasks { // 任务完成或取消后会从任务队列中移除
+		if _, err := task_controller.GetTransferTask(id); err != nil {
+			delete(discTasks, key)
+		}
+	}
+	if _, ok := discTasks[root.String()]; ok {
+		return nil, fmt.Errorf("光盘 %s 正在整理中，不能重复转移", root)
+	}
+	t := task_controller.SubmitTransferTask(root.GetName(), fn)
+	discTasks[root.String()] = t.ID
+	return t, nil
+}
+
+// discFolder 获取光盘文件夹的目标路径
+// 电影直接放在电影目录中（Title (Year)/BDMV），格式化名称中没有目录时使用格式化名称作为电影目录
+// 剧集的每一集需要单独的文件夹，使用格式化名称作为文件夹名（Season 1/Title S01E01/BDMV）
+func discFolder(dstDir storage.StoragePath, targetName string, mediaType meta.MediaType) storage.StoragePath {
+	if mediaType == meta.MediaTypeMovie {
+		if dir := pathlib.Dir(targetName); dir != "." {
+			return dstDir.Join(dir)
+		}
+	}
+	return dstDir.Join(targetName)
+}
+
+// ArchiveDisc 整理一个光盘文件夹（BDMV/VIDEO_TS）到指定目录
+// 光盘目录树整体转移到目标文件夹中，电影的 movie.nfo 和图片写在 BDMV/VIDEO_TS 旁，剧集的元数据写在光盘文件夹旁
+// srcDir: 光盘根目录
+// dstDir: 目标目录
+// transferType: 传输类型（复制、移动、链接等）
+// item: 媒体项（包含元数据）
+// info: 识别到的媒体信息（为nil代表无需刮削）
+// 返回值: 目标光盘文件夹和可能的错误
+func ArchiveDisc(
+	ctx context.Context,
+	srcDir storage.StoragePath,
+	dstDir storage.StoragePath,
+	transferType storage.TransferType,
+	item *schemas.MediaItem,
+	info *schemas.MediaInfo,
+) (storage.StoragePath, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	item.FileExtension = "" // 光盘文件夹没有扩展名
+	targetName, err := recognize_controller.FormatVideo(item)
+	if err != nil {
+		return nil, err
+	}
+	dstPath := discFolder(dstDir, targetName, item.MediaType)
+	for _, marker := range discMarkers { // 电影目录可能已存在（如其他版本），只检查光盘结构
+		exist, err := storage_controller.Exist(dstPath.Join(marker.dir))
+		if err != nil {
+			return nil, fmt.Errorf("检查目标文件夹是否存在失败：%v", err)
+		}
+		if exist {
+			return nil, fmt.Errorf("目标文件夹 %s 中已存在光盘结构，跳过转移", dstPath)
+		}
+	}
+
+	logrus.Infof("开始转移光盘文件夹：%s -> %s，转移类型类型：%s", srcDir, dstPath, transferType)
+	err = storage_controller.TransferDir(srcDir, dstPath, transferType)
+	if err != nil {
+		return nil, err
+	}
+
+	if info != nil {
+		logrus.Info("开始生成刮削元数据")
+		dstFile, err := storage_controller.GetDetail(dstPath)
+		if err != nil {
+			return nil, fmt.Errorf("获取目标文件夹信息失败：%w", err)
+		}
+		err = scrape_controller.Scrape(ctx, dstFile, info)
+		if err != nil {
+			logrus.Warningf("刮削数据失败：%v", err)
+		}
+	}
+	return dstPath, nil
+}
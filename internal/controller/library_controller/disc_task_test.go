@@ -0,0 +1,38 @@
+package library_controller
+
+import (
+	"MediaTools/internal/controller/task_controller"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitDiscTask(t *testing.T) {
+	root := storage.NewStoragePath(storage.StorageLocal, "/media/Movie (2020)")
+	release := make(chan struct{})
+	running := func(ctx context.Context) { <-release }
+
+	first, err := submitDiscTask(root, running)
+	require.NoError(t, err)
+
+	// 同一光盘内的其他文件不再重复提交
+	_, err = submitDiscTask(root, running)
+	require.Error(t, err)
+
+	other, err := submitDiscTask(storage.NewStoragePath(storage.StorageLocal, "/media/Other (2021)"), running)
+	require.NoError(t, err)
+	close(release)
+
+	// 任务完成后可以再次提交
+	require.Eventually(t, func() bool {
+		_, err1 := task_controller.GetTransferTask(first.ID)
+		_, err2 := task_controller.GetTransferTask(other.ID)
+		return err1 != nil && err2 != nil
+	}, time.Second, 5*time.Millisecond)
+	again, err := submitDiscTask(root, func(ctx context.Context) {})
+	require.NoError(t, err)
+	require.NotEqual(t, first.ID, again.ID)
+}
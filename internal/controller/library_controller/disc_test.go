@@ -0,0 +1,91 @@
+package library_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectDisc(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+
+	root := filepath.ToSlash(t.TempDir())
+	files := []string{
+		"Inception.2010.1080p.BluRay/BDMV/index.bdmv",
+		"Inception.2010.1080p.BluRay/BDMV/STREAM/00001.m2ts",
+		"Heat.1995.DVD/VIDEO_TS/VIDEO_TS.IFO",
+		"Heat.1995.DVD/VIDEO_TS/VTS_01_1.VOB",
+		"Broken/BDMV/STREAM/00001.m2ts",
+		"Movie.2020.mkv",
+	}
+	for _, f := range files {
+		p := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	tests := []struct {
+		path     string
+		root     string
+		discType library_controller.DiscType
+	}{
+		{"Inception.2010.1080p.BluRay", "Inception.2010.1080p.BluRay", library_controller.DiscTypeBluRay},
+		{"Inception.2010.1080p.BluRay/BDMV/STREAM/00001.m2ts", "Inception.2010.1080p.BluRay", library_controller.DiscTypeBluRay},
+		{"Heat.1995.DVD/VIDEO_TS/VTS_01_1.VOB", "Heat.1995.DVD", library_controller.DiscTypeDVD},
+		{"Heat.1995.DVD", "Heat.1995.DVD", library_controller.DiscTypeDVD},
+		{"Broken/BDMV/STREAM/00001.m2ts", "Broken/BDMV/STREAM/00001.m2ts", library_controller.DiscTypeNone},
+		{"Movie.2020.mkv", "Movie.2020.mkv", library_controller.DiscTypeNone},
+		{"Avatar.2009.ISO", "Avatar.2009.ISO", library_controller.DiscTypeISO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			discRoot, discType, err := library_controller.DetectDisc(storage.NewStoragePath(storage.StorageLocal, root+"/"+tt.path))
+			require.NoError(t, err)
+			require.Equal(t, tt.discType, discType)
+			require.Equal(t, root+"/"+tt.root, discRoot.GetPath())
+		})
+	}
+}
+
+func TestArchiveDisc(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+	format := config.Media.Format
+	t.Cleanup(func() {
+		config.Media.Format = format
+		recognize_controller.InitFormatTemplates()
+	})
+	config.Media.Format.Movie = "{{.Title}} ({{.Year}})/{{.Title}} ({{.Year}}) -{{.Edition}}{{.FileExtension}}"
+	require.NoError(t, recognize_controller.InitFormatTemplates())
+
+	root := filepath.ToSlash(t.TempDir())
+	for _, f := range []string{"src/Inception.2010.BluRay/BDMV/index.bdmv", "src/Inception.2010.BluRay/CERTIFICATE/id.bdmv", "dst/Inception (2010)/Inception (2010) -Theatrical.mkv"} {
+		p := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	// 电影的光盘结构直接放在电影目录中，不再嵌套一层格式化名称的文件夹
+	srcDir := storage.NewStoragePath(storage.StorageLocal, root+"/src/Inception.2010.BluRay")
+	dstDir := storage.NewStoragePath(storage.StorageLocal, root+"/dst")
+	item := &schemas.MediaItem{Title: "Inception", Year: 2010, MediaType: meta.MediaTypeMovie, Edition: "Extended"}
+	dstPath, err := library_controller.ArchiveDisc(context.Background(), srcDir, dstDir, storage.TransferCopy, item, nil)
+	require.NoError(t, err)
+	require.Equal(t, root+"/dst/Inception (2010)", dstPath.GetPath())
+	require.FileExists(t, filepath.Join(root, "dst/Inception (2010)/BDMV/index.bdmv"))
+	require.FileExists(t, filepath.Join(root, "dst/Inception (2010)/CERTIFICATE/id.bdmv"))
+
+	_, err = library_controller.ArchiveDisc(context.Background(), srcDir, dstDir, storage.TransferCopy, item, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package library_controller
+
+import (
+	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"fmt"
+	pathlib "path"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ArchiveExtra 整理一个附加内容文件（预告片、特辑、NCOP/NCED 等）到所属媒体的附加内容目录
+// 所属媒体目录由电影/剧集格式模板决定：电影为影片所在目录，剧集有季时为季目录，否则为剧集目录
+// 附加内容不进行刮削，避免覆盖所属媒体的元数据
+// srcFile: 源文件
+// dstDir: 目标目录
+// transferType: 传输类型（复制、移动、链接等）
+// item: 媒体项（包含元数据）
+// 返回值: 目标文件信息和可能的错误
+func ArchiveExtra(
+	ctx context.Context,
+	srcFile storage.StoragePath,
+	dstDir storage.StoragePath,
+	transferType storage.TransferType,
+	item *schemas.MediaItem,
+	_ *schemas.MediaInfo,
+) (storage.StoragePath, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	ownerName, err := recognize_controller.FormatVideo(item)
+	if err != nil {
+		return nil, err
+	}
+	ownerDir := pathlib.Dir(ownerName)
+	if item.MediaType == meta.MediaTypeTV && item.Season == -1 {
+		ownerDir = pathlib.Dir(ownerDir) // 跳过季目录
+	}
+	extraName, err := recognize_controller.FormatExtra(item)
+	if err != nil {
+		return nil, err
+	}
+
+	dstPath := transferTarget(dstDir.Join(ownerDir, item.ExtraType.FolderName(), extraName), transferType)
+	exist, err := storage_controller.Exist(dstPath)
+	if err != nil {
+		return nil, fmt.Errorf("检查目标文件是否存在失败：%v", err)
+	}
+	if exist {
+		return nil, fmt.Errorf("目标文件 %s 已存在，跳过转移", dstPath)
+	}
+
+	logrus.Infof("开始转移附加内容（%s）：%s -> %s，转移类型类型：%s", item.ExtraType, srcFile, dstPath, transferType)
+	err = storage_controller.TransferFile(srcFile, dstPath, transferType)
+	if err != nil {
+		return nil, err
+	}
+	return dstPath, nil
+}
@@ -0,0 +1,483 @@
+package library_controller
+
+import (
+	"MediaTools/extensions"
+	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/controller/task_controller"
+	"MediaTools/internal/database"
+	"MediaTools/internal/models"
+	"MediaTools/internal/pkg/audiotag"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// 随音乐文件一起转移的封面图片扩展名
+var musicImageExts = []string{".jpg", ".jpeg", ".png", ".webp", ".gif", ".bmp"}
+
+// 随音乐文件一起转移的 CUE 表单和抓轨日志扩展名
+var musicSheetExts = []string{".cue", ".log"}
+
+// 常见的专辑封面文件名（不含扩展名），已存在时不再写入内嵌封面
+var coverNames = []string{"cover", "folder", "front"}
+
+// IsMusicFile 是否为音乐文件，音轨文件（.mka）只作为视频的伴随文件
+func IsMusicFile(path storage.StoragePath) bool {
+	ext := path.LowerExt()
+	return slices.Contains(extensions.AudioExtensions, ext) && !slices.Contains(extensions.AudioTrackExtensions, ext)
+}
+
+// ReadMusicMeta 解析音乐文件元数据，音频标签优先，缺失的字段使用文件名和目录名补全
+// stopDir 为媒体库源目录（可为空），向上查找目录时不会越过该目录
+// 返回元数据和内嵌封面（没有时为 nil）
+func ReadMusicMeta(srcFile storage.StoragePath, stopDir string) (*meta.MusicMeta, *audiotag.Picture) {
+	musicMeta := meta.ParseMusicMeta(srcFile.GetPath(), stopDir)
+	tags, err := readAudioTags(srcFile)
+	if err != nil {
+		logrus.Debugf("读取音频标签失败，使用文件名解析结果：%v", err)
+		return musicMeta, nil
+	}
+	logrus.Debugf("读取到音频标签（%s）：%s", tags.Format, srcFile)
+	applyAudioTags(musicMeta, tags)
+	return musicMeta, tags.Picture
+}
+
+func readAudioTags(srcFile storage.StoragePath) (*audiotag.Tags, error) {
+	reader, err := storage_controller.ReadFile(srcFile)
+	if err != nil {
+		return nil, fmt.Errorf("读取音乐文件 %s 失败: %w", srcFile, err)
+	}
+	defer reader.Close()
+
+	tags, err := audiotag.Read(reader)
+	if err != nil {
+		return nil, fmt.Errorf("解析音乐文件 %s 失败: %w", srcFile, err)
+	}
+	return tags, nil
+}
+
+// applyAudioTags 使用音频标签覆盖文件名解析到的字段，标签为空的字段保持不变
+func applyAudioTags(musicMeta *meta.MusicMeta, tags *audiotag.Tags) {
+	setString := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	setInt := func(dst *int, src int) {
+		if src > 0 {
+			*dst = src
+		}
+	}
+	setString(&musicMeta.Title, tags.Title)
+	setString(&musicMeta.Artist, tags.Artist)
+	setString(&musicMeta.AlbumArtist, tags.AlbumArtist)
+	setString(&musicMeta.Album, tags.Album)
+	setString(&musicMeta.Genre, tags.Genre)
+	setInt(&musicMeta.Track, tags.Track)
+	setInt(&musicMeta.TrackTotal, tags.TrackTotal)
+	setInt(&musicMeta.Disc, tags.Disc)
+	setInt(&musicMeta.DiscTotal, tags.DiscTotal)
+	setInt(&musicMeta.Year, tags.Year)
+	if tags.AlbumArtist == "" && tags.Artist != "" && musicMeta.AlbumArtist == "" {
+		musicMeta.AlbumArtist = tags.Artist
+	}
+}
+
+// ArchiveMusic 整理一个音乐文件及其封面、CUE 表单和抓轨日志到指定目录
+// srcFile: 源文件
+// dstDir: 目标目录
+// transferType: 传输类型（复制、移动、链接等）
+// item: 媒体项（包含音乐元数据）
+// picture: 内嵌封面（为nil代表不写入封面）
+// 返回值: 目标文件信息和可能的错误
+func ArchiveMusic(
+	ctx context.Context,
+	srcFile storage.StoragePath,
+	dstDir storage.StoragePath,
+	transferType storage.TransferType,
+	item *schemas.MediaItem,
+	picture *audiotag.Picture,
+) (storage.StoragePath, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	targetName, err := recognize_controller.FormatMusic(item)
+	if err != nil {
+		return nil, err
+	}
+	dstPath := transferTarget(dstDir.Join(targetName), transferType)
+	exist, err := storage_controller.Exist(dstPath)
+	if err != nil {
+		return nil, fmt.Errorf("检查目标文件是否存在失败：%v", err)
+	}
+	if exist {
+		return nil, fmt.Errorf("目标文件 %s 已存在，跳过转移", dstPath)
+	}
+
+	logrus.Infof("开始转移音乐文件：%s -> %s，转移类型类型：%s", srcFile, dstPath, transferType)
+	if err := storage_controller.TransferFile(srcFile, dstPath, transferType); err != nil {
+		return nil, err
+	}
+
+	logrus.Info("开始转移封面/CUE/日志文件")
+	if err := TransferMusicCompanions(ctx, srcFile, dstPath, transferType); err != nil {
+		logrus.Warningf("查找封面/CUE/日志文件失败，跳过转移：%v", err)
+	}
+	if picture != nil {
+		if err := writeEmbeddedCover(dstPath.Parent(), picture); err != nil {
+			logrus.Warningf("写入内嵌封面失败：%v", err)
+		}
+	}
+	return dstPath, nil
+}
+
+// FindMusicCompanions 查找音乐文件的封面图片、CUE 表单和抓轨日志
+// 扫描音乐文件所在目录，位于碟片目录（CD1、Disc 2 等）中时同时扫描专辑目录
+func FindMusicCompanions(ctx context.Context, srcFile storage.StoragePath) ([]storage.StoragePath, error) {
+	dirs := []storage.StoragePath{srcFile.Parent()}
+	if meta.IsDiscFolder(srcFile.Parent().GetName()) {
+		dirs = append(dirs, srcFile.Parent().Parent())
+	}
+
+	var companions []storage.StoragePath
+	for i, dir := range dirs {
+		entries, err := storage_controller.List(dir)
+		if err != nil {
+			return nil, err
+		}
+		for entry, err := range entries {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("查找封面/CUE/日志文件操作被取消: %v", ctx.Err())
+			default:
+			}
+			if err != nil {
+				logrus.Warningf("遍历目录 %s 失败：%v", dir, err)
+				continue
+			}
+			if entry.GetFileType() == storage.FileTypeDirectory {
+				continue
+			}
+			ext := entry.LowerExt()
+			switch {
+			case slices.Contains(musicImageExts, ext):
+			case slices.Contains(musicSheetExts, ext) && i == 0: // 专辑目录中的 CUE/日志属于其他碟片
+			default:
+				continue
+			}
+			companions = append(companions, entry)
+		}
+	}
+	return companions, nil
+}
+
+// TransferMusicCompanions 查找并转移音乐文件的伴随文件到目标文件所在目录，单个文件转移失败不影响其他文件
+// 与音乐文件同名的 CUE/日志（整轨专辑）按目标文件名重命名，CUE 中引用的文件名同步修改；其他文件保持原名，
+// 多音轨专辑的 CUE 中引用的当前音轨文件名同样修改为目标文件名
+// 目标目录中已存在的文件（同一专辑的其他音轨已转移）会被跳过
+func TransferMusicCompanions(ctx context.Context, srcFile storage.StoragePath, dstFile storage.StoragePath, transferType storage.TransferType) error {
+	companions, err := FindMusicCompanions(ctx, srcFile)
+	if err != nil {
+		return err
+	}
+	transferType = companionTransferType(transferType)
+	srcBase := strings.TrimSuffix(srcFile.GetName(), srcFile.GetExt())
+	dstBase := strings.TrimSuffix(dstFile.GetName(), dstFile.GetExt())
+	for _, companion := range companions {
+		name := companion.GetName()
+		renamed := slices.Contains(musicSheetExts, companion.LowerExt()) &&
+			strings.TrimSuffix(name, companion.GetExt()) == srcBase
+		if renamed {
+			name = dstBase + companion.GetExt()
+		}
+		dstPath := dstFile.Parent().Join(name)
+		if !renamed && companion.LowerExt() == ".cue" {
+			if err := transferAlbumCueSheet(companion, dstPath, transferType, srcFile.GetName(), dstFile.GetName()); err != nil {
+				logrus.Warningf("转移 CUE 文件失败：%v", err)
+			}
+			continue
+		}
+		exist, err := storage_controller.Exist(dstPath)
+		if err != nil {
+			logrus.Warningf("检查目标文件是否存在失败：%v", err)
+			continue
+		}
+		if exist {
+			continue
+		}
+
+		logrus.Debugf("转移封面/CUE/日志文件：%s -> %s", companion, dstPath)
+		if renamed && companion.LowerExt() == ".cue" {
+			err = transferCueSheet(companion, dstPath, transferType, srcFile.GetName(), dstFile.GetName())
+		} else {
+			err = storage_controller.TransferFile(companion, dstPath, transferType)
+		}
+		if err != nil {
+			logrus.Warningf("转移封面/CUE/日志文件失败：%v", err)
+		}
+	}
+	return nil
+}
+
+// transferCueSheet 转移 CUE 表单，并将其中引用的音频文件名（FILE "xxx"）修改为目标文件名
+// 没有找到引用（如 CUE 不是 UTF-8 编码）时按原样转移
+func transferCueSheet(srcFile storage.StoragePath, dstFile storage.StoragePath, transferType storage.TransferType, oldName string, newName string) error {
+	data, err := readCueSheet(srcFile)
+	if err != nil {
+		return err
+	}
+
+	old := []byte(`"` + oldName + `"`)
+	if oldName == newName || !bytes.Contains(data, old) {
+		return storage_controller.TransferFile(srcFile, dstFile, transferType)
+	}
+	data = bytes.ReplaceAll(data, old, []byte(`"`+newName+`"`))
+	if err := storage_controller.CreateFile(dstFile, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("写入 CUE 文件 %s 失败：%w", dstFile, err)
+	}
+	if transferType == storage.TransferMove {
+		return storage_controller.Delete(srcFile)
+	}
+	return nil
+}
+
+// transferAlbumCueSheet 转移多音轨专辑的 CUE 表单（与音乐文件不同名），将其中引用的当前音轨文件名修改为目标文件名
+// 同一专辑的每个音轨转移时都修改目标目录中的 CUE；移动时源 CUE 引用的音轨都已转移后才删除源 CUE
+// 没有引用当前音轨时按原样转移，目标已存在时跳过
+func transferAlbumCueSheet(srcFile storage.StoragePath, dstFile storage.StoragePath, transferType storage.TransferType, oldName string, newName string) error {
+	data, err := readCueSheet(srcFile)
+	if err != nil {
+		return err
+	}
+	exist, err := storage_controller.Exist(dstFile)
+	if err != nil {
+		return fmt.Errorf("检查目标文件是否存在失败：%w", err)
+	}
+	old := []byte(`"` + oldName + `"`)
+	if oldName == newName || !bytes.Contains(data, old) {
+		if exist {
+			return nil
+		}
+		return storage_controller.TransferFile(srcFile, dstFile, transferType)
+	}
+
+	if exist {
+		// 使用目标 CUE 的内容，保留之前转移的音轨已修改的文件名
+		if data, err = readCueSheet(dstFile); err != nil {
+			return err
+		}
+		if !bytes.Contains(data, old) {
+			return nil
+		}
+		// 目标可能是源文件的链接，先删除再写入，避免修改源文件
+		if err := storage_controller.Delete(dstFile); err != nil {
+			return fmt.Errorf("删除 CUE 文件 %s 失败：%w", dstFile, err)
+		}
+	}
+	data = bytes.ReplaceAll(data, old, []byte(`"`+newName+`"`))
+	if err := storage_controller.CreateFile(dstFile, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("写入 CUE 文件 %s 失败：%w", dstFile, err)
+	}
+	if transferType != storage.TransferMove {
+		return nil
+	}
+
+	// 源 CUE 引用的音轨仍有未转移的，保留源 CUE
+	srcData, err := readCueSheet(srcFile)
+	if err != nil {
+		return err
+	}
+	for _, m := range cueFileRe.FindAllSubmatch(srcData, -1) {
+		exist, err := storage_controller.Exist(srcFile.Parent().Join(string(m[1])))
+		if err != nil {
+			return fmt.Errorf("检查音轨文件是否存在失败：%w", err)
+		}
+		if exist {
+			return nil
+		}
+	}
+	return storage_controller.Delete(srcFile)
+}
+
+// CUE 表单中引用音频文件的 FILE 命令
+var cueFileRe = regexp.MustCompile(`(?m)^\s*FILE\s+"([^"]+)"`)
+
+func readCueSheet(path storage.StoragePath) ([]byte, error) {
+	reader, err := storage_controller.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取 CUE 文件 %s 失败：%w", path, err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取 CUE 文件 %s 失败：%w", path, err)
+	}
+	return data, nil
+}
+
+// writeEmbeddedCover 专辑目录中没有封面图片时，将内嵌封面写入为 cover.<扩展名>
+func writeEmbeddedCover(albumDir storage.StoragePath, picture *audiotag.Picture) error {
+	for _, name := range coverNames {
+		for _, ext := range musicImageExts {
+			exist, err := storage_controller.Exist(albumDir.Join(name + ext))
+			if err != nil {
+				return err
+			}
+			if exist {
+				return nil
+			}
+		}
+	}
+	dstPath := albumDir.Join("cover" + picture.Ext())
+	logrus.Debugf("写入内嵌封面：%s", dstPath)
+	return storage_controller.CreateFile(dstPath, bytes.NewReader(picture.Data))
+}
+
+// ArchiveMusicAdvanced 整理音乐文件或专辑目录（目录中的所有音乐文件）
+// srcFile: 源文件或目录路径
+// dstDir: 目标目录路径
+// transferType: 传输类型
+// organizeByType: 是否按媒体类型整理目录
+// scrape: 是否写入内嵌封面
+// 返回值: 提交的任务和可能的错误
+func ArchiveMusicAdvanced(ctx context.Context, srcFile storage.StoragePath, dstDir storage.StoragePath,
+	transferType storage.TransferType, organizeByType bool, scrape bool,
+) (*task.Task, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	detail, err := storage_controller.GetDetail(srcFile)
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 详情失败：%v", srcFile, err)
+	}
+	files := []storage.StoragePath{srcFile}
+	if detail.Type == storage.FileTypeDirectory {
+		files, err = collectMusicFiles(ctx, srcFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("目录 %s 中没有音乐文件", srcFile)
+		}
+	}
+	if len(files) == 1 {
+		history, err := database.QueryMediaTransferHistoryBySrc(srcFile)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("查询媒体转移历史失败：%v", err)
+		}
+		if history != nil && history.Status {
+			return nil, fmt.Errorf("音乐文件 %s 已经转移到 %s，不能重复转移", srcFile, history.DstPath)
+		}
+	}
+
+	var stopDir string
+	if lib := MatchLibrary(srcFile); lib != nil {
+		stopDir = lib.SrcPath
+	}
+	if organizeByType {
+		dstDir = dstDir.Join(GenMediaTypeFloderName(meta.MediaTypeMusic))
+	}
+
+	task := task_controller.SubmitTransferTask(srcFile.GetName(), func(ctx context.Context) {
+		for _, file := range files {
+			select {
+			case <-ctx.Done():
+				logrus.Warningf("整理音乐任务被取消：%v", ctx.Err())
+				return
+			default:
+			}
+			if history, err := database.QueryMediaTransferHistoryBySrc(file); err == nil && history.Status {
+				logrus.Infof("音乐文件 %s 已经转移到 %s，跳过", file, history.DstPath)
+				continue
+			}
+			archiveMusicFile(ctx, file, dstDir, transferType, stopDir, scrape)
+		}
+	})
+	return task, nil
+}
+
+// collectMusicFiles 递归查找目录中的音乐文件
+func collectMusicFiles(ctx context.Context, dir storage.StoragePath) ([]storage.StoragePath, error) {
+	entries, err := storage_controller.IterFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("遍历目录 %s 失败：%v", dir, err)
+	}
+	var files []storage.StoragePath
+	for entry, err := range entries {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("查找音乐文件操作被取消: %v", ctx.Err())
+		default:
+		}
+		if err != nil {
+			logrus.Warningf("遍历目录 %s 失败：%v", dir, err)
+			continue
+		}
+		if entry.GetFileType() != storage.FileTypeDirectory && IsMusicFile(entry) {
+			files = append(files, entry)
+		}
+	}
+	return files, nil
+}
+
+// archiveMusicFile 解析并整理单个音乐文件，记录转移历史
+func archiveMusicFile(ctx context.Context, srcFile storage.StoragePath, dstDir storage.StoragePath,
+	transferType storage.TransferType, stopDir string, scrape bool,
+) {
+	history := &models.MediaTransferHistory{
+		SrcType:      srcFile.GetStorageType(),
+		SrcPath:      srcFile.GetPath(),
+		TransferType: transferType,
+	}
+	if old, err := database.QueryMediaTransferHistoryBySrc(srcFile); err == nil {
+		history.ID = old.ID // 覆盖之前失败的记录
+	}
+
+	musicMeta, picture := ReadMusicMeta(srcFile, stopDir)
+	logrus.Debugf("解析音乐元数据：%s，%+v", srcFile.GetPath(), musicMeta)
+	item := schemas.NewMusicItem(musicMeta)
+	history.Item = item
+	if !scrape {
+		picture = nil
+	}
+
+	dstFile, err := func() (storage.StoragePath, error) {
+		if item.Album == "" || item.AlbumArtist == "" {
+			return nil, fmt.Errorf("未能识别 %s 的专辑或艺术家", srcFile)
+		}
+		dstFile, err := ArchiveMusic(ctx, srcFile, dstDir, transferType, item, picture)
+		if err != nil {
+			return nil, fmt.Errorf("转移音乐文件失败：%v", err)
+		}
+		return dstFile, nil
+	}()
+	if err != nil {
+		logrus.Warning(err)
+		history.Status = false
+		history.Message = err.Error()
+	} else {
+		logrus.Infof("音乐文件转移成功：%s -> %s", srcFile.String(), dstFile.String())
+		history.Status = true
+		history.DstPath = dstFile.GetPath()
+		history.DstType = dstFile.GetStorageType()
+	}
+
+	if err := database.UpdateMediaTransferHistory(history); err != nil {
+		logrus.Errorf("更新媒体转移记录失败: %v", err)
+	} else {
+		logrus.Debugf("更新媒体转移记录成功: %+v", history)
+	}
+}
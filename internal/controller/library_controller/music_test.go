@@ -0,0 +1,136 @@
+package library_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferMusicCompanions(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+
+	root := filepath.ToSlash(t.TempDir())
+	files := map[string]string{
+		"src/Album (2001)/cover.jpg":             "cover",
+		"src/Album (2001)/Scans/back.jpg":        "scan",
+		"src/Album (2001)/CD1/01 - Track.flac":   "",
+		"src/Album (2001)/CD1/CD1.log":           "log",
+		"src/Album (2001)/CD2/01 - Other.flac":   "",
+		"src/Album (2001)/CD2/CD2.log":           "log",
+		"src/Image/Album.flac":                   "",
+		"src/Image/Album.cue":                    "FILE \"Album.flac\" WAVE\n  TRACK 01 AUDIO\n",
+		"src/Image/Album.log":                    "log",
+		"dst/Artist/Album (2001)/1-01 Track.mp3": "",
+	}
+	for f, content := range files {
+		p := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+
+	// 多碟专辑：碟片目录中的日志和专辑目录中的封面，不包含子目录和其他碟片的文件
+	srcFile := storage.NewStoragePath(storage.StorageLocal, root+"/src/Album (2001)/CD1/01 - Track.flac")
+	dstFile := storage.NewStoragePath(storage.StorageLocal, root+"/dst/Artist/Album (2001)/1-01 Track.flac")
+	require.NoError(t, library_controller.TransferMusicCompanions(context.Background(), srcFile, dstFile, storage.TransferCopy))
+	entries, err := os.ReadDir(filepath.Join(root, "dst/Artist/Album (2001)"))
+	require.NoError(t, err)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	require.ElementsMatch(t, []string{"1-01 Track.mp3", "CD1.log", "cover.jpg"}, got)
+
+	// 整轨专辑：同名的 CUE 和日志按目标文件名重命名，CUE 中的文件名同步修改
+	srcFile = storage.NewStoragePath(storage.StorageLocal, root+"/src/Image/Album.flac")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dst/Artist/Image"), os.ModePerm))
+	dstFile = storage.NewStoragePath(storage.StorageLocal, root+"/dst/Artist/Image/Artist - Image.flac")
+	require.NoError(t, library_controller.TransferMusicCompanions(context.Background(), srcFile, dstFile, storage.TransferCopy))
+	cue, err := os.ReadFile(filepath.Join(root, "dst/Artist/Image/Artist - Image.cue"))
+	require.NoError(t, err)
+	require.Equal(t, "FILE \"Artist - Image.flac\" WAVE\n  TRACK 01 AUDIO\n", string(cue))
+	require.FileExists(t, filepath.Join(root, "dst/Artist/Image/Artist - Image.log"))
+}
+
+func TestTransferAlbumCueSheet(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+
+	root := filepath.ToSlash(t.TempDir())
+	cue := "FILE \"01 - One.flac\" WAVE\n  TRACK 01 AUDIO\nFILE \"02 - Two.flac\" WAVE\n  TRACK 02 AUDIO\n"
+	for f, content := range map[string]string{
+		"src/Album/01 - One.flac": "",
+		"src/Album/02 - Two.flac": "",
+		"src/Album/Album.cue":     cue,
+	} {
+		p := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dst/Album"), os.ModePerm))
+
+	// 与 ArchiveMusic 相同，先移动音轨再转移伴随文件
+	move := func(srcName, dstName string) {
+		srcFile := storage.NewStoragePath(storage.StorageLocal, root+"/src/Album/"+srcName)
+		dstFile := storage.NewStoragePath(storage.StorageLocal, root+"/dst/Album/"+dstName)
+		require.NoError(t, os.Rename(filepath.Join(root, "src/Album", srcName), filepath.Join(root, "dst/Album", dstName)))
+		require.NoError(t, library_controller.TransferMusicCompanions(context.Background(), srcFile, dstFile, storage.TransferMove))
+	}
+
+	// 每个音轨只修改 CUE 中引用自身的文件名，还有未转移的音轨时保留源 CUE
+	move("01 - One.flac", "1-01 One.flac")
+	data, err := os.ReadFile(filepath.Join(root, "dst/Album/Album.cue"))
+	require.NoError(t, err)
+	require.Equal(t, "FILE \"1-01 One.flac\" WAVE\n  TRACK 01 AUDIO\nFILE \"02 - Two.flac\" WAVE\n  TRACK 02 AUDIO\n", string(data))
+	require.FileExists(t, filepath.Join(root, "src/Album/Album.cue"))
+
+	move("02 - Two.flac", "1-02 Two.flac")
+	data, err = os.ReadFile(filepath.Join(root, "dst/Album/Album.cue"))
+	require.NoError(t, err)
+	require.Equal(t, "FILE \"1-01 One.flac\" WAVE\n  TRACK 01 AUDIO\nFILE \"1-02 Two.flac\" WAVE\n  TRACK 02 AUDIO\n", string(data))
+	require.NoFileExists(t, filepath.Join(root, "src/Album/Album.cue"))
+
+	// 链接时不修改源 CUE
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src/Album/Album.cue"), []byte(cue), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "link/Album"), os.ModePerm))
+	for src, dst := range map[string]string{"01 - One.flac": "1-01 One.flac", "02 - Two.flac": "1-02 Two.flac"} {
+		srcFile := storage.NewStoragePath(storage.StorageLocal, root+"/src/Album/"+src)
+		dstFile := storage.NewStoragePath(storage.StorageLocal, root+"/link/Album/"+dst)
+		require.NoError(t, library_controller.TransferMusicCompanions(context.Background(), srcFile, dstFile, storage.TransferLink))
+	}
+	data, err = os.ReadFile(filepath.Join(root, "link/Album/Album.cue"))
+	require.NoError(t, err)
+	require.Equal(t, "FILE \"1-01 One.flac\" WAVE\n  TRACK 01 AUDIO\nFILE \"1-02 Two.flac\" WAVE\n  TRACK 02 AUDIO\n", string(data))
+	data, err = os.ReadFile(filepath.Join(root, "src/Album/Album.cue"))
+	require.NoError(t, err)
+	require.Equal(t, cue, string(data))
+}
+
+func TestReadMusicMetaWithoutTags(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+
+	root := filepath.ToSlash(t.TempDir())
+	p := filepath.Join(root, "Daft Punk/Discovery (2001)/05 - One More Time.mp3")
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+	require.NoError(t, os.WriteFile(p, []byte("not an mp3"), 0o644))
+
+	srcFile := storage.NewStoragePath(storage.StorageLocal, filepath.ToSlash(p))
+	require.True(t, library_controller.IsMusicFile(srcFile))
+	musicMeta, picture := library_controller.ReadMusicMeta(srcFile, root)
+	require.Nil(t, picture)
+	require.Equal(t, "One More Time", musicMeta.Title)
+	require.Equal(t, "Discovery", musicMeta.Album)
+	require.Equal(t, "Daft Punk", musicMeta.GetAlbumArtist())
+	require.Equal(t, 5, musicMeta.Track)
+	require.Equal(t, 2001, musicMeta.Year)
+
+	require.False(t, library_controller.IsMusicFile(storage.NewStoragePath(storage.StorageLocal, "/a/b.mka")))
+}
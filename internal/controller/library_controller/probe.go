@@ -0,0 +1,122 @@
+package library_controller
+
+import (
+	"MediaTools/encode"
+	"MediaTools/internal/controller/scrape_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/probe"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FillMediaItemByProbe 探测媒体文件的实际流信息，补全文件名中未识别到的分辨率、编码和 HDR 信息
+// 返回探测结果供刮削时复用，探测失败时返回 nil
+func FillMediaItemByProbe(srcFile storage.StoragePath, item *schemas.MediaItem) *probe.Info {
+	info, err := scrape_controller.ProbeFile(srcFile)
+	if err != nil {
+		logrus.Debugf("探测媒体流信息失败：%v", err)
+		return nil
+	}
+	FillMediaItem(item, info)
+	return info
+}
+
+// FillMediaItem 使用探测结果补全媒体项中为空的字段，已有值保持不变
+func FillMediaItem(item *schemas.MediaItem, info *probe.Info) {
+	if video := info.Video(); video != nil {
+		if item.ResourcePix == meta.ResourcePixUnknown {
+			item.ResourcePix = probePix(video.Width, video.Height)
+		}
+		if item.VideoEncode == encode.VideoEncodeUnknown {
+			item.VideoEncode = probeVideoEncode(video.Codec, video.BitDepth)
+		}
+		if len(item.ResourceEffect) == 0 {
+			switch video.HDR {
+			case probe.HDR10:
+				item.ResourceEffect = []meta.ResourceEffect{meta.ResourceEffectHDR10}
+			case probe.HLG:
+				item.ResourceEffect = []meta.ResourceEffect{meta.ResourceEffectHLG}
+			case probe.DolbyVision:
+				item.ResourceEffect = []meta.ResourceEffect{meta.ResourceEffectDV}
+			}
+		}
+	}
+	if audio := info.Audio(); audio != nil && item.AudioEncode == encode.AudioEncodeUnknown {
+		item.AudioEncode = probeAudioEncode(audio.Codec)
+	}
+}
+
+// probePix 按宽高推断分辨率，兼容宽银幕（高度较小）和竖屏视频
+func probePix(width, height int) meta.ResourcePix {
+	switch {
+	case width <= 0 && height <= 0:
+		return meta.ResourcePixUnknown
+	case width >= 7600 || height >= 4200:
+		return meta.ResourcePix4320p
+	case width >= 3800 || height >= 2100:
+		return meta.ResourcePix2160p
+	case width >= 2500 || height >= 1400:
+		return meta.ResourcePix1440p
+	case width >= 1900 || height >= 1000:
+		return meta.ResourcePix1080p
+	case width >= 1200 || height >= 700:
+		return meta.ResourcePix720p
+	default:
+		return meta.ResourcePix480p
+	}
+}
+
+func probeVideoEncode(codec string, bitDepth int) encode.VideoEncode {
+	switch codec {
+	case "h264":
+		if bitDepth >= 10 {
+			return encode.VideoEncodeH264_10bit
+		}
+		return encode.VideoEncodeH264
+	case "hevc":
+		if bitDepth >= 10 {
+			return encode.VideoEncodeH265_10bit
+		}
+		return encode.VideoEncodeH265
+	case "av1":
+		if bitDepth >= 10 {
+			return encode.VideoEncodeAV1_10bit
+		}
+		return encode.VideoEncodeAV1
+	case "mpeg2":
+		return encode.VideoEncodeMPEG2
+	case "mpeg4":
+		return encode.VideoEncodeMPEG4
+	default:
+		return encode.VideoEncodeUnknown
+	}
+}
+
+func probeAudioEncode(codec string) encode.AudioEncode {
+	switch codec {
+	case "aac":
+		return encode.AudioEncodeAAC
+	case "ac3":
+		return encode.AudioEncodeAC3
+	case "eac3":
+		return encode.AudioEncodeEAC3
+	case "dts":
+		return encode.AudioEncodeDTS
+	case "truehd":
+		return encode.AudioEncodeTrueHD
+	case "flac":
+		return encode.AudioEncodeFLAC
+	case "pcm":
+		return encode.AudioEncodeLPCM
+	case "opus":
+		return encode.AudioEncodeOpus
+	case "vorbis":
+		return encode.AudioEncodeVorbis
+	case "mp3":
+		return encode.AudioEncodeMP3
+	default:
+		return encode.AudioEncodeUnknown
+	}
+}
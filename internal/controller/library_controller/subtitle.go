@@ -0,0 +1,79 @@
+package library_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/subtitle"
+	"MediaTools/internal/schemas/storage"
+	"MediaTools/utils"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SubtitleOptionsFromConfig 根据字幕处理配置生成处理选项，未启用时返回 false
+func SubtitleOptionsFromConfig() (subtitle.Options, bool) {
+	c := config.Media.Subtitle
+	if !c.Enable {
+		return subtitle.Options{}, false
+	}
+	return subtitle.Options{
+		Chinese: subtitle.ChineseConvert(c.Chinese),
+		Format:  subtitle.ParseFormat(c.Format),
+	}, true
+}
+
+// ConvertSubtitle 读取字幕文件，转换为 UTF-8 并按选项处理后写入目标路径
+// 目标格式与目标路径扩展名不一致时，按目标格式修改扩展名；目标路径可以与源路径相同（原地转换）
+// 返回实际写入的路径和处理结果
+func ConvertSubtitle(srcFile storage.StoragePath, dstFile storage.StoragePath, opts subtitle.Options) (storage.StoragePath, *subtitle.Result, error) {
+	reader, err := storage_controller.ReadFile(srcFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取字幕文件 %s 失败：%w", srcFile, err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取字幕文件 %s 失败：%w", srcFile, err)
+	}
+
+	result, err := subtitle.Process(data, subtitle.ParseFormat(srcFile.GetExt()), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("处理字幕文件 %s 失败：%w", srcFile, err)
+	}
+	if subtitle.ParseFormat(dstFile.GetExt()) != result.Format {
+		dstFile, err = storage_controller.GetPath(utils.ChangeExt(dstFile.GetPath(), result.Format.Ext()), dstFile.GetStorageType())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	logrus.Debugf("写入处理后的字幕文件：%s -> %s，原始编码：%s", srcFile, dstFile, result.Encoding)
+	if err := storage_controller.CreateFile(dstFile, bytes.NewReader(result.Data)); err != nil {
+		return nil, nil, fmt.Errorf("写入字幕文件 %s 失败：%w", dstFile, err)
+	}
+	return dstFile, result, nil
+}
+
+// transferSubtitle 处理并转移字幕文件，移动时删除源文件；处理失败时按原样转移
+// 处理后的字幕总是写入新文件，链接方式转移时不再与源文件关联
+func transferSubtitle(srcFile storage.StoragePath, dstFile storage.StoragePath, transferType storage.TransferType, opts subtitle.Options) error {
+	if subtitle.ParseFormat(srcFile.GetExt()) == "" { // 图形字幕、音轨等直接转移
+		return storage_controller.TransferFile(srcFile, dstFile, transferType)
+	}
+	_, _, err := ConvertSubtitle(srcFile, dstFile, opts)
+	if err != nil {
+		logrus.Warningf("%v，按原样转移", err)
+		dstFile, err = storage_controller.GetPath(utils.ChangeExt(dstFile.GetPath(), srcFile.LowerExt()), dstFile.GetStorageType())
+		if err != nil {
+			return err
+		}
+		return storage_controller.TransferFile(srcFile, dstFile, transferType)
+	}
+	if transferType == storage.TransferMove {
+		return storage_controller.Delete(srcFile)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package library_controller
 
 import (
 	"MediaTools/internal/config"
+	"MediaTools/internal/controller/storage_controller"
 	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/pkg/wordmatch"
 	"MediaTools/internal/schemas"
@@ -14,17 +15,12 @@ import (
 	"strings"
 )
 
-func MatchLibrary(fi *storage.StorageFileInfo) *config.LibraryConfig {
+// MatchLibrary 查找源文件所在的媒体库，多个媒体库嵌套时使用最长的匹配，不属于任何媒体库时返回 nil
+func MatchLibrary(fi storage.StoragePath) *config.LibraryConfig {
 	lock.RLock()
 	defer lock.RUnlock()
 
-	for i, lib := range config.Media.Libraries {
-		if lib.SrcType == fi.StorageType &&
-			strings.HasPrefix(fi.Path, lib.SrcPath) {
-			return &config.Media.Libraries[i]
-		}
-	}
-	return nil
+	return config.MatchSrcLibrary(fi)
 }
 
 func MatchCategory(cs []Category, countries []string, language string, genreIDs []int) string {
@@ -85,6 +81,8 @@ func GenMediaTypeFloderName(mediaType meta.MediaType) string {
 		return "电影"
 	case meta.MediaTypeTV:
 		return "电视剧"
+	case meta.MediaTypeMusic:
+		return "音乐"
 	default:
 		return ""
 	}
@@ -240,3 +238,20 @@ func ParseEpisodeFormat(name string, format string) (int, error) {
 func ParseEpisodeOffset(episode int, expr string) (int, error) {
 	return wordmatch.ParseOffsetExpr(expr, episode)
 }
+
+// transferTarget 获取转移后的目标文件路径，Strm 方式转移时为同名的 strm 文件
+func transferTarget(dstPath storage.StoragePath, transferType storage.TransferType) storage.StoragePath {
+	if transferType == storage.TransferStrm {
+		return storage_controller.StrmPath(dstPath)
+	}
+	return dstPath
+}
+
+// companionTransferType 获取伴随文件（字幕、封面等）的转移方式
+// Strm 方式转移时伴随文件复制到目标目录，供媒体服务器直接读取
+func companionTransferType(transferType storage.TransferType) storage.TransferType {
+	if transferType == storage.TransferStrm {
+		return storage.TransferCopy
+	}
+	return transferType
+}
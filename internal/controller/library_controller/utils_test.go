@@ -1,7 +1,9 @@
 package library_controller_test
 
 import (
+	"MediaTools/internal/config"
 	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/schemas/storage"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -115,3 +117,35 @@ func TestParseEpisodeFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchLibrary(t *testing.T) {
+	libraries := config.Media.Libraries
+	t.Cleanup(func() { config.Media.Libraries = libraries })
+	config.Media.Libraries = []config.LibraryConfig{
+		{Name: "tv", SrcType: storage.StorageLocal, SrcPath: "/downloads/tv"},
+		{Name: "downloads", SrcType: storage.StorageLocal, SrcPath: "/downloads/"},
+		{Name: "anime", SrcType: storage.StorageLocal, SrcPath: "/downloads/tv/anime"},
+	}
+
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/downloads/tv/Show.S01E01.mkv", "tv"},
+		{"/downloads/tv2/Show.S01E01.mkv", "downloads"}, // 不匹配 /downloads/tv
+		{"/downloads/tv/anime/Show - 01.mkv", "anime"},
+		{"/downloads", "downloads"},
+		{"/downloads2/Movie.mkv", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			lib := library_controller.MatchLibrary(storage.NewStoragePath(storage.StorageLocal, tc.path))
+			if tc.expected == "" {
+				require.Nil(t, lib)
+				return
+			}
+			require.NotNil(t, lib)
+			require.Equal(t, tc.expected, lib.Name)
+		})
+	}
+}
@@ -1,23 +1,20 @@
 package library_controller
 
 import (
-	"MediaTools/extensions"
+	"MediaTools/internal/controller/provider_controller"
 	"MediaTools/internal/controller/recognize_controller"
 	"MediaTools/internal/controller/scrape_controller"
 	"MediaTools/internal/controller/storage_controller"
 	"MediaTools/internal/controller/task_controller"
-	"MediaTools/internal/controller/tmdb_controller"
 	"MediaTools/internal/database"
 	"MediaTools/internal/models"
 	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/pkg/task"
 	"MediaTools/internal/schemas"
 	"MediaTools/internal/schemas/storage"
-	"MediaTools/utils"
 	"context"
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -46,7 +43,7 @@ func ArchiveMedia(
 	if err != nil {
 		return nil, err
 	}
-	dstPath := dstDir.Join(targetName)
+	dstPath := transferTarget(dstDir.Join(targetName), transferType)
 	exist, err := storage_controller.Exist(dstPath)
 	if err != nil {
 		return nil, fmt.Errorf("检查目标文件是否存在失败：%v", err)
@@ -62,50 +59,9 @@ func ArchiveMedia(
 		return nil, err
 	}
 
-	{
-		logrus.Info("开始转移字幕/音轨文件")
-		srcDir := srcFile.Parent()
-		paths, err := storage_controller.List(srcDir)
-		if err != nil {
-			logrus.Warningf("读取目录失败，跳过转移字幕/音轨文件：%v", err)
-		} else {
-			exts := append(extensions.SubtitleExtensions, extensions.AudioTrackExtensions...)
-			for path, err := range paths {
-				select {
-				case <-ctx.Done():
-					return nil, fmt.Errorf("转移字幕/音轨文件操作被取消: %v", ctx.Err())
-				default:
-				}
-
-				if err != nil {
-					logrus.Warningf("遍历目录 %s 失败，跳过转移字幕/音轨文件：%v", srcDir, err)
-					continue // 跳过错误的路径
-				}
-				if path.GetPath() == srcDir.GetPath() {
-					continue // 跳过源目录本身
-				}
-
-				if path.GetFileType() == storage.FileTypeDirectory {
-					logrus.Debugf("跳过目录：%s", path)
-					continue // 跳过目录
-				}
-
-				if slices.Contains(exts, path.LowerExt()) {
-					otherdstPathPath := utils.ChangeExt(dstPath.GetPath(), path.GetExt())
-					otherdstPath, err := storage_controller.GetPath(otherdstPathPath, dstPath.GetStorageType())
-					if err != nil {
-						logrus.Warningf("获取文件 %s:%s 失败: %v", dstPath.GetStorageType(), otherdstPathPath, err)
-						continue
-					}
-					logrus.Debugf("转移字幕/音轨文件：%s -> %s", path.String(), otherdstPath)
-					err = storage_controller.TransferFile(path, otherdstPath, transferType) // 转移字幕或音轨文件
-					if err != nil {
-						logrus.Warningf("转移字幕/音轨文件失败：%v", err)
-					}
-				}
-			}
-
-		}
+	logrus.Info("开始转移字幕/音轨文件")
+	if err := TransferCompanions(ctx, srcFile, dstPath, transferType); err != nil {
+		logrus.Warningf("查找字幕/音轨文件失败，跳过转移：%v", err)
 	}
 
 	if info != nil {
@@ -126,7 +82,7 @@ func ArchiveMedia(
 // srcFile: 源文件路径
 // dstDir: 目标目录路径
 // transferType: 传输类型
-// mediaType: 媒体类型（电影、电视剧等）
+// mediaType: 媒体类型（电影、电视剧等），音乐文件或指定为音乐时按音乐整理
 // tmdbID: TMDB ID（可选）
 // season: 季编号（可选，-1表示不设定季编号）
 // episodeStr: 集数字符串（可选，支持范围，如 "1-3"）
@@ -142,9 +98,22 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 	tmdbID int, season int, episodeStr string, episodeFormat string, episodeOffset string,
 	part string, organizeByType bool, organizeByCategory bool, scrape bool,
 ) (*task.Task, error) {
+	if mediaType == meta.MediaTypeMusic || (mediaType == meta.MediaTypeUnknown && IsMusicFile(srcFile)) {
+		return ArchiveMusicAdvanced(ctx, srcFile, dstDir, transferType, organizeByType, scrape)
+	}
+
 	lock.RLock()
 	defer lock.RUnlock()
 
+	discRoot, discType, err := DetectDisc(srcFile)
+	if err != nil {
+		return nil, fmt.Errorf("检测光盘结构失败：%v", err)
+	}
+	if discType != DiscTypeNone {
+		logrus.Infof("检测到光盘结构（%s）：%s，作为整体进行整理", discType, discRoot)
+		srcFile = discRoot
+	}
+
 	history, err := database.QueryMediaTransferHistoryBySrc(srcFile)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("查询媒体转移历史失败：%v", err)
@@ -157,7 +126,16 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 	history.SrcPath = srcFile.GetPath()
 	history.SrcType = srcFile.GetStorageType()
 
-	videoMeta, rule1, rule2 := recognize_controller.ParseVideoMeta(srcFile.GetName())
+	var (
+		stopDir   string
+		providers []string
+	)
+	if lib := MatchLibrary(srcFile); lib != nil {
+		stopDir = lib.SrcPath
+		providers = lib.Providers
+	}
+	videoMeta, rule1, rule2, sources := recognize_controller.ParseVideoMetaByPath(srcFile.GetPath(), stopDir)
+	logrus.Debugf("解析视频元数据: %s，各字段来源：%v", srcFile.GetPath(), sources)
 	switch {
 	case rule1 != "" && rule2 != "":
 		logrus.Debugf("解析视频元数据: %s，匹配的自定义规则：%s，应用的自定义媒体规则：%s", srcFile.GetName(), rule1, rule2)
@@ -169,6 +147,10 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 		logrus.Debugf("解析视频元数据: %s，没有匹配到自定义规则和应用的自定义媒体规则", srcFile.GetName())
 	}
 
+	if videoMeta.ExtraType != meta.ExtraTypeNone {
+		logrus.Infof("识别到附加内容（%s）：%s", videoMeta.ExtraType, srcFile.GetName())
+	}
+
 	var msgs []string
 	if mediaType != meta.MediaTypeUnknown {
 		videoMeta.MediaType = mediaType
@@ -179,7 +161,7 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 		msgs = append(msgs, fmt.Sprintf("TMDB ID: %d", tmdbID))
 	}
 	if season > -1 {
-		videoMeta.Season = season
+		videoMeta.Season, videoMeta.TotalSeason = season, 1
 		msgs = append(msgs, fmt.Sprintf("季数: %d", season))
 	}
 
@@ -222,10 +204,10 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 	}
 
 	history = new(models.MediaTransferHistory)
-	task := task_controller.SubmitTransferTask(srcFile.GetName(), func(ctx context.Context) {
+	transfer := func(ctx context.Context) {
 
 		dstFile, err := func() (storage.StoragePath, error) {
-			info, err := tmdb_controller.RecognizeAndEnrichMedia(ctx, videoMeta)
+			info, err := provider_controller.RecognizeAndEnrichMedia(ctx, videoMeta, providers)
 			if err != nil {
 				return nil, fmt.Errorf("识别媒体信息失败：%w", err)
 			}
@@ -244,13 +226,23 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 			if err != nil {
 				return nil, fmt.Errorf("创建媒体项失败：%w", err)
 			}
+			if !discType.IsFolder() {
+				info.Probe = FillMediaItemByProbe(srcFile, item)
+			}
 			history.Item = item
 
+			archive := ArchiveMedia
+			switch {
+			case discType.IsFolder():
+				archive = ArchiveDisc
+			case videoMeta.ExtraType.IsExtra():
+				archive = ArchiveExtra
+			}
 			var dstFile storage.StoragePath
 			if scrape {
-				dstFile, err = ArchiveMedia(ctx, srcFile, dstDir, transferType, item, info)
+				dstFile, err = archive(ctx, srcFile, dstDir, transferType, item, info)
 			} else {
-				dstFile, err = ArchiveMedia(ctx, srcFile, dstDir, transferType, item, nil)
+				dstFile, err = archive(ctx, srcFile, dstDir, transferType, item, nil)
 			}
 			if err != nil {
 				return nil, fmt.Errorf("转移媒体文件失败：%v", err)
@@ -275,7 +267,10 @@ func ArchiveMediaAdvanced(ctx context.Context, srcFile storage.StoragePath, dstD
 		} else {
 			logrus.Debugf("更新媒体转移记录成功: %+v", history)
 		}
-	})
+	}
 
-	return task, nil
+	if discType.IsFolder() {
+		return submitDiscTask(srcFile, transfer)
+	}
+	return task_controller.SubmitTransferTask(srcFile.GetName(), transfer), nil
 }
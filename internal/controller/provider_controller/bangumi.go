@@ -0,0 +1,146 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/pkg/bangumi/v0"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// bangumiProvider 番组计划元数据来源，电视剧按季建立条目
+type bangumiProvider struct {
+	client *bangumi.BangumiClient
+}
+
+func (p bangumiProvider) Name() ProviderName {
+	return ProviderBangumi
+}
+
+// bangumiMediaType 根据放送平台判断条目是电影还是电视剧
+func bangumiMediaType(subject bangumi.Subject) meta.MediaType {
+	switch subject.Platform {
+	case "剧场版", "电影":
+		return meta.MediaTypeMovie
+	default:
+		return meta.MediaTypeTV
+	}
+}
+
+func bangumiTitle(subject bangumi.Subject) string {
+	if subject.NameCN != "" {
+		return subject.NameCN
+	}
+	return subject.Name
+}
+
+func (p bangumiProvider) Search(ctx context.Context, title string, mediaType meta.MediaType, year int) ([]SearchResult, error) {
+	req := bangumi.SearchRequest{
+		Keyword: title,
+		Sort:    "match",
+		Filter: bangumi.SearchFilter{
+			Type: []bangumi.SubjectType{bangumi.SubjectTypeAnime, bangumi.SubjectTypeReal},
+		},
+	}
+	if year > 0 {
+		req.Filter.AirDate = []string{
+			fmt.Sprintf(">=%d-01-01", year-1),
+			fmt.Sprintf("<%d-01-01", year+2),
+		}
+	}
+	subjects, err := p.client.SearchSubjects(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, min(len(subjects), searchLimit))
+	for _, subject := range subjects {
+		t := bangumiMediaType(subject)
+		if mediaType != meta.MediaTypeUnknown && t != mediaType {
+			continue
+		}
+		results = append(results, SearchResult{
+			ID:            strconv.Itoa(subject.ID),
+			Title:         bangumiTitle(subject),
+			OriginalTitle: subject.Name,
+			Year:          parseYear(subject.Date),
+			MediaType:     t,
+		})
+		if len(results) >= searchLimit {
+			break
+		}
+	}
+	return filterYear(results, year), nil
+}
+
+func (p bangumiProvider) getSubject(ctx context.Context, id string) (*bangumi.Subject, error) {
+	subjectID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 Bangumi ID: %s", id)
+	}
+	return p.client.GetSubject(ctx, subjectID)
+}
+
+func (p bangumiProvider) GetDetail(ctx context.Context, id string, mediaType meta.MediaType) (*schemas.ProviderInfo, error) {
+	subject, err := p.getSubject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.ProviderInfo{
+		Provider:      string(ProviderBangumi),
+		ID:            id,
+		SeasonOnly:    bangumiMediaType(*subject) == meta.MediaTypeTV,
+		Title:         bangumiTitle(*subject),
+		OriginalTitle: subject.Name,
+		Overview:      subject.Summary,
+		Year:          parseYear(subject.Date),
+		Rating:        subject.Rating.Score,
+		Votes:         subject.Rating.Total,
+	}, nil
+}
+
+// GetEpisode 条目只对应单季，忽略季数，按本篇序号查找集数
+func (p bangumiProvider) GetEpisode(ctx context.Context, id string, season int, episode int) (*schemas.ProviderEpisode, error) {
+	subjectID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 Bangumi ID: %s", id)
+	}
+	episodes, err := p.client.GetEpisodes(ctx, subjectID, bangumi.EpisodeTypeMain)
+	if err != nil {
+		return nil, err
+	}
+	match := func(get func(bangumi.Episode) float64) *bangumi.Episode {
+		for i := range episodes {
+			if get(episodes[i]) == float64(episode) {
+				return &episodes[i]
+			}
+		}
+		return nil
+	}
+	ep := match(func(e bangumi.Episode) float64 { return e.Ep })
+	if ep == nil {
+		ep = match(func(e bangumi.Episode) float64 { return e.Sort })
+	}
+	if ep == nil {
+		return nil, fmt.Errorf("Bangumi 条目「%s」中没有第 %d 集", id, episode)
+	}
+	title := ep.NameCN
+	if title == "" {
+		title = ep.Name
+	}
+	return &schemas.ProviderEpisode{
+		Season:   season,
+		Episode:  episode,
+		Title:    title,
+		Overview: ep.Desc,
+		AirDate:  ep.AirDate,
+	}, nil
+}
+
+// FindByExternalID Bangumi 不提供外部 ID 查询
+func (p bangumiProvider) FindByExternalID(ctx context.Context, ids ExternalIDs, mediaType meta.MediaType) (string, error) {
+	if ids.BangumiID > 0 {
+		return strconv.Itoa(ids.BangumiID), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,95 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/pkg/douban"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// doubanProvider 豆瓣元数据来源，电视剧按季建立条目
+type doubanProvider struct {
+	client *douban.DoubanClient
+}
+
+func (p doubanProvider) Name() ProviderName {
+	return ProviderDouban
+}
+
+func doubanSubjectType(mediaType meta.MediaType) (douban.SubjectType, error) {
+	switch mediaType {
+	case meta.MediaTypeMovie:
+		return douban.SubjectTypeMovie, nil
+	case meta.MediaTypeTV:
+		return douban.SubjectTypeTV, nil
+	default:
+		return "", fmt.Errorf("不支持的媒体类型: 「%s」", mediaType)
+	}
+}
+
+func (p doubanProvider) Search(ctx context.Context, title string, mediaType meta.MediaType, year int) ([]SearchResult, error) {
+	items, err := p.client.Suggest(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, len(items))
+	for _, item := range items {
+		if item.Type != "movie" { // 影人等
+			continue
+		}
+		t := meta.MediaTypeMovie
+		if item.IsTV() {
+			t = meta.MediaTypeTV
+		}
+		if mediaType != meta.MediaTypeUnknown && t != mediaType {
+			continue
+		}
+		y, _ := strconv.Atoi(item.Year)
+		results = append(results, SearchResult{
+			ID:            item.ID,
+			Title:         item.Title,
+			OriginalTitle: item.SubTitle,
+			Year:          y,
+			MediaType:     t,
+		})
+	}
+	return filterYear(results, year), nil
+}
+
+func (p doubanProvider) GetDetail(ctx context.Context, id string, mediaType meta.MediaType) (*schemas.ProviderInfo, error) {
+	subjectType, err := doubanSubjectType(mediaType)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := p.client.GetSubject(ctx, subjectType, id)
+	if err != nil {
+		return nil, err
+	}
+	info := schemas.ProviderInfo{
+		Provider:      string(ProviderDouban),
+		ID:            id,
+		SeasonOnly:    subject.IsTV,
+		Title:         subject.Title,
+		OriginalTitle: subject.OriginalTitle,
+		Overview:      subject.Intro,
+		Genres:        subject.Genres,
+	}
+	info.Year, _ = strconv.Atoi(subject.Year)
+	if subject.Rating != nil && subject.Rating.Count > 0 {
+		info.Rating = subject.Rating.Value
+		info.Votes = subject.Rating.Count
+	}
+	return &info, nil
+}
+
+// GetEpisode 豆瓣没有分集信息
+func (p doubanProvider) GetEpisode(ctx context.Context, id string, season int, episode int) (*schemas.ProviderEpisode, error) {
+	return nil, fmt.Errorf("豆瓣不提供分集信息")
+}
+
+// FindByExternalID 豆瓣不提供外部 ID 查询
+func (p doubanProvider) FindByExternalID(ctx context.Context, ids ExternalIDs, mediaType meta.MediaType) (string, error) {
+	return ids.DoubanID, nil
+}
@@ -0,0 +1,184 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/database"
+	"MediaTools/internal/outbound"
+	"MediaTools/internal/pkg/bangumi/v0"
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/douban"
+	"MediaTools/internal/pkg/thetvdb/v4"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	tvdbClient    *thetvdb.TVDBClient // 未配置 API Key 时为 nil
+	bangumiClient *bangumi.BangumiClient
+	doubanClient  *douban.DoubanClient
+	lock          sync.RWMutex
+)
+
+func Init() error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	logrus.Info("开始初始化 Provider Controller...")
+	tvdb, err := newTVDBClient()
+	if err != nil {
+		return err
+	}
+	bgm, err := newBangumiClient()
+	if err != nil {
+		return err
+	}
+	db, err := newDoubanClient()
+	if err != nil {
+		return err
+	}
+	tvdbClient, bangumiClient, doubanClient = tvdb, bgm, db
+	logrus.Info("Provider Controller 初始化完成")
+	return nil
+}
+
+func newTVDBClient() (*thetvdb.TVDBClient, error) {
+	if config.TVDB.ApiKey == "" {
+		logrus.Info("未配置 TheTVDB API Key，跳过 TheTVDB 初始化")
+		return nil, nil
+	}
+	opts := []thetvdb.Options{
+		thetvdb.CustomHTTPClient(outbound.GetHTTPClient()),
+	}
+	if config.TVDB.ApiURL != "" {
+		opts = append(opts, thetvdb.CustomAPIURL(config.TVDB.ApiURL))
+	}
+	if config.TVDB.Pin != "" {
+		opts = append(opts, thetvdb.CustomPin(config.TVDB.Pin))
+	}
+	if config.TVDB.Language != "" {
+		opts = append(opts, thetvdb.CustomLanguage(config.TVDB.Language))
+	}
+	c, err := database.NewCache(config.TVDB.Cache, "tvdb")
+	if err != nil {
+		return nil, fmt.Errorf("创建 TheTVDB 缓存失败: %w", err)
+	}
+	opts = append(opts,
+		thetvdb.CustomCache(c),
+		thetvdb.CustomStaleWhileRevalidate(config.TVDB.Cache.StaleWhileRevalidate),
+	)
+	return thetvdb.NewClient(config.TVDB.ApiKey, opts...)
+}
+
+func newBangumiClient() (*bangumi.BangumiClient, error) {
+	opts := []bangumi.Options{
+		bangumi.CustomHTTPClient(outbound.GetHTTPClient()),
+	}
+	if config.Bangumi.ApiURL != "" {
+		opts = append(opts, bangumi.CustomAPIURL(config.Bangumi.ApiURL))
+	}
+	if config.Bangumi.AccessToken != "" {
+		opts = append(opts, bangumi.CustomAccessToken(config.Bangumi.AccessToken))
+	}
+	c, err := database.NewCache(config.Bangumi.Cache, "bangumi")
+	if err != nil {
+		return nil, fmt.Errorf("创建 Bangumi 缓存失败: %w", err)
+	}
+	opts = append(opts,
+		bangumi.CustomCache(c),
+		bangumi.CustomStaleWhileRevalidate(config.Bangumi.Cache.StaleWhileRevalidate),
+	)
+	return bangumi.NewClient(opts...)
+}
+
+func newDoubanClient() (*douban.DoubanClient, error) {
+	opts := []douban.Options{
+		douban.CustomHTTPClient(outbound.GetHTTPClient()),
+	}
+	if config.Douban.ApiURL != "" {
+		opts = append(opts, douban.CustomAPIURL(config.Douban.ApiURL))
+	}
+	if config.Douban.SearchURL != "" {
+		opts = append(opts, douban.CustomSearchURL(config.Douban.SearchURL))
+	}
+	if config.Douban.Cookie != "" {
+		opts = append(opts, douban.CustomCookie(config.Douban.Cookie))
+	}
+	c, err := database.NewCache(config.Douban.Cache, "douban")
+	if err != nil {
+		return nil, fmt.Errorf("创建豆瓣缓存失败: %w", err)
+	}
+	opts = append(opts,
+		douban.CustomCache(c),
+		douban.CustomStaleWhileRevalidate(config.Douban.Cache.StaleWhileRevalidate),
+	)
+	return douban.NewClient(opts...)
+}
+
+// GetProvider 获取补充元数据来源，来源未启用（如 TheTVDB 未配置 API Key）或为 TMDB 时返回错误
+func GetProvider(name ProviderName) (MetadataProvider, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	switch name {
+	case ProviderTMDB:
+		return nil, fmt.Errorf("TMDB 是识别的基准来源，不作为补充元数据来源使用")
+	case ProviderTVDB:
+		if tvdbClient == nil {
+			return nil, fmt.Errorf("未配置 TheTVDB API Key")
+		}
+		return tvdbProvider{client: tvdbClient}, nil
+	case ProviderBangumi:
+		return bangumiProvider{client: bangumiClient}, nil
+	case ProviderDouban:
+		return doubanProvider{client: doubanClient}, nil
+	default:
+		return nil, fmt.Errorf("不支持的元数据来源: %s", name)
+	}
+}
+
+type cacheClient interface {
+	CacheStats() (*cache.Stats, error)
+	PurgeCache(expiredOnly bool) (int64, error)
+}
+
+func getCacheClient(name ProviderName) (cacheClient, error) {
+	switch name {
+	case ProviderTVDB:
+		if tvdbClient == nil {
+			return nil, fmt.Errorf("未配置 TheTVDB API Key")
+		}
+		return tvdbClient, nil
+	case ProviderBangumi:
+		return bangumiClient, nil
+	case ProviderDouban:
+		return doubanClient, nil
+	default:
+		return nil, fmt.Errorf("元数据来源 %s 没有独立缓存", name)
+	}
+}
+
+// CacheStats 获取元数据来源的缓存统计信息
+func CacheStats(name ProviderName) (*cache.Stats, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	c, err := getCacheClient(name)
+	if err != nil {
+		return nil, err
+	}
+	return c.CacheStats()
+}
+
+// PurgeCache 清理元数据来源的缓存，expiredOnly 为 true 时只清理已过期的条目
+func PurgeCache(name ProviderName, expiredOnly bool) (int64, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	c, err := getCacheClient(name)
+	if err != nil {
+		return 0, err
+	}
+	return c.PurgeCache(expiredOnly)
+}
@@ -0,0 +1,90 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MergeProviders 将其他元数据来源的信息合并到 TMDB 识别结果中
+// 优先级高于 TMDB 的来源覆盖标题、简介（多个来源时优先级最高的生效），低于 TMDB 的来源只填补空白
+// 按季建立条目的来源（SeasonOnly）不覆盖整部剧的名称和简介，只作用于季和集
+// 搜索时没有标题一致而按年份选择的条目（Fuzzy）只填补空白
+func MergeProviders(info *schemas.MediaInfo, order []ProviderName, providers []schemas.ProviderInfo) {
+	rank := func(p schemas.ProviderInfo) int {
+		if i := slices.Index(order, ProviderName(p.Provider)); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	providers = slices.Clone(providers)
+	slices.SortStableFunc(providers, func(a, b schemas.ProviderInfo) int { return rank(a) - rank(b) })
+	tmdbRank := slices.Index(order, ProviderTMDB)
+
+	// 先由低到高应用覆盖 TMDB 的来源，优先级最高的最后写入
+	for i := len(providers) - 1; i >= 0; i-- {
+		if rank(providers[i]) < tmdbRank {
+			applyProvider(info, providers[i], !providers[i].Fuzzy) // 按年份选择的条目不覆盖 TMDB 文本
+		}
+	}
+	for _, p := range providers {
+		if tmdbRank < 0 || rank(p) > tmdbRank {
+			applyProvider(info, p, false)
+		}
+	}
+
+	for _, p := range providers {
+		switch ProviderName(p.Provider) {
+		case ProviderTVDB:
+			if id, err := strconv.Atoi(p.ID); err == nil && info.TVDBID == 0 {
+				info.TVDBID = id
+			}
+		case ProviderBangumi:
+			if id, err := strconv.Atoi(p.ID); err == nil {
+				info.BangumiID = id
+			}
+		case ProviderDouban:
+			info.DoubanID = p.ID
+		}
+	}
+	info.Providers = providers
+}
+
+// applyProvider 将单个来源的文本写入 TMDB 信息，override 为 false 时只填补空白
+func applyProvider(info *schemas.MediaInfo, p schemas.ProviderInfo, override bool) {
+	switch info.MediaType {
+	case meta.MediaTypeMovie:
+		if movie := info.TMDBInfo.MovieInfo; movie != nil {
+			setText(&movie.Title, p.Title, override)
+			setText(&movie.Overview, p.Overview, override)
+		}
+	case meta.MediaTypeTV:
+		tv := info.TMDBInfo.TVInfo
+		if p.SeasonOnly {
+			if tv.SeasonInfo != nil {
+				setText(&tv.SeasonInfo.Overview, p.Overview, override)
+			}
+		} else if tv.SerieInfo != nil {
+			setText(&tv.SerieInfo.Name, p.Title, override)
+			setText(&tv.SerieInfo.Overview, p.Overview, override)
+		}
+		if p.Episode != nil && tv.EpisodeInfo != nil {
+			// TMDB 自动生成的集名称视为空
+			setText(&tv.EpisodeInfo.Name, p.Episode.Title, override || tmdb_controller.IsPlaceholderEpisodeName(tv.EpisodeInfo.Name))
+			setText(&tv.EpisodeInfo.Overview, p.Episode.Overview, override)
+		}
+	}
+}
+
+func setText(field *string, value string, override bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if override || strings.TrimSpace(*field) == "" {
+		*field = value
+	}
+}
@@ -0,0 +1,102 @@
+package provider_controller_test
+
+import (
+	"MediaTools/internal/controller/provider_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"MediaTools/internal/schemas"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderOrder(t *testing.T) {
+	require.Equal(t, []provider_controller.ProviderName{"tmdb"}, provider_controller.ProviderOrder(nil))
+	require.Equal(t,
+		[]provider_controller.ProviderName{"bangumi", "tmdb", "douban"},
+		provider_controller.ProviderOrder([]string{"Bangumi", "tmdb", "douban", "bangumi"}),
+	)
+	require.Equal(t,
+		[]provider_controller.ProviderName{"tvdb", "tmdb"},
+		provider_controller.ProviderOrder([]string{"tvdb"}),
+	)
+
+	require.NoError(t, provider_controller.CheckProviders([]string{"tvdb", "tmdb"}))
+	require.Error(t, provider_controller.CheckProviders([]string{"imdb"}))
+	require.Error(t, provider_controller.CheckProviders([]string{"douban", "Douban"}))
+}
+
+func TestMergeProvidersMovie(t *testing.T) {
+	info := &schemas.MediaInfo{
+		MediaType: meta.MediaTypeMovie,
+		TMDBInfo: schemas.TMDBInfo{
+			MovieInfo: &themoviedb.MovieDetail{Title: "Your Name.", Overview: ""},
+		},
+	}
+	provider_controller.MergeProviders(info,
+		provider_controller.ProviderOrder([]string{"douban", "bangumi", "tmdb", "tvdb"}),
+		[]schemas.ProviderInfo{
+			{Provider: "tvdb", ID: "1234", Title: "Kimi no Na wa", Overview: "TVDB overview"},
+			{Provider: "bangumi", ID: "160209", Title: "你的名字。", Overview: "Bangumi 简介"},
+			{Provider: "douban", ID: "26683290", Title: "你的名字。", Rating: 8.5, Votes: 1000},
+		},
+	)
+
+	movie := info.TMDBInfo.MovieInfo
+	require.Equal(t, "你的名字。", movie.Title)
+	require.Equal(t, "Bangumi 简介", movie.Overview) // 豆瓣没有简介，使用下一优先级
+	require.Equal(t, 1234, info.TVDBID)
+	require.Equal(t, 160209, info.BangumiID)
+	require.Equal(t, "26683290", info.DoubanID)
+	require.Equal(t, []string{"douban", "bangumi", "tvdb"}, []string{info.Providers[0].Provider, info.Providers[1].Provider, info.Providers[2].Provider})
+}
+
+func TestMergeProvidersTV(t *testing.T) {
+	info := &schemas.MediaInfo{
+		MediaType: meta.MediaTypeTV,
+		TVDBID:    5678,
+		TMDBInfo: schemas.TMDBInfo{
+			TVInfo: schemas.TMDBTVInfo{
+				SerieInfo:   &themoviedb.TVSerieDetail{Name: "進撃の巨人", Overview: "TMDB 简介"},
+				SeasonInfo:  &themoviedb.TVSeasonDetail{Name: "第 2 季"},
+				EpisodeInfo: &themoviedb.TVEpisodeDetail{Name: "第 3 集"},
+			},
+		},
+	}
+	provider_controller.MergeProviders(info,
+		provider_controller.ProviderOrder([]string{"bangumi"}),
+		[]schemas.ProviderInfo{{
+			Provider:   "bangumi",
+			ID:         "203",
+			SeasonOnly: true,
+			Title:      "进击的巨人 第二季",
+			Overview:   "第二季简介",
+			Episode:    &schemas.ProviderEpisode{Season: 2, Episode: 3, Title: "远方"},
+		}},
+	)
+
+	tv := info.TMDBInfo.TVInfo
+	require.Equal(t, "進撃の巨人", tv.SerieInfo.Name) // 按季的条目不覆盖整部剧名称
+	require.Equal(t, "TMDB 简介", tv.SerieInfo.Overview)
+	require.Equal(t, "第二季简介", tv.SeasonInfo.Overview)
+	require.Equal(t, "远方", tv.EpisodeInfo.Name)
+	require.Equal(t, 5678, info.TVDBID)
+	require.Equal(t, 203, info.BangumiID)
+}
+
+func TestMergeProvidersFuzzy(t *testing.T) {
+	info := &schemas.MediaInfo{
+		MediaType: meta.MediaTypeMovie,
+		TMDBInfo: schemas.TMDBInfo{
+			MovieInfo: &themoviedb.MovieDetail{Title: "Frozen", Overview: ""},
+		},
+	}
+	provider_controller.MergeProviders(info,
+		provider_controller.ProviderOrder([]string{"douban", "tmdb"}),
+		[]schemas.ProviderInfo{{Provider: "douban", ID: "1", Fuzzy: true, Title: "冰雪奇缘2", Overview: "豆瓣简介"}},
+	)
+
+	movie := info.TMDBInfo.MovieInfo
+	require.Equal(t, "Frozen", movie.Title) // 按年份选择的条目不覆盖 TMDB 标题
+	require.Equal(t, "豆瓣简介", movie.Overview)
+}
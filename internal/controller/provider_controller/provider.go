@@ -0,0 +1,125 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ProviderName 元数据来源名称
+type ProviderName string
+
+const (
+	ProviderTMDB    ProviderName = "tmdb"
+	ProviderTVDB    ProviderName = "tvdb"
+	ProviderBangumi ProviderName = "bangumi"
+	ProviderDouban  ProviderName = "douban"
+)
+
+var providerNames = []ProviderName{ProviderTMDB, ProviderTVDB, ProviderBangumi, ProviderDouban}
+
+// SearchResult 搜索结果
+type SearchResult struct {
+	ID            string         // 来源中的条目 ID
+	Title         string         // 标题
+	OriginalTitle string         // 原始标题
+	Year          int            // 年份，0 表示未知
+	MediaType     meta.MediaType // 电影、电视剧
+}
+
+// ExternalIDs 各来源中的 ID，空值（0 或空字符串）表示未知
+type ExternalIDs struct {
+	TMDBID    int
+	IMDBID    string
+	TVDBID    int
+	BangumiID int
+	DoubanID  string
+}
+
+// MetadataProvider 补充元数据来源，在 TMDB 识别结果的基础上补充标题、简介、评分和单集信息，条目 ID 写入 NFO 的 uniqueid
+// TMDB 是识别的基准来源，不通过该接口使用；图片只使用 TMDB 和 Fanart，不从补充来源获取
+// 电视剧的 id 均为整部剧（或 Bangumi、豆瓣中单季）条目的 ID
+type MetadataProvider interface {
+	// Name 来源名称
+	Name() ProviderName
+	// Search 搜索条目，year 为 0 时不限制年份
+	Search(ctx context.Context, title string, mediaType meta.MediaType, year int) ([]SearchResult, error)
+	// GetDetail 获取条目详情
+	GetDetail(ctx context.Context, id string, mediaType meta.MediaType) (*schemas.ProviderInfo, error)
+	// GetEpisode 获取电视剧单集信息
+	GetEpisode(ctx context.Context, id string, season int, episode int) (*schemas.ProviderEpisode, error)
+	// FindByExternalID 通过其他来源的 ID 查找条目，不支持或未找到时返回空字符串
+	FindByExternalID(ctx context.Context, ids ExternalIDs, mediaType meta.MediaType) (string, error)
+}
+
+// CheckProviders 检查媒体库配置的元数据来源
+func CheckProviders(names []string) error {
+	seen := make(map[ProviderName]struct{}, len(names))
+	for _, name := range names {
+		n := ProviderName(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(providerNames, n) {
+			return fmt.Errorf("不支持的元数据来源: %s", name)
+		}
+		if _, ok := seen[n]; ok {
+			return fmt.Errorf("元数据来源 %s 重复", name)
+		}
+		seen[n] = struct{}{}
+	}
+	return nil
+}
+
+// ProviderOrder 规范化元数据来源优先级，识别依赖 TMDB，未配置 TMDB 时将其放在最后
+func ProviderOrder(names []string) []ProviderName {
+	order := make([]ProviderName, 0, len(names)+1)
+	for _, name := range names {
+		n := ProviderName(strings.ToLower(strings.TrimSpace(name)))
+		if slices.Contains(providerNames, n) && !slices.Contains(order, n) {
+			order = append(order, n)
+		}
+	}
+	if !slices.Contains(order, ProviderTMDB) {
+		order = append(order, ProviderTMDB)
+	}
+	return order
+}
+
+// 每次搜索最多返回的结果数
+const searchLimit = 10
+
+// filterYear 过滤年份相差超过 1 年的结果，year 为 0 或结果年份未知时不过滤
+func filterYear(results []SearchResult, year int) []SearchResult {
+	if year <= 0 {
+		return results
+	}
+	filtered := results[:0]
+	for _, r := range results {
+		if r.Year == 0 || abs(r.Year-year) <= 1 {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// parseYear 从日期（2006-01-02）或年份字符串中解析年份，失败时返回 0
+func parseYear(date string) int {
+	date = strings.TrimSpace(date)
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
@@ -0,0 +1,168 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RecognizeAndEnrichMedia 使用 TMDB 识别媒体信息，再按媒体库配置的优先级从其他元数据来源补充信息
+// providers: 媒体库配置的元数据来源优先级，为空时只使用 TMDB
+func RecognizeAndEnrichMedia(ctx context.Context, videoMeta *meta.VideoMeta, providers []string) (*schemas.MediaInfo, error) {
+	info, err := tmdb_controller.RecognizeAndEnrichMedia(ctx, videoMeta)
+	if err != nil {
+		return nil, err
+	}
+	order := ProviderOrder(providers)
+	if len(order) == 1 {
+		return info, nil
+	}
+
+	var results []schemas.ProviderInfo
+	for _, name := range order {
+		if name == ProviderTMDB {
+			continue
+		}
+		p, err := GetProvider(name)
+		if err != nil {
+			logrus.Warningf("元数据来源 %s 不可用: %v", name, err)
+			continue
+		}
+		result, err := recognizeProvider(ctx, p, videoMeta, info)
+		if err != nil {
+			logrus.Warningf("从 %s 获取「%s」信息失败: %v", name, mediaTitle(info), err)
+			continue
+		}
+		logrus.Debugf("从 %s 获取到媒体信息: %+v", name, result)
+		results = append(results, *result)
+	}
+	MergeProviders(info, order, results)
+	return info, nil
+}
+
+// seasonBased 来源是否按季建立电视剧条目
+func seasonBased(name ProviderName) bool {
+	return name == ProviderBangumi || name == ProviderDouban
+}
+
+// recognizeProvider 在元数据来源中查找 TMDB 识别结果对应的条目并获取详情
+// 优先通过外部 ID 查找，找不到时使用 TMDB 标题和原始标题搜索
+func recognizeProvider(ctx context.Context, p MetadataProvider, videoMeta *meta.VideoMeta, info *schemas.MediaInfo) (*schemas.ProviderInfo, error) {
+	ids := ExternalIDs{
+		TMDBID:    info.TMDBID,
+		IMDBID:    info.IMDBID,
+		TVDBID:    info.TVDBID,
+		BangumiID: info.BangumiID,
+		DoubanID:  info.DoubanID,
+	}
+	id, err := p.FindByExternalID(ctx, ids, info.MediaType)
+	if err != nil {
+		logrus.Warningf("通过外部 ID 在 %s 中查找失败: %v", p.Name(), err)
+	}
+	exact := true
+	if id == "" {
+		if id, exact, err = searchProvider(ctx, p, info); err != nil {
+			return nil, err
+		}
+	}
+
+	detail, err := p.GetDetail(ctx, id, info.MediaType)
+	if err != nil {
+		return nil, err
+	}
+	detail.Fuzzy = !exact
+	if info.MediaType == meta.MediaTypeTV && videoMeta.Episode != -1 {
+		episode, err := p.GetEpisode(ctx, id, videoMeta.Season, videoMeta.Episode)
+		if err != nil {
+			logrus.Debugf("从 %s 获取 S%02dE%02d 信息失败: %v", p.Name(), videoMeta.Season, videoMeta.Episode, err)
+		} else {
+			detail.Episode = episode
+		}
+	}
+	return detail, nil
+}
+
+// searchProvider 使用 TMDB 标题和原始标题搜索，优先选择标题完全一致的结果
+// 没有标题一致的结果时使用年份相差不超过一年的第一个结果，exact 为 false
+func searchProvider(ctx context.Context, p MetadataProvider, info *schemas.MediaInfo) (id string, exact bool, err error) {
+	var (
+		titles []string
+		year   int
+	)
+	switch info.MediaType {
+	case meta.MediaTypeMovie:
+		movie := info.TMDBInfo.MovieInfo
+		titles = []string{movie.Title, movie.OriginalTitle}
+		year = parseYear(movie.ReleaseDate)
+	case meta.MediaTypeTV:
+		tv := info.TMDBInfo.TVInfo
+		titles = []string{tv.SerieInfo.Name, tv.SerieInfo.OriginalName}
+		year = parseYear(tv.SerieInfo.FirstAirDate)
+		if seasonBased(p.Name()) && tv.SeasonInfo != nil {
+			if y := parseYear(tv.SeasonInfo.AirDate); y > 0 {
+				year = y // 按季建立条目的来源使用该季的播出年份
+			}
+		}
+	default:
+		return "", false, fmt.Errorf("不支持的媒体类型: 「%s」", info.MediaType)
+	}
+
+	var (
+		searched []string
+		fallback string
+	)
+	for _, title := range titles {
+		title = strings.TrimSpace(title)
+		if title == "" || containsFold(searched, title) {
+			continue
+		}
+		searched = append(searched, title)
+		results, err := p.Search(ctx, title, info.MediaType, year)
+		if err != nil {
+			logrus.Warningf("在 %s 中搜索「%s」失败: %v", p.Name(), title, err)
+			continue
+		}
+		for _, r := range results {
+			if containsFold(titles, r.Title) || containsFold(titles, r.OriginalTitle) {
+				return r.ID, true, nil
+			}
+			if fallback == "" && year > 0 && r.Year > 0 && abs(r.Year-year) <= 1 {
+				fallback = r.ID
+			}
+		}
+	}
+	if fallback != "" {
+		logrus.Infof("未在 %s 中找到标题一致的条目 %v，使用年份一致的搜索结果 %s", p.Name(), searched, fallback)
+		return fallback, false, nil
+	}
+	return "", false, fmt.Errorf("未在 %s 中找到 %v", p.Name(), searched)
+}
+
+func containsFold(list []string, s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), s) {
+			return true
+		}
+	}
+	return false
+}
+
+func mediaTitle(info *schemas.MediaInfo) string {
+	switch info.MediaType {
+	case meta.MediaTypeMovie:
+		return info.TMDBInfo.MovieInfo.Title
+	case meta.MediaTypeTV:
+		return info.TMDBInfo.TVInfo.SerieInfo.Name
+	default:
+		return ""
+	}
+}
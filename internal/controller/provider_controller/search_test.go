@@ -0,0 +1,57 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"MediaTools/internal/schemas"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// searchOnlyProvider 只实现搜索的元数据来源，按搜索标题返回预设结果
+type searchOnlyProvider struct {
+	MetadataProvider
+	results map[string][]SearchResult
+}
+
+func (searchOnlyProvider) Name() ProviderName { return ProviderDouban }
+
+func (p searchOnlyProvider) Search(ctx context.Context, title string, mediaType meta.MediaType, year int) ([]SearchResult, error) {
+	return p.results[title], nil
+}
+
+func TestSearchProvider(t *testing.T) {
+	info := &schemas.MediaInfo{
+		MediaType: meta.MediaTypeMovie,
+		TMDBInfo: schemas.TMDBInfo{
+			MovieInfo: &themoviedb.MovieDetail{Title: "冰雪奇缘", OriginalTitle: "Frozen", ReleaseDate: "2013-11-27"},
+		},
+	}
+
+	// 标题一致的结果优先，原始标题也参与匹配
+	p := searchOnlyProvider{results: map[string][]SearchResult{
+		"冰雪奇缘":   {{ID: "2", Title: "冰雪奇缘2", Year: 2019}, {ID: "3", Title: "冰雪奇缘：生日惊喜", Year: 2013}},
+		"Frozen": {{ID: "1", Title: "冰雪奇缘", OriginalTitle: "Frozen", Year: 2013}},
+	}}
+	id, exact, err := searchProvider(context.Background(), p, info)
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+	require.True(t, exact)
+
+	// 没有标题一致的结果时只使用年份一致的结果
+	p = searchOnlyProvider{results: map[string][]SearchResult{
+		"冰雪奇缘": {{ID: "2", Title: "冰雪奇缘2", Year: 2019}, {ID: "3", Title: "冰雪奇缘：生日惊喜", Year: 2014}},
+	}}
+	id, exact, err = searchProvider(context.Background(), p, info)
+	require.NoError(t, err)
+	require.Equal(t, "3", id)
+	require.False(t, exact)
+
+	p = searchOnlyProvider{results: map[string][]SearchResult{
+		"冰雪奇缘": {{ID: "2", Title: "冰雪奇缘2", Year: 2019}, {ID: "4", Title: "冰雪奇缘 幕后"}},
+	}}
+	_, _, err = searchProvider(context.Background(), p, info)
+	require.Error(t, err)
+}
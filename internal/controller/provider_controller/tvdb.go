@@ -0,0 +1,139 @@
+package provider_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/thetvdb/v4"
+	"MediaTools/internal/schemas"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// tvdbProvider TheTVDB v4 元数据来源
+type tvdbProvider struct {
+	client *thetvdb.TVDBClient
+}
+
+func (p tvdbProvider) Name() ProviderName {
+	return ProviderTVDB
+}
+
+func tvdbSearchType(mediaType meta.MediaType) (string, error) {
+	switch mediaType {
+	case meta.MediaTypeMovie:
+		return "movie", nil
+	case meta.MediaTypeTV:
+		return "series", nil
+	default:
+		return "", fmt.Errorf("不支持的媒体类型: 「%s」", mediaType)
+	}
+}
+
+func (p tvdbProvider) Search(ctx context.Context, title string, mediaType meta.MediaType, year int) ([]SearchResult, error) {
+	searchType, err := tvdbSearchType(mediaType)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Search(ctx, title, searchType, 0)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]SearchResult, 0, min(len(resp), searchLimit))
+	for _, item := range resp {
+		if item.TVDBID == "" {
+			continue
+		}
+		y, _ := strconv.Atoi(item.Year)
+		results = append(results, SearchResult{
+			ID:            item.TVDBID,
+			Title:         item.Name,
+			OriginalTitle: item.Name,
+			Year:          y,
+			MediaType:     mediaType,
+		})
+		if len(results) >= searchLimit {
+			break
+		}
+	}
+	return filterYear(results, year), nil
+}
+
+func (p tvdbProvider) GetDetail(ctx context.Context, id string, mediaType meta.MediaType) (*schemas.ProviderInfo, error) {
+	tvdbID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 TVDB ID: %s", id)
+	}
+	info := schemas.ProviderInfo{
+		Provider: string(ProviderTVDB),
+		ID:       id,
+	}
+	var genres []thetvdb.Genre
+	switch mediaType {
+	case meta.MediaTypeMovie:
+		movie, err := p.client.GetMovieExtended(ctx, tvdbID)
+		if err != nil {
+			return nil, err
+		}
+		info.Title = movie.Name
+		info.Overview = movie.Overview
+		info.Year, _ = strconv.Atoi(movie.Year)
+		genres = movie.Genres
+	case meta.MediaTypeTV:
+		serie, err := p.client.GetSeriesExtended(ctx, tvdbID)
+		if err != nil {
+			return nil, err
+		}
+		info.Title = serie.Name
+		info.Overview = serie.Overview
+		info.Year = parseYear(serie.FirstAired)
+		genres = serie.Genres
+	default:
+		return nil, fmt.Errorf("不支持的媒体类型: 「%s」", mediaType)
+	}
+	for _, genre := range genres {
+		info.Genres = append(info.Genres, genre.Name)
+	}
+	// TheTVDB 的 score 为热度而非评分，不作为评分使用
+	return &info, nil
+}
+
+func (p tvdbProvider) GetEpisode(ctx context.Context, id string, season int, episode int) (*schemas.ProviderEpisode, error) {
+	tvdbID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("无效的 TVDB ID: %s", id)
+	}
+	ep, err := p.client.GetSeriesEpisode(ctx, tvdbID, season, episode)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.ProviderEpisode{
+		Season:   season,
+		Episode:  episode,
+		Title:    ep.Name,
+		Overview: ep.Overview,
+		AirDate:  ep.Aired,
+	}, nil
+}
+
+// FindByExternalID 电视剧直接使用 TMDB 提供的 TVDB ID，电影通过 IMDb ID 查找
+func (p tvdbProvider) FindByExternalID(ctx context.Context, ids ExternalIDs, mediaType meta.MediaType) (string, error) {
+	if mediaType == meta.MediaTypeTV && ids.TVDBID > 0 {
+		return strconv.Itoa(ids.TVDBID), nil
+	}
+	if ids.IMDBID == "" {
+		return "", nil
+	}
+	results, err := p.client.SearchByRemoteID(ctx, ids.IMDBID)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range results {
+		switch {
+		case mediaType == meta.MediaTypeMovie && r.Movie != nil:
+			return strconv.Itoa(r.Movie.ID), nil
+		case mediaType == meta.MediaTypeTV && r.Series != nil:
+			return strconv.Itoa(r.Series.ID), nil
+		}
+	}
+	return "", nil
+}
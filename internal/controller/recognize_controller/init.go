@@ -15,6 +15,8 @@ var (
 	loock               sync.RWMutex
 	movieTemplate       *template.Template
 	tvTemplate          *template.Template
+	extraTemplate       *template.Template
+	musicTemplate       *template.Template
 	wm                  *wordmatch.WordsMatcher
 	customizationWordRe *regexp.Regexp
 )
@@ -25,11 +27,20 @@ func InitFormatTemplates() error {
 
 	logrus.Info("开始初始化媒体格式模板...")
 	var err error
-	movieTemplate, err = template.New("movie").Parse(config.Media.Format.Movie)
+	// 缺少的语言标题（如 {{.Titles.ja}}）输出空字符串而不是 <no value>
+	movieTemplate, err = template.New("movie").Option("missingkey=zero").Parse(config.Media.Format.Movie)
 	if err != nil {
 		return err
 	}
-	tvTemplate, err = template.New("tv").Parse(config.Media.Format.TV)
+	tvTemplate, err = template.New("tv").Option("missingkey=zero").Parse(config.Media.Format.TV)
+	if err != nil {
+		return err
+	}
+	extraTemplate, err = template.New("extra").Option("missingkey=zero").Parse(config.Media.Format.Extra)
+	if err != nil {
+		return err
+	}
+	musicTemplate, err = template.New("music").Option("missingkey=zero").Parse(config.Media.Format.Music)
 	if err != nil {
 		return err
 	}
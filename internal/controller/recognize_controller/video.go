@@ -4,6 +4,7 @@ import (
 	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/schemas"
 	"fmt"
+	pathlib "path"
 	"regexp"
 	"strconv"
 	"strings"
@@ -32,6 +33,33 @@ func FormatVideo(item *schemas.MediaItem) (string, error) {
 	return buffer.String(), nil
 }
 
+// FormatExtra 按附加内容格式模板生成附加内容文件名（相对于所属媒体的附加内容目录）
+func FormatExtra(item *schemas.MediaItem) (string, error) {
+	loock.RLock()
+	defer loock.RUnlock()
+
+	var buffer strings.Builder
+	if err := extraTemplate.Execute(&buffer, item); err != nil {
+		return "", fmt.Errorf("渲染模板失败: %v", err)
+	}
+	return buffer.String(), nil
+}
+
+// FormatMusic 按音乐格式模板生成音乐文件的相对路径
+func FormatMusic(item *schemas.MediaItem) (string, error) {
+	loock.RLock()
+	defer loock.RUnlock()
+
+	var buffer strings.Builder
+	if err := musicTemplate.Execute(&buffer, item); err != nil {
+		return "", fmt.Errorf("渲染模板失败: %v", err)
+	}
+	if strings.TrimSpace(buffer.String()) == "" {
+		return "", fmt.Errorf("音乐格式模板渲染结果为空")
+	}
+	return buffer.String(), nil
+}
+
 // MatchAndProcessVideoTitle 匹配并处理视频标题
 // 返回处理后的标题和匹配到的规则
 // 如果未匹配到规则，则返回原始标题和空规则
@@ -74,10 +102,40 @@ func ParseVideoMeta(title string) (*meta.VideoMeta, string, string) {
 	return vm, customRule, metaRule
 }
 
+// ParseVideoMetaByPath 根据文件路径解析媒体数据，合并文件名与父目录、祖父目录名称的解析结果
+// stopDir 为媒体库源目录（可为空），向上查找目录时不会越过该目录
+// 返回解析元数据、匹配的自定义规则、应用的自定义媒体规则和各字段来源
+func ParseVideoMetaByPath(p string, stopDir string) (*meta.VideoMeta, string, string, map[string]string) {
+	loock.RLock()
+	defer loock.RUnlock()
+
+	title, customRule := MatchAndProcessVideoTitle(pathlib.Base(p))
+	vm := meta.ParseVideoMeta(title)
+	vm.Customization = MatchCustomizationWordWord(title)
+
+	stopDir = strings.TrimSuffix(stopDir, "/")
+	var folders []*meta.VideoMeta
+	for dir := pathlib.Dir(p); len(folders) < 2; dir = pathlib.Dir(dir) {
+		if dir == "/" || dir == "." || dir == stopDir ||
+			(stopDir != "" && !strings.HasPrefix(dir, stopDir+"/")) {
+			break
+		}
+		name, _ := MatchAndProcessVideoTitle(pathlib.Base(dir))
+		folders = append(folders, meta.ParseVideoMeta(name))
+	}
+	sources := meta.MergeFolderMeta(vm, folders...)
+
+	metaRule := ApplyMediaMetaRule(vm) // 自定义媒体规则优先于目录信息
+	return vm, customRule, metaRule, sources
+}
+
 var ruleRe = regexp.MustCompile(`\{\[.+\]\}`)
 
 // ApplyMediaMetaRule 应用的自定义媒体规则
 // {[tmdbid=xxx;type=movie/tv;s=xxx;e=xxx]} 直接指定TMDBID，其中s、e为季数和集数（可选）
+// {[imdbid=ttxxx]}、{[tvdbid=xxx]} 指定 IMDb ID、TVDB ID，识别时通过 TMDB 换算为 TMDB ID（可选）
+// {[ordering=aired/absolute/剧集组ID]} 指定剧集编号方式（可选）
+// {[edition=xxx]} 指定剪辑版本（可选）
 // 返回应用的规则
 func ApplyMediaMetaRule(vm *meta.VideoMeta) string {
 	loock.RLock()
@@ -110,6 +168,23 @@ func ApplyMediaMetaRule(vm *meta.VideoMeta) string {
 				vm.TMDBID = id
 				rules = append(rules, "tmdbid="+strconv.Itoa(id))
 
+			case "imdbid": // IMDb ID
+				if !strings.HasPrefix(strings.ToLower(kv[1]), "tt") {
+					logrus.Warningf("标题「%s」匹配到的设置规则IMDBID格式错误：%s", vm.OrginalTitle, kv[1])
+					continue
+				}
+				vm.IMDBID = strings.ToLower(kv[1])
+				rules = append(rules, "imdbid="+vm.IMDBID)
+
+			case "tvdbid": // TVDB ID
+				id, err := strconv.Atoi(kv[1])
+				if err != nil {
+					logrus.Warningf("标题「%s」匹配到的设置规则TVDBID格式错误：%s", vm.OrginalTitle, kv[1])
+					continue
+				}
+				vm.TVDBID = id
+				rules = append(rules, "tvdbid="+strconv.Itoa(id))
+
 			case "type": // 媒体类型
 				mediaType := meta.ParseMediaType(kv[1])
 				if mediaType == meta.MediaTypeUnknown {
@@ -125,7 +200,7 @@ func ApplyMediaMetaRule(vm *meta.VideoMeta) string {
 					logrus.Warningf("标题「%s」匹配到的设置规则季数格式错误：%s", vm.OrginalTitle, kv[1])
 					continue
 				}
-				vm.Season = season
+				vm.Season, vm.TotalSeason = season, 1
 				rules = append(rules, "s="+strconv.Itoa(season))
 
 			case "e": // 集数
@@ -136,6 +211,19 @@ func ApplyMediaMetaRule(vm *meta.VideoMeta) string {
 				}
 				vm.Episode = episode
 				rules = append(rules, "e="+strconv.Itoa(episode))
+
+			case "ordering": // 剧集编号方式
+				ordering, ok := meta.ParseOrdering(kv[1])
+				if !ok {
+					logrus.Warningf("标题「%s」匹配到的设置规则剧集编号方式错误：%s", vm.OrginalTitle, kv[1])
+					continue
+				}
+				vm.Ordering = ordering
+				rules = append(rules, "ordering="+ordering)
+
+			case "edition": // 剪辑版本
+				vm.Edition = kv[1]
+				rules = append(rules, "edition="+kv[1])
 			}
 		}
 		return "{[" + strings.Join(rules, ";") + "]}"
@@ -409,3 +409,105 @@ func TestUpdateMetaByRule(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyOrderingRule(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+		rule     string
+	}{
+		{"[SubsPlease] One Piece - 1089 (1080p) {[tmdbid=37854;ordering=absolute]}", meta.OrderingAbsolute, "{[tmdbid=37854;ordering=absolute]}"},
+		{"Doctor Who S01E01 {[ordering=Aired]}", meta.OrderingAired, "{[ordering=aired]}"},
+		{"Dragon Ball S01E01 {[ordering=5acf93e60e0a26346d0000ce]}", "5acf93e60e0a26346d0000ce", "{[ordering=5acf93e60e0a26346d0000ce]}"},
+		{"Dragon Ball S01E01 {[ordering=dvd]}", "", "{[]}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			vm := &meta.VideoMeta{OrginalTitle: tt.title}
+			rule := recognize_controller.ApplyMediaMetaRule(vm)
+			require.Equal(t, tt.expected, vm.Ordering)
+			require.Equal(t, tt.rule, rule)
+		})
+	}
+}
+
+func TestApplyExternalIDRule(t *testing.T) {
+	vm := &meta.VideoMeta{OrginalTitle: "The Matrix {[imdbid=TT0133093;tvdbid=169]}"}
+	rule := recognize_controller.ApplyMediaMetaRule(vm)
+	require.Equal(t, "tt0133093", vm.IMDBID)
+	require.Equal(t, 169, vm.TVDBID)
+	require.Equal(t, "{[imdbid=tt0133093;tvdbid=169]}", rule)
+
+	vm = &meta.VideoMeta{OrginalTitle: "The Matrix {[imdbid=0133093]}"}
+	require.Equal(t, "{[]}", recognize_controller.ApplyMediaMetaRule(vm))
+	require.Empty(t, vm.IMDBID)
+}
+
+func TestParseVideoMetaByPath(t *testing.T) {
+	require.NoError(t, recognize_controller.InitCustomWord())
+
+	tests := []struct {
+		path    string
+		stopDir string
+		title   string
+		season  int
+		episode int
+		sources map[string]string
+	}{
+		{
+			path:    "/media/anime/[Group] Show Title/Season 2/03.mkv",
+			stopDir: "/media/anime",
+			title:   "Show Title",
+			season:  2,
+			episode: 3,
+			sources: map[string]string{"title": meta.SourceGrandparent, "season": meta.SourceParent, "episode": meta.SourceFile, "media_type": meta.SourceFile},
+		},
+		{
+			path:    "/media/anime/Season 2/03.mkv",
+			stopDir: "/media/anime",
+			title:   "",
+			season:  2,
+			episode: 3,
+			sources: map[string]string{"season": meta.SourceParent, "episode": meta.SourceFile, "media_type": meta.SourceFile},
+		},
+		{
+			path:    "/media/anime/Show Title/03.mkv",
+			stopDir: "/media/anime/",
+			title:   "Show Title",
+			season:  -1,
+			episode: 3,
+			sources: map[string]string{"title": meta.SourceParent, "episode": meta.SourceFile, "media_type": meta.SourceFile},
+		},
+		{
+			path:    "/media/anime/Show Title/03.mkv",
+			stopDir: "/media/anime/Show Title",
+			title:   "",
+			season:  -1,
+			episode: 3,
+			sources: map[string]string{"episode": meta.SourceFile, "media_type": meta.SourceFile},
+		},
+		{
+			path:    "/home/user/Downloads/Breaking.Bad.S01E03.1080p.mkv",
+			title:   "Breaking Bad",
+			season:  1,
+			episode: 3,
+			sources: map[string]string{"title": meta.SourceFile, "season": meta.SourceFile, "episode": meta.SourceFile, "media_type": meta.SourceFile, "resource_pix": meta.SourceFile},
+		},
+		{
+			path:    "/mnt/media/downloads/complete/Breaking.Bad.S01E03.1080p.mkv",
+			title:   "Breaking Bad",
+			season:  1,
+			episode: 3,
+			sources: map[string]string{"title": meta.SourceFile, "season": meta.SourceFile, "episode": meta.SourceFile, "media_type": meta.SourceFile, "resource_pix": meta.SourceFile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			vm, _, _, sources := recognize_controller.ParseVideoMetaByPath(tt.path, tt.stopDir)
+			require.Equal(t, tt.title, vm.GetTitle())
+			require.Equal(t, tt.season, vm.Season)
+			require.Equal(t, tt.episode, vm.Episode)
+			require.Equal(t, tt.sources, sources)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ActorThumbLibrary  = "library"  // 媒体库根目录下的 .actors 目录（Kodi 约定）
+	ActorThumbJellyfin = "jellyfin" // Jellyfin 元数据 People 目录
+
+	defaultActorThumbLimit = 15 // 默认下载头像的演员数量
+	actorThumbWorkers      = 4  // 同时下载头像的数量
+)
+
+// CheckActorThumbConfig 检查演员头像配置
+func CheckActorThumbConfig(cfg config.ActorThumbConfig) error {
+	switch cfg.Location {
+	case "", ActorThumbLibrary:
+	case ActorThumbJellyfin:
+		if cfg.PeoplePath == "" {
+			return fmt.Errorf("保存到 Jellyfin 元数据目录时需要配置 People 目录")
+		}
+	default:
+		return fmt.Errorf("不支持的演员头像保存位置: %s", cfg.Location)
+	}
+	if cfg.Limit < 0 {
+		return fmt.Errorf("演员数量不能为负数")
+	}
+	return nil
+}
+
+var (
+	actorThumbLock     sync.Mutex
+	actorThumbInflight = make(map[string]*actorThumbCall) // 正在下载的头像，同一头像只下载一次
+)
+
+type actorThumbCall struct {
+	done chan struct{}
+	path storage.StoragePath
+	err  error
+}
+
+// localizeActorThumbs 下载前 N 位演员的头像到本地，并将 NFO 中的 thumb 替换为本地图片路径
+// 头像按演员姓名保存在整个媒体库共享的目录中，已存在的头像不会重复下载
+// thumb 使用相对于 NFO 所在目录的路径（媒体库的挂载路径在媒体服务器中可能不同），
+// 保存到 Jellyfin People 目录时清空 thumb，由 Jellyfin 读取 People 目录中的头像
+// mediaDir: 电影或电视剧目录，不属于任何媒体库时在该目录下创建 .actors
+// nfoDir: NFO 文件所在目录
+func localizeActorThumbs(ctx context.Context, mediaDir storage.StoragePath, nfoDir storage.StoragePath, actors []Actor) {
+	cfg := config.Media.ActorThumb
+	if !cfg.Enable || len(actors) == 0 {
+		return
+	}
+	limit := cfg.Limit
+	if limit <= 0 {
+		limit = defaultActorThumbLimit
+	}
+
+	// 同一演员可能饰演多个角色，按演员计算数量
+	var (
+		names   []string
+		indexes = make(map[string][]int)
+	)
+	for i, actor := range actors {
+		if actor.profilePath == "" || actor.Name == "" {
+			continue
+		}
+		if _, ok := indexes[actor.Name]; !ok {
+			if len(names) >= limit {
+				continue
+			}
+			names = append(names, actor.Name)
+		}
+		indexes[actor.Name] = append(indexes[actor.Name], i)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, actorThumbWorkers)
+		mu  sync.Mutex
+	)
+	for _, name := range names {
+		target, err := actorThumbTarget(cfg, mediaDir, name)
+		if err != nil {
+			logrus.Warningf("获取演员「%s」头像路径失败: %v", name, err)
+			continue
+		}
+		profilePath := actors[indexes[name][0]].profilePath
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			path, err := downloadActorThumb(ctx, profilePath, target, mediaDir.GetStorageType())
+			if err != nil {
+				logrus.Warningf("下载演员「%s」头像失败: %v", name, err)
+				return
+			}
+			thumb := ""
+			if cfg.Location != ActorThumbJellyfin {
+				thumb, err = filepath.Rel(nfoDir.GetPath(), path.GetPath())
+				if err != nil {
+					logrus.Warningf("获取演员「%s」头像相对路径失败: %v", name, err)
+					return
+				}
+				thumb = filepath.ToSlash(thumb)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, i := range indexes[name] {
+				actors[i].Thumb = thumb
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// downloadActorThumb 下载演员头像，同一路径的并发下载只执行一次
+func downloadActorThumb(ctx context.Context, profilePath string, target string, storageType storage.StorageType) (storage.StoragePath, error) {
+	key := string(storageType) + ":" + target
+
+	actorThumbLock.Lock()
+	if call, ok := actorThumbInflight[key]; ok {
+		actorThumbLock.Unlock()
+		<-call.done
+		return call.path, call.err
+	}
+	call := &actorThumbCall{done: make(chan struct{})}
+	actorThumbInflight[key] = call
+	actorThumbLock.Unlock()
+
+	call.path, call.err = downloadImageAndSave(ctx, profilePath, target, storageType, tmdb_controller.DownloadImageData)
+	close(call.done)
+
+	actorThumbLock.Lock()
+	delete(actorThumbInflight, key)
+	actorThumbLock.Unlock()
+	return call.path, call.err
+}
+
+// actorThumbTarget 演员头像保存路径（不含扩展名）
+// library: <媒体库目标路径>/.actors/<姓名>，姓名中的空格替换为下划线
+// jellyfin: <People 目录>/<首字母>/<姓名>/folder
+func actorThumbTarget(cfg config.ActorThumbConfig, mediaDir storage.StoragePath, name string) (string, error) {
+	name = sanitizeActorName(name)
+	if name == "" {
+		return "", fmt.Errorf("演员姓名为空")
+	}
+	switch cfg.Location {
+	case ActorThumbJellyfin:
+		dir, err := storage_controller.GetPath(cfg.PeoplePath, mediaDir.GetStorageType())
+		if err != nil {
+			return "", err
+		}
+		first, _ := utf8.DecodeRuneInString(name)
+		return dir.Join(string(unicode.ToUpper(first)), name, "folder").GetPath(), nil
+
+	default:
+		dir := mediaDir
+		if lib := config.MatchDstLibrary(mediaDir); lib != nil {
+			libDir, err := storage_controller.GetPath(lib.DstPath, lib.DstType)
+			if err != nil {
+				return "", err
+			}
+			dir = libDir
+		}
+		return dir.Join(".actors", strings.ReplaceAll(name, " ", "_")).GetPath(), nil
+	}
+}
+
+// sanitizeActorName 去除姓名中不能用于文件名的字符
+func sanitizeActorName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return -1
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	return strings.Trim(strings.TrimSpace(name), ".")
+}
@@ -0,0 +1,17 @@
+package scrape_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/scrape_controller"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckActorThumbConfig(t *testing.T) {
+	require.NoError(t, scrape_controller.CheckActorThumbConfig(config.ActorThumbConfig{Enable: true}))
+	require.NoError(t, scrape_controller.CheckActorThumbConfig(config.ActorThumbConfig{Location: scrape_controller.ActorThumbJellyfin, PeoplePath: "/config/metadata/People"}))
+	require.Error(t, scrape_controller.CheckActorThumbConfig(config.ActorThumbConfig{Location: scrape_controller.ActorThumbJellyfin}))
+	require.Error(t, scrape_controller.CheckActorThumbConfig(config.ActorThumbConfig{Location: "plex"}))
+	require.Error(t, scrape_controller.CheckActorThumbConfig(config.ActorThumbConfig{Limit: -1}))
+}
@@ -0,0 +1,99 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActorThumbTarget(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+	libraries := config.Media.Libraries
+	t.Cleanup(func() { config.Media.Libraries = libraries })
+	config.Media.Libraries = []config.LibraryConfig{{DstType: storage.StorageLocal, DstPath: "/media/movies"}}
+
+	movieDir := storage.NewStoragePath(storage.StorageLocal, "/media/movies/Movie (2020)")
+	target, err := actorThumbTarget(config.ActorThumbConfig{}, movieDir, "Tom Hanks")
+	require.NoError(t, err)
+	require.Equal(t, "/media/movies/.actors/Tom_Hanks", target)
+
+	// 不属于任何媒体库时保存在媒体目录下
+	otherDir := storage.NewStoragePath(storage.StorageLocal, "/downloads/Movie (2020)")
+	target, err = actorThumbTarget(config.ActorThumbConfig{Location: ActorThumbLibrary}, otherDir, "Tom Hanks")
+	require.NoError(t, err)
+	require.Equal(t, "/downloads/Movie (2020)/.actors/Tom_Hanks", target)
+
+	target, err = actorThumbTarget(config.ActorThumbConfig{Location: ActorThumbJellyfin, PeoplePath: "/config/metadata/People"}, movieDir, "zoë kravitz")
+	require.NoError(t, err)
+	require.Equal(t, "/config/metadata/People/Z/zoë kravitz/folder", target)
+
+	target, err = actorThumbTarget(config.ActorThumbConfig{}, movieDir, "AC/DC: Live?")
+	require.NoError(t, err)
+	require.Equal(t, "/media/movies/.actors/ACDC_Live", target)
+
+	_, err = actorThumbTarget(config.ActorThumbConfig{}, movieDir, "..")
+	require.Error(t, err)
+}
+
+func TestLocalizeActorThumbs(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+	libraries, actorThumb := config.Media.Libraries, config.Media.ActorThumb
+	t.Cleanup(func() { config.Media.Libraries, config.Media.ActorThumb = libraries, actorThumb })
+
+	root := filepath.ToSlash(t.TempDir())
+	config.Media.Libraries = []config.LibraryConfig{{DstType: storage.StorageLocal, DstPath: root + "/tv"}}
+	// 已存在的头像不会重新下载
+	for _, name := range []string{"tv/.actors/Bryan_Cranston.jpg", "tv/.actors/Aaron_Paul.jpg", "People/B/Bryan Cranston/folder.jpg"} {
+		p := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, nil, 0o644))
+	}
+
+	serieDir := storage.NewStoragePath(storage.StorageLocal, root+"/tv/Breaking Bad (2008)")
+	seasonDir := serieDir.Join("Season 1")
+	newActors := func() []Actor {
+		return []Actor{
+			{Name: "Bryan Cranston", Role: "Walter White", Thumb: "https://image.tmdb.org/t/p/original/a.jpg", profilePath: "/a.jpg"},
+			{Name: "Bryan Cranston", Role: "Heisenberg", Thumb: "https://image.tmdb.org/t/p/original/a.jpg", profilePath: "/a.jpg"},
+			{Name: "Aaron Paul", Role: "Jesse Pinkman", Thumb: "https://image.tmdb.org/t/p/original/b.jpg", profilePath: "/b.jpg"},
+			{Name: "No Profile", Role: "Extra"},
+		}
+	}
+
+	// 未启用时保持 TMDB 地址
+	config.Media.ActorThumb = config.ActorThumbConfig{}
+	actors := newActors()
+	localizeActorThumbs(context.Background(), serieDir, serieDir, actors)
+	require.Equal(t, newActors(), actors)
+
+	// 使用相对于 NFO 所在目录的路径，同一演员的多个角色都替换，超过数量限制的演员保持 TMDB 地址
+	config.Media.ActorThumb = config.ActorThumbConfig{Enable: true, Limit: 1}
+	actors = newActors()
+	localizeActorThumbs(context.Background(), serieDir, serieDir, actors)
+	require.Equal(t, "../.actors/Bryan_Cranston.jpg", actors[0].Thumb)
+	require.Equal(t, "../.actors/Bryan_Cranston.jpg", actors[1].Thumb)
+	require.Equal(t, "https://image.tmdb.org/t/p/original/b.jpg", actors[2].Thumb)
+	require.Empty(t, actors[3].Thumb)
+
+	config.Media.ActorThumb = config.ActorThumbConfig{Enable: true}
+	actors = newActors()
+	localizeActorThumbs(context.Background(), serieDir, seasonDir, actors)
+	require.Equal(t, "../../.actors/Bryan_Cranston.jpg", actors[0].Thumb)
+	require.Equal(t, "../../.actors/Aaron_Paul.jpg", actors[2].Thumb)
+
+	// 保存到 Jellyfin People 目录时清空 thumb
+	config.Media.ActorThumb = config.ActorThumbConfig{Enable: true, Location: ActorThumbJellyfin, PeoplePath: root + "/People", Limit: 1}
+	actors = newActors()
+	localizeActorThumbs(context.Background(), serieDir, serieDir, actors)
+	require.Empty(t, actors[0].Thumb)
+	require.Empty(t, actors[1].Thumb)
+	require.Equal(t, "https://image.tmdb.org/t/p/original/b.jpg", actors[2].Thumb)
+}
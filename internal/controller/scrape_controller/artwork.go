@@ -0,0 +1,191 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/pkg/fanart/v3"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"cmp"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ArtworkCandidate 候选图片
+type ArtworkCandidate struct {
+	URL         string  // TMDB 图片路径或 Fanart 图片地址
+	Language    string  // 图片语言，空表示无文字
+	Width       int     // 宽度，0 表示未知
+	Height      int     // 高度，0 表示未知
+	VoteAverage float64 // TMDB 评分
+	VoteCount   int     // TMDB 投票数
+	Likes       int     // Fanart 点赞数
+}
+
+// ArtworkPolicy 图片选择与处理策略
+type ArtworkPolicy struct {
+	Languages []string // 语言优先级（已规范化），空字符串表示无文字图片
+	MinWidth  int      // 最小宽度
+	MinHeight int      // 最小高度
+	MaxSize   int      // 最大边长，0 不缩放
+	Format    string   // 重新编码格式，为空保持原格式
+	Quality   int      // 有损编码质量
+}
+
+const defaultImageQuality = 90
+
+// CurrentArtworkPolicy 根据配置生成图片策略
+// 未配置语言时依次使用 TMDB 语言、英文和无文字图片
+func CurrentArtworkPolicy() ArtworkPolicy {
+	cfg := config.Media.Artwork
+	policy := ArtworkPolicy{
+		MinWidth:  cfg.MinWidth,
+		MinHeight: cfg.MinHeight,
+		MaxSize:   cfg.MaxSize,
+		Format:    strings.ToLower(cfg.Format),
+		Quality:   cfg.Quality,
+	}
+	if policy.Format == "jpg" {
+		policy.Format = "jpeg"
+	}
+	if policy.Quality <= 0 || policy.Quality > 100 {
+		policy.Quality = defaultImageQuality
+	}
+
+	languages := cfg.Languages
+	if len(languages) == 0 {
+		languages = []string{config.TMDB.Language, "en", "null"}
+	}
+	for _, lang := range languages {
+		lang = normalizeArtworkLanguage(lang)
+		if !slices.Contains(policy.Languages, lang) {
+			policy.Languages = append(policy.Languages, lang)
+		}
+	}
+	return policy
+}
+
+// normalizeArtworkLanguage 规范化图片语言：只保留主语言代码，无文字图片统一为空字符串
+func normalizeArtworkLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "null", "00", "xx", "none":
+		return ""
+	}
+	return lang
+}
+
+// artworkAspect 各类图片的标准宽高比，0 表示不限制
+func artworkAspect(t ArtworkType) float64 {
+	switch t {
+	case ArtworkPoster:
+		return 2.0 / 3.0
+	case ArtworkFanart, ArtworkBackground, ArtworkLandscape:
+		return 16.0 / 9.0
+	case ArtworkBanner:
+		return 1000.0 / 185.0
+	case ArtworkDisc:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// artworkTextless 该类图片是否优先选择无文字版本
+func artworkTextless(t ArtworkType) bool {
+	switch t {
+	case ArtworkFanart, ArtworkBackground:
+		return true
+	default:
+		return false
+	}
+}
+
+// Select 按策略从候选图片中选出最合适的一张，没有合适的图片时返回空字符串
+// 依次比较：语言优先级、宽高比偏差、评分、投票数、点赞数、分辨率
+func (p ArtworkPolicy) Select(candidates []ArtworkCandidate, t ArtworkType) string {
+	languages := p.Languages
+	if artworkTextless(t) && slices.Contains(languages, "") {
+		languages = append([]string{""}, slices.DeleteFunc(slices.Clone(languages), func(lang string) bool { return lang == "" })...)
+	}
+	langRank := func(c ArtworkCandidate) int {
+		if i := slices.Index(languages, normalizeArtworkLanguage(c.Language)); i >= 0 {
+			return i
+		}
+		return len(languages)
+	}
+	aspect := artworkAspect(t)
+	aspectRank := func(c ArtworkCandidate) int {
+		if aspect == 0 || c.Width <= 0 || c.Height <= 0 {
+			return 0
+		}
+		if math.Abs(float64(c.Width)/float64(c.Height)-aspect)/aspect > 0.05 {
+			return 1
+		}
+		return 0
+	}
+
+	list := make([]ArtworkCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.URL == "" || !slices.Contains(supportImgExts, strings.ToLower(filepath.Ext(c.URL))) {
+			continue
+		}
+		if (c.Width > 0 && c.Width < p.MinWidth) || (c.Height > 0 && c.Height < p.MinHeight) {
+			continue
+		}
+		list = append(list, c)
+	}
+	if len(list) == 0 {
+		return ""
+	}
+
+	slices.SortStableFunc(list, func(a, b ArtworkCandidate) int {
+		return cmp.Or(
+			cmp.Compare(langRank(a), langRank(b)),
+			cmp.Compare(aspectRank(a), aspectRank(b)),
+			cmp.Compare(b.VoteAverage, a.VoteAverage),
+			cmp.Compare(b.VoteCount, a.VoteCount),
+			cmp.Compare(b.Likes, a.Likes),
+			cmp.Compare(b.Width*b.Height, a.Width*a.Height),
+		)
+	})
+	return list[0].URL
+}
+
+// SelectArtwork 使用当前配置的策略选择图片
+func SelectArtwork(candidates []ArtworkCandidate, t ArtworkType) string {
+	return CurrentArtworkPolicy().Select(candidates, t)
+}
+
+func tmdbCandidates(images []themoviedb.ImageInfo) []ArtworkCandidate {
+	candidates := make([]ArtworkCandidate, 0, len(images))
+	for _, img := range images {
+		candidates = append(candidates, ArtworkCandidate{
+			URL:         img.FilePath,
+			Language:    img.Iso6391,
+			Width:       img.Width,
+			Height:      img.Height,
+			VoteAverage: img.VoteAverage,
+			VoteCount:   img.VoteCount,
+		})
+	}
+	return candidates
+}
+
+func fanartCandidates[T fanart.Image](images []T) []ArtworkCandidate {
+	candidates := make([]ArtworkCandidate, 0, len(images))
+	for _, img := range images {
+		info := img.GetBaseInfo()
+		likes, _ := strconv.Atoi(info.Likes)
+		candidates = append(candidates, ArtworkCandidate{
+			URL:      info.URL,
+			Language: info.Lang,
+			Likes:    likes,
+		})
+	}
+	return candidates
+}
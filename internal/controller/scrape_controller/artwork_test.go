@@ -0,0 +1,34 @@
+package scrape_controller_test
+
+import (
+	"MediaTools/internal/controller/scrape_controller"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtworkPolicySelect(t *testing.T) {
+	policy := scrape_controller.ArtworkPolicy{Languages: []string{"zh", "en", ""}, MinWidth: 1000}
+	posters := []scrape_controller.ArtworkCandidate{
+		{URL: "/en.jpg", Language: "en", Width: 2000, Height: 3000, VoteAverage: 5.5},
+		{URL: "/zh-small.jpg", Language: "zh", Width: 500, Height: 750, VoteAverage: 9},
+		{URL: "/zh-wide.jpg", Language: "zh", Width: 1920, Height: 1080, VoteAverage: 8},
+		{URL: "/zh.jpg", Language: "zh", Width: 1000, Height: 1500, VoteAverage: 5},
+		{URL: "/zh.svg", Language: "zh", Width: 2000, Height: 3000, VoteAverage: 10},
+	}
+	require.Equal(t, "/zh.jpg", policy.Select(posters, scrape_controller.ArtworkPoster))
+
+	backdrops := []scrape_controller.ArtworkCandidate{
+		{URL: "/zh.jpg", Language: "zh", Width: 3840, Height: 2160},
+		{URL: "/textless.jpg", Width: 1920, Height: 1080},
+	}
+	require.Equal(t, "/textless.jpg", policy.Select(backdrops, scrape_controller.ArtworkFanart))
+
+	fanart := []scrape_controller.ArtworkCandidate{
+		{URL: "https://example.com/a.png", Language: "en", Likes: 1},
+		{URL: "https://example.com/b.png", Language: "en", Likes: 7},
+	}
+	require.Equal(t, "https://example.com/b.png", policy.Select(fanart, scrape_controller.ArtworkLogo))
+
+	require.Empty(t, policy.Select(posters[1:2], scrape_controller.ArtworkPoster))
+}
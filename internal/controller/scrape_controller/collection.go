@@ -0,0 +1,83 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// collectionDir 合集图片目录
+// 配置了合集目录时为 <媒体库目标路径>/<合集目录>/<合集名称>；否则电影目录的上级目录名称与合集名称一致时使用该目录
+// 无法确定合集目录时返回 nil
+func collectionDir(dstFile *storage.StorageFileInfo, name string) (storage.StoragePath, error) {
+	if dir := config.Media.Collection.Dir; dir != "" {
+		lib := config.MatchDstLibrary(dstFile)
+		if lib == nil {
+			return nil, fmt.Errorf("%s 不属于任何媒体库", dstFile)
+		}
+		libDir, err := storage_controller.GetPath(lib.DstPath, lib.DstType)
+		if err != nil {
+			return nil, err
+		}
+		return libDir.Join(dir, name), nil
+	}
+
+	parent := mediaDir(dstFile).Parent()
+	if parent.GetName() == name {
+		return parent, nil
+	}
+	return nil, nil
+}
+
+// scrapeCollectionImage 下载电影所属合集的海报和背景图
+func scrapeCollectionImage(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas.MediaInfo, profile *Profile) []error {
+	collection := info.TMDBInfo.MovieInfo.BelongsToCollection
+	dir, err := collectionDir(dstFile, collection.Name)
+	if err != nil {
+		return []error{fmt.Errorf("获取合集「%s」目录失败: %v", collection.Name, err)}
+	}
+	if dir == nil {
+		logrus.Debugf("电影「%s」未按合集整理，跳过合集「%s」图片", info.TMDBInfo.MovieInfo.Title, collection.Name)
+		return nil
+	}
+
+	var errs []error
+	images, err := tmdb_controller.GetCollectionImage(ctx, collection.ID)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("获取合集「%s」图片信息失败: %v", collection.Name, err))
+		images = &themoviedb.CollectionImage{} // 仍可使用合集默认海报和背景图
+	}
+
+	for _, item := range []struct {
+		artworkType ArtworkType
+		candidates  []themoviedb.ImageInfo
+		fallback    string
+		name        string
+	}{
+		{ArtworkPoster, images.Posters, collection.PosterPath, "海报"},
+		{ArtworkFanart, images.Backdrops, collection.BackdropPath, "背景图"},
+	} {
+		target, ok := profile.artworkTarget(dir, item.artworkType)
+		if !ok {
+			continue
+		}
+		path := SelectArtwork(tmdbCandidates(item.candidates), item.artworkType)
+		if path == "" {
+			path = item.fallback
+		}
+		if path == "" {
+			continue
+		}
+		if err := DownloadTMDBImageAndSave(ctx, path, target, dir.GetStorageType()); err != nil {
+			errs = append(errs, fmt.Errorf("刮削合集「%s」%s失败: %v", collection.Name, item.name, err))
+		}
+	}
+	return errs
+}
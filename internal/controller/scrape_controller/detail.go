@@ -0,0 +1,212 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"MediaTools/internal/schemas"
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const defaultCertificationCountry = "US"
+
+// 电影上映类型优先级：院线、限映、数字、实体、首映、电视
+// https://developer.themoviedb.org/reference/movie-release-dates
+var movieReleaseTypeOrder = []int{3, 2, 4, 5, 1, 6}
+
+// certificationCountry 分级使用的国家/地区
+func certificationCountry() string {
+	if country := strings.ToUpper(strings.TrimSpace(config.TMDB.CertificationCountry)); country != "" {
+		return country
+	}
+	return defaultCertificationCountry
+}
+
+// pickCertification 从各国家/地区的分级中选出配置国家的分级，没有时使用美国分级
+// mpaa: 美国分级直接使用分级名称（如 PG-13），其他国家/地区添加前缀（如 DE-12），与 Jellyfin 一致
+// certification: 国家/地区:分级（如 US:PG-13）
+func pickCertification(certs map[string]string, country string) (mpaa string, certification string) {
+	for _, c := range []string{country, defaultCertificationCountry} {
+		cert := strings.TrimSpace(certs[c])
+		if cert == "" {
+			continue
+		}
+		if c == defaultCertificationCountry {
+			mpaa = cert
+		} else {
+			mpaa = c + "-" + cert
+		}
+		return mpaa, c + ":" + cert
+	}
+	return "", ""
+}
+
+// movieCertifications 各国家/地区的电影分级，同一国家/地区按上映类型优先级选择
+func movieCertifications(releaseDates *themoviedb.MovieReleaseDate) map[string]string {
+	certs := make(map[string]string)
+	for _, result := range releaseDates.Results {
+		rank := len(movieReleaseTypeOrder)
+		for _, date := range result.ReleaseDates {
+			if date.Certification == "" {
+				continue
+			}
+			r := slices.Index(movieReleaseTypeOrder, date.Type)
+			if r < 0 {
+				r = len(movieReleaseTypeOrder)
+			}
+			if _, ok := certs[result.Iso31661]; !ok || r < rank {
+				certs[result.Iso31661] = date.Certification
+				rank = r
+			}
+		}
+	}
+	return certs
+}
+
+// tvCertifications 各国家/地区的电视剧分级
+func tvCertifications(contentRatings *themoviedb.TVSerieContentRating) map[string]string {
+	certs := make(map[string]string)
+	for _, result := range contentRatings.Results {
+		if result.Rating != "" {
+			certs[result.Iso31661] = result.Rating
+		}
+	}
+	return certs
+}
+
+// trailerURL 从视频列表中选出预告片，返回 Kodi YouTube 插件播放地址，没有时返回空字符串
+// 优先选择官方预告片，其次是前导预告片（Teaser）
+func trailerURL(videos []themoviedb.VideoInfo) string {
+	var list []themoviedb.VideoInfo
+	for _, video := range videos {
+		if video.Site == "YouTube" && video.Key != "" && (video.Type == "Trailer" || video.Type == "Teaser") {
+			list = append(list, video)
+		}
+	}
+	if len(list) == 0 {
+		return ""
+	}
+	rank := func(video themoviedb.VideoInfo) int {
+		r := 0
+		if video.Type != "Trailer" {
+			r += 2
+		}
+		if !video.Official {
+			r++
+		}
+		return r
+	}
+	slices.SortStableFunc(list, func(a, b themoviedb.VideoInfo) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			cmp.Compare(b.Size, a.Size),
+		)
+	})
+	return "plugin://plugin.video.youtube/?action=play_video&videoid=" + list[0].Key
+}
+
+// movieTrailer 获取电影预告片，配置语言没有预告片时使用英文预告片
+func movieTrailer(ctx context.Context, movieID int) (string, error) {
+	var trailer string
+	for _, language := range videoLanguages() {
+		resp, err := tmdb_controller.GetMovieVideo(ctx, movieID, language)
+		if err != nil {
+			return "", err
+		}
+		if trailer = trailerURL(resp.Results); trailer != "" {
+			break
+		}
+	}
+	return trailer, nil
+}
+
+// tvTrailer 获取电视剧预告片，配置语言没有预告片时使用英文预告片
+func tvTrailer(ctx context.Context, seriesID int) (string, error) {
+	var trailer string
+	for _, language := range videoLanguages() {
+		resp, err := tmdb_controller.GetTVSerieVideo(ctx, seriesID, language)
+		if err != nil {
+			return "", err
+		}
+		if trailer = trailerURL(resp.Results); trailer != "" {
+			break
+		}
+	}
+	return trailer, nil
+}
+
+// videoLanguages 获取视频时依次使用的语言，nil 表示 TMDB 配置的语言
+func videoLanguages() []*string {
+	languages := []*string{nil}
+	if normalizeArtworkLanguage(config.TMDB.Language) != "en" {
+		en := "en-US"
+		languages = append(languages, &en)
+	}
+	return languages
+}
+
+// tvStatus 将 TMDB 电视剧状态转换为 Kodi/Jellyfin 使用的状态
+func tvStatus(status string) string {
+	switch status {
+	case "Returning Series", "In Production", "Planned", "Pilot":
+		return "Continuing"
+	case "Ended", "Canceled":
+		return "Ended"
+	default:
+		return ""
+	}
+}
+
+// tmdbRating TMDB 评分，没有投票时返回 nil
+func tmdbRating(voteAverage float64, voteCount int) []Rating {
+	if voteCount <= 0 {
+		return nil
+	}
+	return []Rating{{
+		Default: true,
+		Max:     10,
+		Name:    "themoviedb",
+		Value:   voteAverage,
+		Votes:   voteCount,
+	}}
+}
+
+// providerRatings 其他元数据来源的评分（非默认评分）
+// 电视剧 NFO 不使用按季建立条目的来源（Bangumi、豆瓣）的评分
+func providerRatings(providers []schemas.ProviderInfo, serie bool) []Rating {
+	var ratings []Rating
+	for _, p := range providers {
+		if p.Votes <= 0 || (serie && p.SeasonOnly) {
+			continue
+		}
+		ratings = append(ratings, Rating{
+			Max:   10,
+			Name:  p.Provider,
+			Value: p.Rating,
+			Votes: p.Votes,
+		})
+	}
+	return ratings
+}
+
+// providerUniqueIDs Bangumi、豆瓣 ID，电视剧为对应季的条目，写入 season.nfo
+func providerUniqueIDs(mediaInfo *schemas.MediaInfo) []UniqueID {
+	var uniqueIDs []UniqueID
+	if mediaInfo.BangumiID != 0 {
+		uniqueIDs = append(uniqueIDs, UniqueID{
+			Type:  "bangumiid",
+			Value: strconv.Itoa(mediaInfo.BangumiID),
+		})
+	}
+	if mediaInfo.DoubanID != "" {
+		uniqueIDs = append(uniqueIDs, UniqueID{
+			Type:  "doubanid",
+			Value: mediaInfo.DoubanID,
+		})
+	}
+	return uniqueIDs
+}
@@ -4,13 +4,14 @@ import (
 	"MediaTools/internal/controller/tmdb_controller"
 	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/schemas"
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/sirupsen/logrus"
 )
 
-func genMovieMetaInfo(mediaInfo *schemas.MediaInfo) *MovieMetaData {
+func genMovieMetaInfo(ctx context.Context, mediaInfo *schemas.MediaInfo) *MovieMetaData {
 	var data MovieMetaData
 	if mediaInfo.TMDBInfo.MovieInfo != nil {
 		data.Title = mediaInfo.TMDBInfo.MovieInfo.Title                 // 电影标题
@@ -38,18 +39,19 @@ func genMovieMetaInfo(mediaInfo *schemas.MediaInfo) *MovieMetaData {
 			writers   []Creator // 编剧列表
 			credits   []Creator // 其他制作人员列表
 		)
-		resp, err := tmdb_controller.GetMovieCredit(mediaInfo.TMDBID, nil)
+		resp, err := tmdb_controller.GetMovieCredit(ctx, mediaInfo.TMDBID, nil)
 		if err != nil {
 			logrus.Warningf("获取电影「%s」演员/制作人员列表失败: %v", mediaInfo.TMDBInfo.MovieInfo.Title, err)
 		} else {
 			for _, cast := range resp.Cast {
 				actors = append(actors, Actor{
-					Name:    cast.Name,
-					Role:    cast.Character,
-					Type:    "Actor",
-					TMDBID:  strconv.Itoa(cast.ID),
-					Thumb:   tmdb_controller.GetImageURL(cast.ProfilePath),
-					Profile: fmt.Sprintf("https://www.themoviedb.org/person/%d", cast.ID),
+					Name:        cast.Name,
+					Role:        cast.Character,
+					Type:        "Actor",
+					TMDBID:      strconv.Itoa(cast.ID),
+					Thumb:       actorThumbURL(cast.ProfilePath),
+					Profile:     fmt.Sprintf("https://www.themoviedb.org/person/%d", cast.ID),
+					profilePath: cast.ProfilePath,
 				})
 			}
 
@@ -74,12 +76,58 @@ func genMovieMetaInfo(mediaInfo *schemas.MediaInfo) *MovieMetaData {
 		data.Writers = writers     // 编剧列表
 		data.Credits = credits     // 其他制作人员列表
 
+		if collection := mediaInfo.TMDBInfo.MovieInfo.BelongsToCollection; collection.ID != 0 {
+			data.Set = &MovieSet{TMDBColID: collection.ID, Name: collection.Name}
+			detail, err := tmdb_controller.GetCollectionDetail(ctx, collection.ID)
+			if err != nil {
+				logrus.Warningf("获取电影「%s」所属合集「%s」详情失败: %v", mediaInfo.TMDBInfo.MovieInfo.Title, collection.Name, err)
+			} else {
+				data.Set.Overview = detail.Overview
+			}
+		}
+
 		var genres []string
 		for _, genre := range mediaInfo.TMDBInfo.MovieInfo.Genres {
 			genres = append(genres, genre.Name)
 		}
 		data.Genres = genres // 电影类型
 
+		movieInfo := mediaInfo.TMDBInfo.MovieInfo
+		data.Tagline = movieInfo.Tagline                                      // 宣传语
+		data.Runtime = movieInfo.Runtime                                      // 片长
+		data.ReleaseDate = movieInfo.ReleaseDate                              // 上映日期
+		data.Ratings = tmdbRating(movieInfo.VoteAverage, movieInfo.VoteCount) // 评分详情
+		data.Ratings = append(data.Ratings, providerRatings(mediaInfo.Providers, false)...)
+		for _, country := range movieInfo.ProductionCountries {
+			data.Countries = append(data.Countries, country.Name) // 制作国家
+		}
+		for _, company := range movieInfo.ProductionCompanies {
+			data.Studios = append(data.Studios, Studio{Name: company.Name}) // 制作公司
+		}
+
+		keyword, err := tmdb_controller.GetMovieKeyword(ctx, mediaInfo.TMDBID)
+		if err != nil {
+			logrus.Warningf("获取电影「%s」关键词失败: %v", movieInfo.Title, err)
+		} else {
+			for _, k := range keyword.Keywords {
+				data.Tags = append(data.Tags, k.Name) // 标签
+			}
+		}
+
+		releaseDates, err := tmdb_controller.GetMovieReleaseDate(ctx, mediaInfo.TMDBID)
+		if err != nil {
+			logrus.Warningf("获取电影「%s」分级失败: %v", movieInfo.Title, err)
+		} else {
+			data.MPAA, data.Certification = pickCertification(movieCertifications(releaseDates), certificationCountry()) // 分级
+		}
+
+		trailer, err := movieTrailer(ctx, mediaInfo.TMDBID)
+		if err != nil {
+			logrus.Warningf("获取电影「%s」预告片失败: %v", movieInfo.Title, err)
+		} else {
+			data.Trailer = trailer // 预告片
+		}
+
 		var uniqueIDs []UniqueID
 		if mediaInfo.TMDBID != 0 {
 			uniqueIDs = append(uniqueIDs, UniqueID{
@@ -99,13 +147,14 @@ func genMovieMetaInfo(mediaInfo *schemas.MediaInfo) *MovieMetaData {
 				Value: strconv.Itoa(mediaInfo.TVDBID),
 			})
 		}
+		uniqueIDs = append(uniqueIDs, providerUniqueIDs(mediaInfo)...)
 		data.UniqueIDs = uniqueIDs
 	}
 
 	return &data
 }
 
-func genTVSerieMetaInfo(mediaInfo *schemas.MediaInfo) *TVSeriesMetaData {
+func genTVSerieMetaInfo(ctx context.Context, mediaInfo *schemas.MediaInfo) *TVSeriesMetaData {
 	var data TVSeriesMetaData
 	if mediaInfo.TMDBInfo.TVInfo.SerieInfo != nil {
 		data.Title = mediaInfo.TMDBInfo.TVInfo.SerieInfo.Name                 // 电视剧标题
@@ -128,19 +177,20 @@ func genTVSerieMetaInfo(mediaInfo *schemas.MediaInfo) *TVSeriesMetaData {
 		}
 
 		var actors []Actor
-		resp, err := tmdb_controller.GetTVSerieCredit(mediaInfo.TMDBID, nil)
+		resp, err := tmdb_controller.GetTVSerieCredit(ctx, mediaInfo.TMDBID, nil)
 		if err != nil {
 			logrus.Warningf("获取电视剧「%s」演员/制作人员列表失败: %v", mediaInfo.TMDBInfo.TVInfo.SerieInfo.Name, err)
 		} else {
 			for _, cast := range resp.Cast {
 				for _, role := range cast.Roles {
 					actors = append(actors, Actor{
-						Name:    cast.Name,
-						Role:    role.Character,
-						Type:    "Actor",
-						TMDBID:  strconv.Itoa(cast.ID),
-						Thumb:   tmdb_controller.GetImageURL(cast.ProfilePath),
-						Profile: fmt.Sprintf("https://www.themoviedb.org/person/%d", cast.ID),
+						Name:        cast.Name,
+						Role:        role.Character,
+						Type:        "Actor",
+						TMDBID:      strconv.Itoa(cast.ID),
+						Thumb:       actorThumbURL(cast.ProfilePath),
+						Profile:     fmt.Sprintf("https://www.themoviedb.org/person/%d", cast.ID),
+						profilePath: cast.ProfilePath,
 					})
 				}
 			}
@@ -152,6 +202,53 @@ func genTVSerieMetaInfo(mediaInfo *schemas.MediaInfo) *TVSeriesMetaData {
 		}
 		data.Genres = genres // 电视剧类型
 
+		serieInfo := mediaInfo.TMDBInfo.TVInfo.SerieInfo
+		data.Status = tvStatus(serieInfo.Status)                              // 状态
+		data.Ratings = tmdbRating(serieInfo.VoteAverage, serieInfo.VoteCount) // 评分详情
+		data.Ratings = append(data.Ratings, providerRatings(mediaInfo.Providers, true)...)
+		if len(serieInfo.EpisodeRunTime) > 0 {
+			data.Runtime = serieInfo.EpisodeRunTime[0] // 单集时长
+		} else {
+			data.Runtime = serieInfo.LastEpisodeToAir.Runtime
+		}
+		for _, country := range serieInfo.ProductionCountries {
+			data.Countries = append(data.Countries, country.Name) // 制作国家
+		}
+		if len(data.Countries) == 0 {
+			data.Countries = serieInfo.OriginCountry
+		}
+		for _, network := range serieInfo.Networks {
+			data.Studios = append(data.Studios, Studio{Name: network.Name}) // 播出电视网，Kodi 将其作为电视剧的制作公司
+		}
+		if len(data.Studios) == 0 {
+			for _, company := range serieInfo.ProductionCompanies {
+				data.Studios = append(data.Studios, Studio{Name: company.Name})
+			}
+		}
+
+		keyword, err := tmdb_controller.GetTVSerieKeyword(ctx, mediaInfo.TMDBID)
+		if err != nil {
+			logrus.Warningf("获取电视剧「%s」关键词失败: %v", serieInfo.Name, err)
+		} else {
+			for _, k := range keyword.Results {
+				data.Tags = append(data.Tags, k.Name) // 标签
+			}
+		}
+
+		contentRatings, err := tmdb_controller.GetTVSerieContentRating(ctx, mediaInfo.TMDBID)
+		if err != nil {
+			logrus.Warningf("获取电视剧「%s」分级失败: %v", serieInfo.Name, err)
+		} else {
+			data.MPAA, data.Certification = pickCertification(tvCertifications(contentRatings), certificationCountry()) // 分级
+		}
+
+		trailer, err := tvTrailer(ctx, mediaInfo.TMDBID)
+		if err != nil {
+			logrus.Warningf("获取电视剧「%s」预告片失败: %v", serieInfo.Name, err)
+		} else {
+			data.Trailer = trailer // 预告片
+		}
+
 		var uniqueIDs []UniqueID
 		if mediaInfo.TMDBID != 0 {
 			uniqueIDs = append(uniqueIDs, UniqueID{
@@ -217,6 +314,7 @@ func genTVSeasonMetaInfo(mediaInfo *schemas.MediaInfo) *TVSeasonMetaData {
 				Value: strconv.Itoa(mediaInfo.TVDBID),
 			})
 		}
+		uniqueIDs = append(uniqueIDs, providerUniqueIDs(mediaInfo)...)
 
 		data.UniqueIDs = uniqueIDs
 	}
@@ -254,12 +352,13 @@ func genTVEpisodeMetaInfo(mediaInfo *schemas.MediaInfo) *TVEpisodeMetaData {
 
 		for _, guestStar := range mediaInfo.TMDBInfo.TVInfo.EpisodeInfo.GuestStars {
 			actors = append(actors, Actor{
-				Name:    guestStar.Name,
-				Role:    guestStar.Character,
-				Type:    "GuestStar",
-				TMDBID:  strconv.Itoa(guestStar.ID),
-				Thumb:   tmdb_controller.GetImageURL(guestStar.ProfilePath),
-				Profile: fmt.Sprintf("https://www.themoviedb.org/person/%d", guestStar.ID),
+				Name:        guestStar.Name,
+				Role:        guestStar.Character,
+				Type:        "GuestStar",
+				TMDBID:      strconv.Itoa(guestStar.ID),
+				Thumb:       actorThumbURL(guestStar.ProfilePath),
+				Profile:     fmt.Sprintf("https://www.themoviedb.org/person/%d", guestStar.ID),
+				profilePath: guestStar.ProfilePath,
 			})
 		}
 		data.Actors = actors // 演员列表
@@ -306,19 +405,19 @@ func genTVEpisodeMetaInfo(mediaInfo *schemas.MediaInfo) *TVEpisodeMetaData {
 	}
 	return &data
 }
-func GenMetaDataNFO(infoType InfoType, mediaInfo *schemas.MediaInfo) ([]byte, error) {
+func GenMetaDataNFO(ctx context.Context, infoType InfoType, mediaInfo *schemas.MediaInfo) ([]byte, error) {
 	var nfoData InfoData
 	switch infoType {
 	case InfoTypeMovie:
 		if mediaInfo.MediaType != meta.MediaTypeMovie || mediaInfo.TMDBInfo.MovieInfo == nil {
 			return nil, fmt.Errorf("媒体信息不完整，无法生成电影 NFO")
 		}
-		nfoData = genMovieMetaInfo(mediaInfo)
+		nfoData = genMovieMetaInfo(ctx, mediaInfo)
 	case InfoTypeTV:
 		if mediaInfo.MediaType != meta.MediaTypeTV || mediaInfo.TMDBInfo.TVInfo.SerieInfo == nil {
 			return nil, fmt.Errorf("媒体信息不完整，无法生成电视剧 NFO")
 		}
-		nfoData = genTVSerieMetaInfo(mediaInfo)
+		nfoData = genTVSerieMetaInfo(ctx, mediaInfo)
 	case InfoTypeTVSeason:
 		if mediaInfo.MediaType != meta.MediaTypeTV ||
 			mediaInfo.TMDBInfo.TVInfo.SeasonInfo == nil ||
@@ -338,3 +437,11 @@ func GenMetaDataNFO(infoType InfoType, mediaInfo *schemas.MediaInfo) ([]byte, er
 	}
 	return nfoData.XML()
 }
+
+// actorThumbURL 演员头像的 TMDB 地址，没有头像时返回空字符串
+func actorThumbURL(profilePath string) string {
+	if profilePath == "" {
+		return ""
+	}
+	return tmdb_controller.GetImageURL(profilePath)
+}
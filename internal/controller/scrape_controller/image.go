@@ -0,0 +1,265 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/controller/fanart_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/schemas/storage"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// imageEncoder 图片编码器
+type imageEncoder struct {
+	Ext    string                                                // 保存时使用的扩展名
+	Alpha  bool                                                  // 是否支持透明通道，不支持时带透明通道的图片保持 PNG
+	Encode func(w io.Writer, img image.Image, quality int) error // 编码函数，quality 为有损编码质量
+}
+
+var imageEncoders = map[string]imageEncoder{
+	"jpeg": {
+		Ext: ".jpg",
+		Encode: func(w io.Writer, img image.Image, quality int) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+		},
+	},
+	"png": {
+		Ext:   ".png",
+		Alpha: true,
+		Encode: func(w io.Writer, img image.Image, _ int) error {
+			return png.Encode(w, img)
+		},
+	},
+}
+
+// artworkExts 判断图片是否已存在时检查的扩展名（包含用户自行放置的 WebP 图片）
+var artworkExts = []string{".jpg", ".jpeg", ".png", ".webp"}
+
+// CheckImageFormat 检查图片格式是否有可用的编码器，为空表示保持原格式
+func CheckImageFormat(format string) error {
+	switch format = strings.ToLower(format); format {
+	case "":
+		return nil
+	case "jpg":
+		format = "jpeg"
+	}
+	if _, ok := imageEncoders[format]; !ok {
+		return fmt.Errorf("不支持的图片格式 %s（仅支持 jpeg、png）", format)
+	}
+	return nil
+}
+
+// imageFormat 根据扩展名获取图片格式
+func imageFormat(ext string) string {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg":
+		return "jpeg"
+	case ".png":
+		return "png"
+	case ".webp":
+		return "webp"
+	default:
+		return ""
+	}
+}
+
+// 下载 TMDB 图片并按图片策略保存到指定路径
+// 默认保留原始图片数据，配置了最大边长或编码格式时重新处理
+// p: TMDB 中图片地址
+// target: 目标路径，不带后缀名
+func DownloadTMDBImageAndSave(ctx context.Context, p string, target string, storageType storage.StorageType) error {
+	_, err := downloadImageAndSave(ctx, p, target, storageType, tmdb_controller.DownloadImageData)
+	return err
+}
+
+// 下载 Fanart 图片并按图片策略保存到指定路径
+// 默认保留原始图片数据，配置了最大边长或编码格式时重新处理
+// url: Fanart 中图片地址
+// target: 目标路径，不带后缀名
+func DownloadFanartImageAndSave(ctx context.Context, url string, target string, storageType storage.StorageType) error {
+	_, err := downloadImageAndSave(ctx, url, target, storageType, fanart_controller.DownloadImageData)
+	return err
+}
+
+// downloadImageAndSave 下载图片并保存，返回最终保存（或已存在）的图片路径
+func downloadImageAndSave(ctx context.Context, src string, target string, storageType storage.StorageType, download func(context.Context, string) ([]byte, error)) (storage.StoragePath, error) {
+	if src == "" {
+		return nil, fmt.Errorf("图片地址为空")
+	}
+	existFile, err := findArtworkFile(target, storageType)
+	if err != nil {
+		return nil, err
+	}
+	if existFile != nil && !overwriteImage(ctx) {
+		return existFile, nil // 如果文件已存在，则跳过下载
+	}
+
+	data, err := download(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	data, ext, err := processImage(CurrentArtworkPolicy(), data, filepath.Ext(src))
+	if err != nil {
+		return nil, err
+	}
+	dstFile, err := storage_controller.GetPath(target+ext, storageType)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage_controller.CreateFile(dstFile, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	if existFile != nil {
+		removeOtherArtwork(target, ext, storageType)
+	}
+	return dstFile, nil
+}
+
+// findArtworkFile 查找目标图片（任意扩展名），不存在时返回 nil
+func findArtworkFile(target string, storageType storage.StorageType) (storage.StoragePath, error) {
+	for _, ext := range artworkExts {
+		p, err := storage_controller.GetPath(target+ext, storageType)
+		if err != nil {
+			return nil, err
+		}
+		exist, err := storage_controller.Exist(p)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// removeOtherArtwork 覆盖图片后删除其他扩展名的旧图片，避免媒体服务器读取到旧图片
+func removeOtherArtwork(target string, keepExt string, storageType storage.StorageType) {
+	for _, ext := range artworkExts {
+		if ext == keepExt {
+			continue
+		}
+		p, err := storage_controller.GetPath(target+ext, storageType)
+		if err != nil {
+			continue
+		}
+		if exist, err := storage_controller.Exist(p); err != nil || !exist {
+			continue
+		}
+		if err := storage_controller.Delete(p); err != nil {
+			logrus.Warningf("删除旧图片 %s 失败: %v", p, err)
+		}
+	}
+}
+
+// processImage 按策略处理图片，返回处理后的数据和扩展名
+// 未配置最大边长和编码格式，或图片无需缩放且格式一致时直接返回原始数据
+func processImage(policy ArtworkPolicy, data []byte, srcExt string) ([]byte, string, error) {
+	srcExt = strings.ToLower(srcExt)
+	if policy.Format == "" && policy.MaxSize <= 0 {
+		return data, srcExt, nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("解析图片失败: %w", err)
+	}
+	format := policy.Format
+	if format == "" {
+		format = imageFormat(srcExt)
+	}
+	needResize := policy.MaxSize > 0 && (cfg.Width > policy.MaxSize || cfg.Height > policy.MaxSize)
+	if !needResize && format == imageFormat(srcExt) {
+		return data, srcExt, nil
+	}
+
+	encoder, ok := imageEncoders[format]
+	if !ok {
+		return nil, "", fmt.Errorf("不支持的图片格式 %s（仅支持 jpeg、png）", format)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("解码图片失败: %w", err)
+	}
+	if !encoder.Alpha && !isOpaque(img) { // 透明图片（Logo、Clear Art 等）不转换为不支持透明通道的格式
+		if !needResize {
+			return data, srcExt, nil
+		}
+		encoder = imageEncoders["png"]
+	}
+	if needResize {
+		img = resizeImage(img, policy.MaxSize)
+	}
+
+	var buff bytes.Buffer
+	if err := encoder.Encode(&buff, img, policy.Quality); err != nil {
+		return nil, "", fmt.Errorf("编码图片失败: %w", err)
+	}
+	return buff.Bytes(), encoder.Ext, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return true
+}
+
+// resizeImage 按最大边长等比缩小图片，使用区域平均（预乘透明度）避免锯齿和透明边缘发黑
+func resizeImage(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if maxSize <= 0 || (w <= maxSize && h <= maxSize) {
+		return img
+	}
+	dw, dh := maxSize, maxSize
+	if w >= h {
+		dh = max(1, h*maxSize/w)
+	} else {
+		dw = max(1, w*maxSize/h)
+	}
+
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		sy0 := y * h / dh
+		sy1 := max((y+1)*h/dh, sy0+1)
+		for x := range dw {
+			sx0 := x * w / dw
+			sx1 := max((x+1)*w/dw, sx0+1)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				i := src.PixOffset(sx0, sy)
+				for sx := sx0; sx < sx1; sx++ {
+					pa := uint64(src.Pix[i+3])
+					r += uint64(src.Pix[i]) * pa
+					g += uint64(src.Pix[i+1]) * pa
+					b += uint64(src.Pix[i+2]) * pa
+					a += pa
+					n++
+					i += 4
+				}
+			}
+			j := dst.PixOffset(x, y)
+			if a > 0 {
+				dst.Pix[j] = uint8(r / a)
+				dst.Pix[j+1] = uint8(g / a)
+				dst.Pix[j+2] = uint8(b / a)
+			}
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
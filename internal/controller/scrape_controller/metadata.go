@@ -24,6 +24,15 @@ type Actor struct {
 	IMDbID    string `xml:"imdbid,omitempty"`     // IMDb演员ID（可选）
 	BangumiID string `xml:"bangumiid,omitempty"`  // 番组计划ID（可选，仅部分文档有）
 	DoubanID  string `xml:"doubanidid,omitempty"` // 豆瓣ID（可选，仅部分文档有）
+
+	profilePath string // TMDB 头像路径，用于下载本地头像
+}
+
+// 电影合集
+type MovieSet struct {
+	TMDBColID int    `xml:"tmdbcolid,attr,omitempty"` // 合集 TMDB ID（Jellyfin 读取）
+	Name      string `xml:"name"`                     // 合集名称
+	Overview  string `xml:"overview"`                 // 合集简介
 }
 
 // 评分信息
@@ -168,12 +177,43 @@ type TVEpisodeMetaData struct {
 	Edition          string     `xml:"edition,omitempty"`           // 版本（如NONE）
 	OriginalFilename string     `xml:"original_filename,omitempty"` // 原始文件名
 	UserNote         string     `xml:"user_note,omitempty"`         // 用户备注
+	FileInfo         *FileInfo  `xml:"fileinfo,omitempty"`          // 媒体流信息
 }
 
 func (t *TVEpisodeMetaData) XML() ([]byte, error) {
 	return xml.MarshalIndent(t, "", "  ")
 }
 
+// 视频流详情
+type VideoDetail struct {
+	Codec             string  `xml:"codec"`                       // 编码
+	Aspect            float64 `xml:"aspect,omitempty"`            // 宽高比
+	Width             int     `xml:"width,omitempty"`             // 宽度
+	Height            int     `xml:"height,omitempty"`            // 高度
+	DurationInSeconds int     `xml:"durationinseconds,omitempty"` // 时长（秒）
+	HDRType           string  `xml:"hdrtype,omitempty"`           // HDR 类型（hdr10/hlg/dolbyvision）
+}
+
+// 音频流详情
+type AudioDetail struct {
+	Codec    string `xml:"codec"`              // 编码
+	Language string `xml:"language,omitempty"` // 语言
+	Channels int    `xml:"channels,omitempty"` // 声道数
+}
+
+// 字幕流详情
+type SubtitleDetail struct {
+	Codec    string `xml:"codec,omitempty"` // 编码
+	Language string `xml:"language"`        // 语言
+}
+
+// 媒体文件流信息
+type FileInfo struct {
+	Videos    []VideoDetail    `xml:"streamdetails>video"`    // 视频流
+	Audios    []AudioDetail    `xml:"streamdetails>audio"`    // 音频流
+	Subtitles []SubtitleDetail `xml:"streamdetails>subtitle"` // 字幕流
+}
+
 // 电影元数据
 type MovieMetaData struct {
 	XMLName       xml.Name   `xml:"movie"`
@@ -198,6 +238,8 @@ type MovieMetaData struct {
 	Premiered     string     `xml:"premiered,omitempty"`      // 首映日期
 	ReleaseDate   string     `xml:"releasedate,omitempty"`    // 上映日期
 	Tagline       string     `xml:"tagline,omitempty"`        // 宣传语
+	Runtime       int        `xml:"runtime,omitempty"`        // 片长（分钟）
+	Set           *MovieSet  `xml:"set,omitempty"`            // 所属合集
 	Countries     []string   `xml:"country,omitempty"`        // 制作国家
 	Genres        []string   `xml:"genre,omitempty"`          // 类型（动作/科幻等）
 	Studios       []Studio   `xml:"studio,omitempty"`         // 制作公司
@@ -222,6 +264,7 @@ type MovieMetaData struct {
 	Edition          string    `xml:"edition,omitempty"`           // 版本
 	OriginalFilename string    `xml:"original_filename,omitempty"` // 原始文件名
 	UserNote         string    `xml:"user_note,omitempty"`         // 用户备注
+	FileInfo         *FileInfo `xml:"fileinfo,omitempty"`          // 媒体流信息
 }
 
 func (t *MovieMetaData) XML() ([]byte, error) {
@@ -0,0 +1,235 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas/storage"
+	"MediaTools/utils"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// readNFO 读取 NFO 文件并解析到 v 中
+func readNFO(path storage.StoragePath, v InfoData) error {
+	reader, err := storage_controller.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("解析 NFO 文件 %s 失败: %w", path, err)
+	}
+	return nil
+}
+
+func readNFOAs[T any, PT interface {
+	*T
+	InfoData
+}](path storage.StoragePath) (*T, error) {
+	var data T
+	if err := readNFO(path, PT(&data)); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ReadMovieNFO 读取电影 NFO 文件
+func ReadMovieNFO(path storage.StoragePath) (*MovieMetaData, error) {
+	return readNFOAs[MovieMetaData](path)
+}
+
+// ReadTVSeriesNFO 读取电视剧 NFO 文件
+func ReadTVSeriesNFO(path storage.StoragePath) (*TVSeriesMetaData, error) {
+	return readNFOAs[TVSeriesMetaData](path)
+}
+
+// ReadTVSeasonNFO 读取电视剧季 NFO 文件
+func ReadTVSeasonNFO(path storage.StoragePath) (*TVSeasonMetaData, error) {
+	return readNFOAs[TVSeasonMetaData](path)
+}
+
+// ReadTVEpisodeNFO 读取电视剧集 NFO 文件
+func ReadTVEpisodeNFO(path storage.StoragePath) (*TVEpisodeMetaData, error) {
+	return readNFOAs[TVEpisodeMetaData](path)
+}
+
+// writeNFO 写入 NFO 文件
+// 已有 NFO 设置了 lockdata 时不覆盖，返回 false；否则保留已有 NFO 中的用户数据（观看状态、用户评分、备注等）
+func writeNFO(path storage.StoragePath, data InfoData) (bool, error) {
+	exist, err := storage_controller.Exist(path)
+	if err != nil {
+		return false, err
+	}
+	if exist {
+		var old InfoData
+		switch data.(type) {
+		case *MovieMetaData:
+			old = new(MovieMetaData)
+		case *TVSeriesMetaData:
+			old = new(TVSeriesMetaData)
+		case *TVSeasonMetaData:
+			old = new(TVSeasonMetaData)
+		case *TVEpisodeMetaData:
+			old = new(TVEpisodeMetaData)
+		}
+		if err := readNFO(path, old); err != nil {
+			logrus.Warningf("读取已有 NFO 文件失败，将直接覆盖: %v", err)
+		} else if mergeNFO(data, old) {
+			return false, nil
+		}
+	}
+
+	xmlData, err := data.XML()
+	if err != nil {
+		return false, fmt.Errorf("生成 XML 失败: %w", err)
+	}
+	reader, err := bytes2Reader(xmlData)
+	if err != nil {
+		return false, err
+	}
+	if err := storage_controller.CreateFile(path, reader); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mergeNFO 将已有 NFO 中的用户数据合并到新生成的数据中，已有 NFO 被锁定时返回 true
+func mergeNFO(data InfoData, old InfoData) bool {
+	switch d := data.(type) {
+	case *MovieMetaData:
+		o := old.(*MovieMetaData)
+		if o.LockData {
+			return true
+		}
+		if o.DateAdded != "" {
+			d.DateAdded = o.DateAdded
+		}
+		d.Watched, d.PlayCount, d.UserRating, d.UserNote = o.Watched, o.PlayCount, o.UserRating, o.UserNote
+	case *TVSeriesMetaData:
+		o := old.(*TVSeriesMetaData)
+		if o.LockData {
+			return true
+		}
+		if o.DateAdded != "" {
+			d.DateAdded = o.DateAdded
+		}
+		d.Watched, d.PlayCount, d.UserRating, d.UserNote = o.Watched, o.PlayCount, o.UserRating, o.UserNote
+	case *TVSeasonMetaData:
+		o := old.(*TVSeasonMetaData)
+		if o.LockData {
+			return true
+		}
+		if o.DateAdded != "" {
+			d.DateAdded = o.DateAdded
+		}
+		d.UserNote = o.UserNote
+	case *TVEpisodeMetaData:
+		o := old.(*TVEpisodeMetaData)
+		if o.LockData {
+			return true
+		}
+		if o.DateAdded != "" {
+			d.DateAdded = o.DateAdded
+		}
+		d.Watched, d.PlayCount, d.UserRating, d.UserNote = o.Watched, o.PlayCount, o.UserRating, o.UserNote
+		d.EpBookmark = o.EpBookmark
+	}
+	return false
+}
+
+// NFOMeta 从已有 NFO 中读取到的识别信息
+type NFOMeta struct {
+	MediaType meta.MediaType
+	TMDBID    int    // 电影或电视剧的 TMDB ID
+	Season    int    // 季号（-1 表示未知）
+	Episode   int    // 集号（-1 表示未知）
+	Source    string // 读取的 NFO 文件
+}
+
+// nfoTMDBID 从唯一标识中获取 TMDB ID，兼容 tmdb 和 tmdbid 两种类型名称
+func nfoTMDBID(ids []UniqueID, fallback int) int {
+	for _, id := range ids {
+		switch strings.ToLower(id.Type) {
+		case "tmdb", "tmdbid":
+			if v, err := strconv.Atoi(strings.TrimSpace(id.Value)); err == nil && v > 0 {
+				return v
+			}
+		}
+	}
+	return fallback
+}
+
+// ReadNFOMeta 读取视频文件旁的已有 NFO（包括旧版 .info 文件），获取 TMDB ID 和季集号
+// 电影从 <视频文件名>.nfo 或 movie.nfo 中读取，电视剧从 tvshow.nfo 读取剧集 ID，从 <视频文件名>.nfo 读取季集号
+// 未找到可用的 NFO 时返回 nil
+func ReadNFOMeta(dstFile storage.StoragePath, mediaType meta.MediaType) *NFOMeta {
+	dir := dstFile.Parent()
+	videoNFO := func(ext string) storage.StoragePath {
+		path, err := storage_controller.GetPath(utils.ChangeExt(dstFile.GetPath(), ext), dstFile.GetStorageType())
+		if err != nil {
+			return nil
+		}
+		return path
+	}
+	exists := func(path storage.StoragePath) bool {
+		if path == nil {
+			return false
+		}
+		exist, err := storage_controller.Exist(path)
+		return err == nil && exist
+	}
+
+	if mediaType != meta.MediaTypeTV {
+		for _, path := range []storage.StoragePath{videoNFO(".nfo"), dir.Join("movie.nfo"), dir.Join("movie.info")} {
+			if !exists(path) {
+				continue
+			}
+			data, err := ReadMovieNFO(path)
+			if err != nil {
+				continue // 可能是剧集 NFO
+			}
+			if id := nfoTMDBID(data.UniqueIDs, data.TMDBID); id > 0 {
+				return &NFOMeta{MediaType: meta.MediaTypeMovie, TMDBID: id, Season: -1, Episode: -1, Source: path.String()}
+			}
+		}
+	}
+	if mediaType == meta.MediaTypeMovie {
+		return nil
+	}
+
+	result := NFOMeta{MediaType: meta.MediaTypeTV, Season: -1, Episode: -1}
+	for _, path := range []storage.StoragePath{videoNFO(".nfo"), videoNFO(".info")} {
+		if !exists(path) {
+			continue
+		}
+		if data, err := ReadTVEpisodeNFO(path); err == nil && data.Episode > 0 {
+			result.Season, result.Episode = data.Season, data.Episode
+			break
+		}
+	}
+	for _, path := range []storage.StoragePath{dir.Join("tvshow.nfo"), dir.Parent().Join("tvshow.nfo"), dir.Parent().Join("tv.info")} {
+		if !exists(path) {
+			continue
+		}
+		data, err := ReadTVSeriesNFO(path)
+		if err != nil {
+			continue
+		}
+		if id := nfoTMDBID(data.UniqueIDs, data.TMDBID); id > 0 {
+			result.TMDBID = id
+			result.Source = path.String()
+			return &result
+		}
+	}
+	return nil
+}
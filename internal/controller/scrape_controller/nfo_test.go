@@ -0,0 +1,92 @@
+package scrape_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/scrape_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas/storage"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNFOMeta(t *testing.T) {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+
+	root := filepath.ToSlash(t.TempDir())
+	files := map[string]string{
+		"Movie (2020)/Movie (2020).nfo": `<?xml version="1.0" encoding="UTF-8"?>
+<movie>
+  <title>Movie</title>
+  <lockdata>true</lockdata>
+  <uniqueid type="imdb">tt0000001</uniqueid>
+  <uniqueid type="tmdb" default="true">550</uniqueid>
+</movie>`,
+		"Show/tvshow.nfo":               `<tvshow><title>Show</title><uniqueid type="tmdbid">1399</uniqueid></tvshow>`,
+		"Show/Season 1/Show S01E05.nfo": `<episodedetails><title>Ep</title><season>1</season><episode>5</episode></episodedetails>`,
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+
+	movieFile := storage.NewStoragePath(storage.StorageLocal, root+"/Movie (2020)/Movie (2020).mkv")
+	movie, err := scrape_controller.ReadMovieNFO(storage.NewStoragePath(storage.StorageLocal, root+"/Movie (2020)/Movie (2020).nfo"))
+	require.NoError(t, err)
+	require.True(t, movie.LockData)
+	require.Equal(t, "Movie", movie.Title)
+
+	nfoMeta := scrape_controller.ReadNFOMeta(movieFile, meta.MediaTypeUnknown)
+	require.NotNil(t, nfoMeta)
+	require.Equal(t, meta.MediaTypeMovie, nfoMeta.MediaType)
+	require.Equal(t, 550, nfoMeta.TMDBID)
+
+	episodeFile := storage.NewStoragePath(storage.StorageLocal, root+"/Show/Season 1/Show S01E05.mkv")
+	nfoMeta = scrape_controller.ReadNFOMeta(episodeFile, meta.MediaTypeUnknown)
+	require.NotNil(t, nfoMeta)
+	require.Equal(t, meta.MediaTypeTV, nfoMeta.MediaType)
+	require.Equal(t, 1399, nfoMeta.TMDBID)
+	require.Equal(t, 1, nfoMeta.Season)
+	require.Equal(t, 5, nfoMeta.Episode)
+
+	require.Nil(t, scrape_controller.ReadNFOMeta(episodeFile, meta.MediaTypeMovie))
+}
+
+func TestMovieSetNFO(t *testing.T) {
+	data := scrape_controller.MovieMetaData{
+		Title: "Movie",
+		Set:   &scrape_controller.MovieSet{TMDBColID: 10, Name: "Star Wars Collection"},
+	}
+	xmlData, err := data.XML()
+	require.NoError(t, err)
+	require.Contains(t, string(xmlData), `<set tmdbcolid="10">`)
+	require.Contains(t, string(xmlData), `<name>Star Wars Collection</name>`)
+	require.Contains(t, string(xmlData), `<overview></overview>`)
+}
+
+func TestMovieDetailNFO(t *testing.T) {
+	data := scrape_controller.MovieMetaData{
+		Title:         "Movie",
+		Runtime:       120,
+		MPAA:          "PG-13",
+		Certification: "US:PG-13",
+		Countries:     []string{"United States of America"},
+		Studios:       []scrape_controller.Studio{{Name: "Lucasfilm"}},
+		Tags:          []string{"space opera"},
+		Ratings:       []scrape_controller.Rating{{Default: true, Max: 10, Name: "themoviedb", Value: 8.2, Votes: 100}},
+	}
+	xmlData, err := data.XML()
+	require.NoError(t, err)
+	require.Contains(t, string(xmlData), `<runtime>120</runtime>`)
+	require.Contains(t, string(xmlData), `<mpaa>PG-13</mpaa>`)
+	require.Contains(t, string(xmlData), `<certification>US:PG-13</certification>`)
+	require.Contains(t, string(xmlData), `<country>United States of America</country>`)
+	require.Contains(t, string(xmlData), `<studio>Lucasfilm</studio>`)
+	require.Contains(t, string(xmlData), `<tag>space opera</tag>`)
+	require.Contains(t, string(xmlData), `<rating default="true" max="10" name="themoviedb">`)
+}
@@ -0,0 +1,61 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/probe"
+	"MediaTools/internal/schemas/storage"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProbeFile 读取媒体文件的容器头部，获取时长和音视频、字幕流信息
+func ProbeFile(path storage.StoragePath) (*probe.Info, error) {
+	reader, err := storage_controller.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取媒体文件 %s 失败: %w", path, err)
+	}
+	defer reader.Close()
+
+	info, err := probe.Probe(reader)
+	if err != nil {
+		return nil, fmt.Errorf("解析媒体文件 %s 失败: %w", path, err)
+	}
+	return info, nil
+}
+
+// genFileInfo 生成 NFO 中的流信息，info 为空时探测媒体文件，探测失败时返回 nil
+func genFileInfo(path storage.StoragePath, info *probe.Info) *FileInfo {
+	if info == nil {
+		var err error
+		info, err = ProbeFile(path)
+		if err != nil {
+			logrus.Debugf("获取媒体流信息失败: %v", err)
+			return nil
+		}
+	}
+
+	var fileInfo FileInfo
+	for _, v := range info.Videos {
+		detail := VideoDetail{
+			Codec:             v.Codec,
+			Width:             v.Width,
+			Height:            v.Height,
+			DurationInSeconds: int(info.Duration.Seconds()),
+			HDRType:           strings.ToLower(v.HDR),
+		}
+		if v.Width > 0 && v.Height > 0 {
+			detail.Aspect = math.Round(float64(v.Width)/float64(v.Height)*100) / 100
+		}
+		fileInfo.Videos = append(fileInfo.Videos, detail)
+	}
+	for _, a := range info.Audios {
+		fileInfo.Audios = append(fileInfo.Audios, AudioDetail{Codec: a.Codec, Language: a.Language, Channels: a.Channels})
+	}
+	for _, s := range info.Subtitles {
+		fileInfo.Subtitles = append(fileInfo.Subtitles, SubtitleDetail{Codec: s.Codec, Language: s.Language})
+	}
+	return &fileInfo
+}
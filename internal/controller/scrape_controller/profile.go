@@ -0,0 +1,256 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/schemas/storage"
+	"MediaTools/utils"
+	"fmt"
+	"strings"
+)
+
+// ProfileName 刮削输出方案名称
+type ProfileName string
+
+const (
+	ProfileKodi     ProfileName = "kodi"
+	ProfileJellyfin ProfileName = "jellyfin"
+	ProfileEmby     ProfileName = "emby"
+	ProfilePlex     ProfileName = "plex" // Plex 本地媒体资源（不读取 NFO，仅使用图片）
+)
+
+// DefaultProfile 未指定刮削输出方案时使用的方案
+const DefaultProfile = ProfileKodi
+
+// ArtworkType 图片类型
+type ArtworkType uint8
+
+const (
+	ArtworkPoster       ArtworkType = iota // 海报
+	ArtworkFanart                          // 背景图（TMDB 剧照）
+	ArtworkBackground                      // 额外背景图（Fanart 背景图）
+	ArtworkLogo                            // Logo
+	ArtworkLandscape                       // 横版缩略图
+	ArtworkBanner                          // 横幅
+	ArtworkClearArt                        // Clear Art
+	ArtworkDisc                            // 光盘图
+	ArtworkCharacterArt                    // 角色图
+)
+
+// Profile 刮削输出方案，决定 NFO 文件名、图片文件名以及输出的 XML 字段
+type Profile struct {
+	Name ProfileName
+
+	NFO       bool   // 是否生成 NFO 文件
+	MovieNFO  string // 电影 NFO 文件名，为空时使用 <视频文件名>.nfo
+	TVShowNFO string // 电视剧 NFO 文件名（位于剧集目录）
+	SeasonNFO string // 季 NFO 文件名（位于季目录）
+
+	Artwork         map[ArtworkType]string // 电影/电视剧图片文件名（不含扩展名），未配置的类型不保存
+	SeasonPoster    string                 // 季海报文件名格式（位于剧集目录），%02d 为季号
+	SpecialsPoster  string                 // 特别篇海报文件名（位于剧集目录）
+	EpisodeThumbTag string                 // 单集缩略图文件名后缀：<视频文件名><后缀>
+
+	FileInfo  bool // 是否输出 fileinfo 媒体流信息
+	LockData  bool // 是否输出 lockdata
+	LegacyIDs bool // 是否输出 tmdbid、imdbid 等独立 ID 字段（Kodi 只读取 uniqueid）
+}
+
+var profiles = map[ProfileName]*Profile{
+	ProfileKodi: {
+		Name:      ProfileKodi,
+		NFO:       true,
+		TVShowNFO: "tvshow.nfo",
+		SeasonNFO: "season.nfo",
+		Artwork: map[ArtworkType]string{
+			ArtworkPoster:       "poster",
+			ArtworkFanart:       "fanart",
+			ArtworkBackground:   "fanart1",
+			ArtworkLogo:         "clearlogo",
+			ArtworkLandscape:    "landscape",
+			ArtworkBanner:       "banner",
+			ArtworkClearArt:     "clearart",
+			ArtworkDisc:         "discart",
+			ArtworkCharacterArt: "characterart",
+		},
+		SeasonPoster:    "season%02d-poster",
+		SpecialsPoster:  "season-specials-poster",
+		EpisodeThumbTag: "-thumb",
+		FileInfo:        true,
+	},
+	ProfileJellyfin: {
+		Name:      ProfileJellyfin,
+		NFO:       true,
+		MovieNFO:  "movie.nfo",
+		TVShowNFO: "tvshow.nfo",
+		SeasonNFO: "season.nfo",
+		Artwork: map[ArtworkType]string{
+			ArtworkPoster:     "poster",
+			ArtworkFanart:     "backdrop",
+			ArtworkBackground: "backdrop1",
+			ArtworkLogo:       "logo",
+			ArtworkLandscape:  "landscape",
+			ArtworkBanner:     "banner",
+			ArtworkClearArt:   "clearart",
+			ArtworkDisc:       "disc",
+		},
+		SeasonPoster:    "season%02d-poster",
+		SpecialsPoster:  "season-specials-poster",
+		EpisodeThumbTag: "-thumb",
+		FileInfo:        true,
+		LockData:        true,
+		LegacyIDs:       true,
+	},
+	ProfileEmby: {
+		Name:      ProfileEmby,
+		NFO:       true,
+		TVShowNFO: "tvshow.nfo",
+		SeasonNFO: "season.nfo",
+		Artwork: map[ArtworkType]string{
+			ArtworkPoster:     "folder",
+			ArtworkFanart:     "backdrop",
+			ArtworkBackground: "backdrop1",
+			ArtworkLogo:       "logo",
+			ArtworkLandscape:  "landscape",
+			ArtworkBanner:     "banner",
+			ArtworkClearArt:   "clearart",
+			ArtworkDisc:       "disc",
+		},
+		SeasonPoster:    "season%02d-poster",
+		SpecialsPoster:  "season-specials-poster",
+		EpisodeThumbTag: "-thumb",
+		LockData:        true,
+		LegacyIDs:       true,
+	},
+	ProfilePlex: {
+		Name: ProfilePlex,
+		Artwork: map[ArtworkType]string{
+			ArtworkPoster: "poster",
+			ArtworkFanart: "fanart",
+			ArtworkBanner: "banner",
+		},
+		SeasonPoster:   "season%02d",
+		SpecialsPoster: "season-specials-poster",
+	},
+}
+
+// ProfileNames 返回所有支持的刮削输出方案名称
+func ProfileNames() []ProfileName {
+	return []ProfileName{ProfileKodi, ProfileJellyfin, ProfileEmby, ProfilePlex}
+}
+
+// GetProfile 获取刮削输出方案，名称为空时返回默认方案
+func GetProfile(name string) (*Profile, error) {
+	if name == "" {
+		return profiles[DefaultProfile], nil
+	}
+	profile, ok := profiles[ProfileName(strings.ToLower(name))]
+	if !ok {
+		return nil, fmt.Errorf("不支持的刮削输出方案: %s", name)
+	}
+	return profile, nil
+}
+
+// libraryProviders 目标文件所在媒体库的元数据来源优先级，不属于任何媒体库时为空（只使用 TMDB）
+func libraryProviders(dstFile storage.StoragePath) []string {
+	if lib := config.MatchDstLibrary(dstFile); lib != nil {
+		return lib.Providers
+	}
+	return nil
+}
+
+// MatchProfile 根据目标文件所在的媒体库选择刮削输出方案，不属于任何媒体库时使用默认方案
+func MatchProfile(dstFile storage.StoragePath) *Profile {
+	if lib := config.MatchDstLibrary(dstFile); lib != nil {
+		if profile, err := GetProfile(lib.ScrapeProfile); err == nil {
+			return profile
+		}
+	}
+	return profiles[DefaultProfile]
+}
+
+// MovieNFOPath 电影 NFO 文件路径
+func (p *Profile) MovieNFOPath(dstFile *storage.StorageFileInfo) string {
+	switch {
+	case p.MovieNFO != "":
+		return mediaDir(dstFile).Join(p.MovieNFO).GetPath()
+	case dstFile.Type == storage.FileTypeDirectory: // 光盘文件夹中的电影使用 movie.nfo
+		return dstFile.Join("movie.nfo").GetPath()
+	}
+	return utils.ChangeExt(dstFile.Path, ".nfo")
+}
+
+// mediaDir 媒体所在目录（图片、电影 NFO 的保存位置），光盘文件夹本身即为媒体目录
+func mediaDir(dstFile *storage.StorageFileInfo) storage.StoragePath {
+	if dstFile.Type == storage.FileTypeDirectory {
+		return dstFile
+	}
+	return dstFile.Parent()
+}
+
+// mediaBase 去掉扩展名的媒体路径，用于生成同名的剧集 NFO 和缩略图
+// 光盘文件夹没有扩展名，名称中的「.」不作为扩展名处理
+func mediaBase(dstFile *storage.StorageFileInfo) string {
+	if dstFile.Type == storage.FileTypeDirectory {
+		return dstFile.Path
+	}
+	return utils.ChangeExt(dstFile.Path, "")
+}
+
+// ArtworkName 图片文件名（不含扩展名），为空表示该方案不保存此类图片
+func (p *Profile) ArtworkName(t ArtworkType) string {
+	return p.Artwork[t]
+}
+
+// SeasonPosterName 季海报文件名（不含扩展名）
+func (p *Profile) SeasonPosterName(season int) string {
+	if season == 0 {
+		return p.SpecialsPoster
+	}
+	return fmt.Sprintf(p.SeasonPoster, season)
+}
+
+// apply 按方案裁剪 NFO 中输出的字段
+func (p *Profile) apply(data InfoData) {
+	switch d := data.(type) {
+	case *MovieMetaData:
+		if !p.FileInfo {
+			d.FileInfo = nil
+		}
+		if !p.LockData {
+			d.LockData = false
+		}
+		if !p.LegacyIDs {
+			d.TMDBID, d.IMDbID, d.TVDBID = 0, "", ""
+		}
+	case *TVSeriesMetaData:
+		if !p.LockData {
+			d.LockData = false
+		}
+		if !p.LegacyIDs {
+			d.TMDBID, d.IMDbID, d.TVDBID = 0, "", ""
+		}
+	case *TVSeasonMetaData:
+		if !p.LockData {
+			d.LockData = false
+		}
+	case *TVEpisodeMetaData:
+		if !p.FileInfo {
+			d.FileInfo = nil
+		}
+		if !p.LockData {
+			d.LockData = false
+		}
+		if !p.LegacyIDs {
+			d.TMDBID, d.IMDbID, d.TVDBID = 0, "", ""
+		}
+	}
+}
+
+// artworkTarget 图片保存路径（不含扩展名），该方案不保存此类图片时返回 false
+func (p *Profile) artworkTarget(dir storage.StoragePath, t ArtworkType) (string, bool) {
+	name := p.ArtworkName(t)
+	if name == "" {
+		return "", false
+	}
+	return dir.Join(name).GetPath(), true
+}
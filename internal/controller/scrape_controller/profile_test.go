@@ -0,0 +1,54 @@
+package scrape_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/scrape_controller"
+	"MediaTools/internal/schemas/storage"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovieNFOPath(t *testing.T) {
+	kodi, err := scrape_controller.GetProfile(string(scrape_controller.ProfileKodi))
+	require.NoError(t, err)
+	jellyfin, err := scrape_controller.GetProfile(string(scrape_controller.ProfileJellyfin))
+	require.NoError(t, err)
+
+	file := storage.NewFileInfo(storage.StorageLocal, "/media/movies/Inception (2010)/Inception (2010) -1080p.mkv", 0, storage.FileTypeFile, time.Time{})
+	require.Equal(t, "/media/movies/Inception (2010)/Inception (2010) -1080p.nfo", kodi.MovieNFOPath(file))
+	require.Equal(t, "/media/movies/Inception (2010)/movie.nfo", jellyfin.MovieNFOPath(file))
+
+	// 光盘文件夹（Title (Year)/BDMV）的 NFO 写在 BDMV 旁
+	disc := storage.NewFileInfo(storage.StorageLocal, "/media/movies/Inception (2010)", 0, storage.FileTypeDirectory, time.Time{})
+	require.Equal(t, "/media/movies/Inception (2010)/movie.nfo", kodi.MovieNFOPath(disc))
+	require.Equal(t, "/media/movies/Inception (2010)/movie.nfo", jellyfin.MovieNFOPath(disc))
+}
+
+func TestMatchProfile(t *testing.T) {
+	libraries := config.Media.Libraries
+	t.Cleanup(func() { config.Media.Libraries = libraries })
+	config.Media.Libraries = []config.LibraryConfig{
+		{DstType: storage.StorageLocal, DstPath: "/media/tv", ScrapeProfile: "jellyfin"},
+		{DstType: storage.StorageLocal, DstPath: "/media", ScrapeProfile: "emby"},
+		{DstType: storage.StorageLocal, DstPath: "/media/tv/anime/", ScrapeProfile: "plex"},
+	}
+
+	testCases := []struct {
+		path     string
+		expected scrape_controller.ProfileName
+	}{
+		{"/media/tv/Show (2020)/Season 1/Show S01E01.mkv", scrape_controller.ProfileJellyfin},
+		{"/media/tv2/Show (2020)/Season 1/Show S01E01.mkv", scrape_controller.ProfileEmby}, // 不匹配 /media/tv
+		{"/media/tv/anime/Show (2020)/Season 1/Show S01E01.mkv", scrape_controller.ProfilePlex},
+		{"/media/movies/Movie (2020)/Movie (2020).mkv", scrape_controller.ProfileEmby},
+		{"/mediaserver/Movie (2020)/Movie (2020).mkv", scrape_controller.DefaultProfile},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			profile := scrape_controller.MatchProfile(storage.NewStoragePath(storage.StorageLocal, tc.path))
+			require.Equal(t, tc.expected, profile.Name)
+		})
+	}
+}
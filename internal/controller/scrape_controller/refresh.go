@@ -0,0 +1,448 @@
+package scrape_controller
+
+import (
+	"MediaTools/extensions"
+	"MediaTools/internal/controller/provider_controller"
+	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/controller/task_controller"
+	"MediaTools/internal/database"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/schemas/storage"
+	"MediaTools/utils"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RefreshMode 媒体库刷新模式
+type RefreshMode string
+
+const (
+	RefreshMissing  RefreshMode = "missing"  // 仅补全缺失或无效的 NFO 和图片
+	RefreshMetadata RefreshMode = "metadata" // 仅重新生成 NFO
+	RefreshImages   RefreshMode = "images"   // 仅重新下载图片
+	RefreshForce    RefreshMode = "force"    // 重新生成 NFO 并重新下载所有图片
+)
+
+// ParseRefreshMode 解析刷新模式，为空时使用 missing
+func ParseRefreshMode(s string) (RefreshMode, error) {
+	switch mode := RefreshMode(strings.ToLower(s)); mode {
+	case "":
+		return RefreshMissing, nil
+	case RefreshMissing, RefreshMetadata, RefreshImages, RefreshForce:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("不支持的刷新模式: %s", s)
+	}
+}
+
+// RefreshFailure 刷新失败的文件
+type RefreshFailure struct {
+	Path    string `json:"path"`    // 文件路径
+	Message string `json:"message"` // 错误信息
+}
+
+// RefreshReport 媒体库刷新报告
+type RefreshReport struct {
+	TaskID    string           `json:"task_id"`            // 任务 ID
+	Mode      RefreshMode      `json:"mode"`               // 刷新模式
+	Running   bool             `json:"running"`            // 是否正在运行
+	StartTime time.Time        `json:"start_time"`         // 开始时间
+	EndTime   time.Time        `json:"end_time,omitzero"`  // 结束时间
+	Total     int              `json:"total"`              // 文件总数
+	Refreshed int              `json:"refreshed"`          // 已刷新的文件数
+	Skipped   int              `json:"skipped"`            // 跳过的文件数（无需刷新、附加内容、光盘等）
+	Failed    int              `json:"failed"`             // 刷新失败的文件数
+	Failures  []RefreshFailure `json:"failures,omitempty"` // 失败详情
+}
+
+const maxRefreshReports = 50 // 保留的刷新报告数量
+
+var (
+	reportLock   sync.RWMutex
+	reports      = make(map[string]*RefreshReport)
+	reportIDList []string // 按提交顺序排列的报告 ID
+)
+
+func addRefreshReport(report *RefreshReport) {
+	reportLock.Lock()
+	defer reportLock.Unlock()
+
+	reports[report.TaskID] = report
+	reportIDList = append(reportIDList, report.TaskID)
+	for len(reportIDList) > maxRefreshReports {
+		delete(reports, reportIDList[0])
+		reportIDList = reportIDList[1:]
+	}
+}
+
+func updateRefreshReport(report *RefreshReport, fn func(r *RefreshReport)) {
+	reportLock.Lock()
+	defer reportLock.Unlock()
+	fn(report)
+}
+
+func copyRefreshReport(report *RefreshReport) *RefreshReport {
+	r := *report
+	r.Failures = slices.Clone(report.Failures)
+	return &r
+}
+
+// GetRefreshReport 获取刷新报告
+func GetRefreshReport(taskID string) (*RefreshReport, error) {
+	reportLock.RLock()
+	defer reportLock.RUnlock()
+
+	report, ok := reports[taskID]
+	if !ok {
+		return nil, fmt.Errorf("刷新报告 %s 不存在", taskID)
+	}
+	return copyRefreshReport(report), nil
+}
+
+// ListRefreshReports 获取所有刷新报告（按提交时间倒序）
+func ListRefreshReports() []*RefreshReport {
+	reportLock.RLock()
+	defer reportLock.RUnlock()
+
+	list := make([]*RefreshReport, 0, len(reportIDList))
+	for i := len(reportIDList) - 1; i >= 0; i-- {
+		list = append(list, copyRefreshReport(reports[reportIDList[i]]))
+	}
+	return list
+}
+
+// recognizeMedia 识别媒体并补全元数据（测试时替换）
+var recognizeMedia = provider_controller.RecognizeAndEnrichMedia
+
+// refreshItem 待刷新的媒体文件
+type refreshItem struct {
+	file      storage.StoragePath
+	mediaType meta.MediaType
+	tmdbID    int
+	season    int
+	episode   int
+}
+
+// RefreshLibrary 提交媒体库刷新任务，复用已有的 TMDB ID 重新生成缺失或过期的 NFO 和图片
+// dir 不为 nil 时遍历该目录下的视频文件；fromHistory 为 true 时改为使用转移成功的历史记录（dir 不为 nil 时仅包含该目录下的记录）
+func RefreshLibrary(dir storage.StoragePath, fromHistory bool, mode RefreshMode) (*task.Task, error) {
+	if dir == nil && !fromHistory {
+		return nil, fmt.Errorf("需要指定媒体库目录或使用转移历史记录")
+	}
+	mode, err := ParseRefreshMode(string(mode))
+	if err != nil {
+		return nil, err
+	}
+
+	name := "刷新转移历史记录"
+	if dir != nil {
+		name = "刷新媒体库 " + dir.String()
+	}
+	report := &RefreshReport{Mode: mode, Running: true}
+	t := task_controller.SubmitScrapeTask(name, func(ctx context.Context) {
+		updateRefreshReport(report, func(r *RefreshReport) { r.StartTime = time.Now() })
+		defer updateRefreshReport(report, func(r *RefreshReport) {
+			r.Running = false
+			r.EndTime = time.Now()
+		})
+
+		var (
+			items []refreshItem
+			err   error
+		)
+		if fromHistory {
+			items, err = collectRefreshItemsFromHistory(ctx, dir)
+		} else {
+			items, err = collectRefreshItemsFromDir(ctx, dir)
+		}
+		if err != nil {
+			logrus.Warningf("%s失败：%v", name, err)
+			updateRefreshReport(report, func(r *RefreshReport) {
+				r.Failures = append(r.Failures, RefreshFailure{Message: err.Error()})
+			})
+			return
+		}
+		updateRefreshReport(report, func(r *RefreshReport) { r.Total = len(items) })
+
+		for _, item := range items {
+			select {
+			case <-ctx.Done():
+				logrus.Infof("%s任务被取消", name)
+				return
+			default:
+			}
+			refreshed, err := refreshFile(ctx, item, mode)
+			updateRefreshReport(report, func(r *RefreshReport) {
+				switch {
+				case err != nil:
+					r.Failed++
+					r.Failures = append(r.Failures, RefreshFailure{Path: item.file.String(), Message: err.Error()})
+				case refreshed:
+					r.Refreshed++
+				default:
+					r.Skipped++
+				}
+			})
+			if err != nil {
+				logrus.Warningf("刷新 %s 失败：%v", item.file, err)
+			}
+		}
+
+		updateRefreshReport(report, func(r *RefreshReport) {
+			logrus.Infof("%s完成：共 %d 个文件，刷新 %d 个，跳过 %d 个，失败 %d 个", name, r.Total, r.Refreshed, r.Skipped, r.Failed)
+		})
+	})
+	updateRefreshReport(report, func(r *RefreshReport) { r.TaskID = t.ID })
+	addRefreshReport(report)
+	return t, nil
+}
+
+// collectRefreshItemsFromHistory 从转移成功的历史记录中收集待刷新文件
+func collectRefreshItemsFromHistory(ctx context.Context, dir storage.StoragePath) ([]refreshItem, error) {
+	status := true
+	histories, err := database.QueryMediaTransferHistory(ctx, nil, nil, storage.StorageUnknown, "", storage.TransferUnknown, &status, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []refreshItem
+	for history, err := range histories {
+		if err != nil {
+			logrus.Warningf("读取转移历史记录失败：%v", err)
+			continue
+		}
+		if history.Item == nil || history.DstPath == "" || history.Item.ExtraType.IsExtra() ||
+			history.Item.MediaType == meta.MediaTypeMusic {
+			continue
+		}
+		if dir != nil && (history.DstType != dir.GetStorageType() || !strings.HasPrefix(history.DstPath, strings.TrimSuffix(dir.GetPath(), "/")+"/")) {
+			continue
+		}
+		file, err := storage_controller.GetPath(history.DstPath, history.DstType)
+		if err != nil {
+			logrus.Warningf("获取 %s 句柄失败：%v", history.DstPath, err)
+			continue
+		}
+		items = append(items, refreshItem{
+			file:      file,
+			mediaType: history.Item.MediaType,
+			tmdbID:    history.Item.TMDBID,
+			season:    history.Item.Season,
+			episode:   history.Item.Episode,
+		})
+	}
+	return items, nil
+}
+
+// collectRefreshItemsFromDir 遍历媒体库目录收集视频文件，跳过附加内容和光盘目录
+func collectRefreshItemsFromDir(ctx context.Context, dir storage.StoragePath) ([]refreshItem, error) {
+	var items []refreshItem
+	var walk func(dir storage.StoragePath) error
+	walk = func(dir storage.StoragePath) error {
+		entries, err := storage_controller.List(dir)
+		if err != nil {
+			return err
+		}
+		for entry, err := range entries {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("遍历媒体库操作被取消: %v", ctx.Err())
+			default:
+			}
+			if err != nil {
+				logrus.Warningf("遍历目录 %s 失败：%v", dir, err)
+				continue
+			}
+			if entry.GetPath() == dir.GetPath() {
+				continue // 跳过目录本身
+			}
+			if entry.GetFileType() == storage.FileTypeDirectory {
+				if !skipRefreshDir(entry.GetName()) {
+					if err := walk(entry); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			ext := entry.LowerExt()
+			if slices.Contains(extensions.MediaExtensions, ext) && !slices.Contains(extensions.DiscImageExtensions, ext) {
+				items = append(items, refreshItem{file: entry, season: -1, episode: -1})
+			}
+		}
+		return nil
+	}
+	if err := walk(dir); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// skipRefreshDir 判断遍历媒体库时是否跳过该目录（附加内容目录、光盘结构目录）
+func skipRefreshDir(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "bdmv", "video_ts", "certificate", "extras":
+		return true
+	}
+	for t := meta.ExtraTypeTrailer; t.IsExtra(); t++ {
+		if strings.ToLower(t.FolderName()) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshFile 刷新单个媒体文件，返回是否进行了刷新
+func refreshFile(ctx context.Context, item refreshItem, mode RefreshMode) (bool, error) {
+	file, err := storage_controller.GetDetail(item.file)
+	if err != nil {
+		return false, fmt.Errorf("获取文件信息失败：%w", err)
+	}
+	if file.Type == storage.FileTypeDirectory { // 光盘文件夹
+		return false, nil
+	}
+
+	videoMeta, _, _ := recognize_controller.ParseVideoMeta(file.Name)
+	if videoMeta.ExtraType.IsExtra() {
+		return false, nil
+	}
+	if item.mediaType != meta.MediaTypeUnknown {
+		videoMeta.MediaType = item.mediaType
+	}
+	if item.tmdbID != 0 {
+		videoMeta.TMDBID = item.tmdbID
+		if item.episode != -1 {
+			videoMeta.Season, videoMeta.Episode = item.season, item.episode
+		}
+	} else if nfoMeta := ReadNFOMeta(file, videoMeta.MediaType); nfoMeta != nil {
+		videoMeta.MediaType = nfoMeta.MediaType
+		videoMeta.TMDBID = nfoMeta.TMDBID
+		if nfoMeta.Episode != -1 {
+			videoMeta.Season, videoMeta.Episode = nfoMeta.Season, nfoMeta.Episode
+		}
+	}
+
+	profile := MatchProfile(file)
+	var needInfo, needImages bool
+	switch mode {
+	case RefreshMissing:
+		needInfo = profile.NFO && !nfoComplete(file, videoMeta.MediaType, profile)
+		needImages = !imagesComplete(file, videoMeta, profile)
+	case RefreshMetadata:
+		needInfo = profile.NFO
+	case RefreshImages:
+		needImages = true
+	case RefreshForce:
+		needInfo, needImages = profile.NFO, true
+	}
+	if !needInfo && !needImages {
+		return false, nil
+	}
+
+	if videoMeta.TMDBID == 0 {
+		logrus.Infof("%s 没有已保存的 TMDB ID，重新识别", file)
+	}
+	info, err := recognizeMedia(ctx, videoMeta, libraryProviders(file))
+	if err != nil {
+		return false, err
+	}
+	imageCtx := ctx
+	if mode != RefreshMissing {
+		imageCtx = WithOverwriteImage(ctx)
+	}
+
+	switch info.MediaType {
+	case meta.MediaTypeMovie:
+		if needInfo {
+			ScrapeMovieInfo(ctx, file, info, profile)
+		}
+		if needImages {
+			ScrapeMovieImage(imageCtx, file, info, profile)
+		}
+	case meta.MediaTypeTV:
+		if needInfo {
+			ScrapeTVInfo(ctx, file, info, profile)
+		}
+		if needImages {
+			ScrapeTVImage(imageCtx, file, info, profile)
+		}
+	default:
+		return false, fmt.Errorf("不支持的媒体类型: %s", info.MediaType)
+	}
+	return true, nil
+}
+
+// nfoComplete 判断 NFO 是否齐全且包含 TMDB ID（锁定的 NFO 视为齐全）
+func nfoComplete(file *storage.StorageFileInfo, mediaType meta.MediaType, profile *Profile) bool {
+	valid := func(path string, read func(storage.StoragePath) (bool, error)) bool {
+		p, err := storage_controller.GetPath(path, file.StorageType)
+		if err != nil {
+			return false
+		}
+		exist, err := storage_controller.Exist(p)
+		if err != nil || !exist {
+			return false
+		}
+		ok, err := read(p)
+		return err == nil && ok
+	}
+
+	if mediaType != meta.MediaTypeTV {
+		return valid(profile.MovieNFOPath(file), func(p storage.StoragePath) (bool, error) {
+			data, err := ReadMovieNFO(p)
+			if err != nil {
+				return false, err
+			}
+			return data.LockData || nfoTMDBID(data.UniqueIDs, data.TMDBID) > 0, nil
+		})
+	}
+
+	seasonDir := file.Parent()
+	return valid(utils.ChangeExt(file.Path, ".nfo"), func(p storage.StoragePath) (bool, error) {
+		data, err := ReadTVEpisodeNFO(p)
+		return err == nil && (data.LockData || data.Episode > 0), err
+	}) && valid(seasonDir.Join(profile.SeasonNFO).GetPath(), func(p storage.StoragePath) (bool, error) {
+		_, err := ReadTVSeasonNFO(p)
+		return err == nil, err
+	}) && valid(seasonDir.Parent().Join(profile.TVShowNFO).GetPath(), func(p storage.StoragePath) (bool, error) {
+		data, err := ReadTVSeriesNFO(p)
+		if err != nil {
+			return false, err
+		}
+		return data.LockData || nfoTMDBID(data.UniqueIDs, data.TMDBID) > 0, nil
+	})
+}
+
+// imagesComplete 判断主要图片（海报、背景图、Logo、季海报、单集缩略图）是否齐全
+func imagesComplete(file *storage.StorageFileInfo, videoMeta *meta.VideoMeta, profile *Profile) bool {
+	exists := func(target string) bool {
+		p, err := findArtworkFile(target, file.StorageType)
+		return err == nil && p != nil
+	}
+	artworkExists := func(dir storage.StoragePath, t ArtworkType) bool {
+		target, ok := profile.artworkTarget(dir, t)
+		return !ok || exists(target)
+	}
+
+	if videoMeta.MediaType != meta.MediaTypeTV {
+		dir := file.Parent()
+		return artworkExists(dir, ArtworkPoster) && artworkExists(dir, ArtworkFanart) && artworkExists(dir, ArtworkLogo)
+	}
+
+	serieDir := file.Parent().Parent()
+	if !artworkExists(serieDir, ArtworkPoster) || !artworkExists(serieDir, ArtworkFanart) || !artworkExists(serieDir, ArtworkLogo) {
+		return false
+	}
+	if videoMeta.Season != -1 && !exists(serieDir.Join(profile.SeasonPosterName(videoMeta.Season)).GetPath()) {
+		return false
+	}
+	return exists(utils.ChangeExt(file.Path, "") + profile.EpisodeThumbTag)
+}
@@ -0,0 +1,177 @@
+package scrape_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// setupRefreshLibrary 创建测试媒体库：
+// Movie A 的 NFO 和图片齐全；Movie B 的 NFO 没有 TMDB ID；Show 缺少单集缩略图；附加内容、光盘和镜像不参与刷新
+func setupRefreshLibrary(t *testing.T) string {
+	_, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+	require.NoError(t, recognize_controller.InitCustomWord())
+	libraries := config.Media.Libraries
+	t.Cleanup(func() { config.Media.Libraries = libraries })
+	config.Media.Libraries = nil // 使用默认的 Kodi 方案
+
+	root := filepath.ToSlash(t.TempDir())
+	files := map[string]string{
+		"Movie A (2020)/Movie A (2020).mkv":                  "",
+		"Movie A (2020)/Movie A (2020).nfo":                  `<movie><title>Movie A</title><uniqueid type="tmdb" default="true">550</uniqueid></movie>`,
+		"Movie A (2020)/poster.jpg":                          "",
+		"Movie A (2020)/fanart.jpg":                          "",
+		"Movie A (2020)/clearlogo.png":                       "",
+		"Movie A (2020)/trailers/Movie A (2020)-trailer.mkv": "",
+		"Movie B (2021)/Movie B (2021).mkv":                  "",
+		"Movie B (2021)/Movie B (2021).nfo":                  `<movie><title>Movie B</title></movie>`,
+		"Movie B (2021)/poster.jpg":                          "",
+		"Movie B (2021)/fanart.jpg":                          "",
+		"Movie B (2021)/clearlogo.png":                       "",
+		"Show (2019)/tvshow.nfo":                             `<tvshow><title>Show</title><uniqueid type="tmdb">1399</uniqueid></tvshow>`,
+		"Show (2019)/poster.jpg":                             "",
+		"Show (2019)/fanart.jpg":                             "",
+		"Show (2019)/clearlogo.png":                          "",
+		"Show (2019)/season01-poster.jpg":                    "",
+		"Show (2019)/Season 1/season.nfo":                    `<season><seasonnumber>1</seasonnumber></season>`,
+		"Show (2019)/Season 1/Show S01E02.mkv":               "",
+		"Show (2019)/Season 1/Show S01E02.nfo":               `<episodedetails><title>Ep</title><season>1</season><episode>2</episode></episodedetails>`,
+		"Disc (2000)/BDMV/STREAM/00000.m2ts":                 "",
+		"Image (2001)/Image (2001).iso":                      "",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), os.ModePerm))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	return root
+}
+
+// stubRecognizeMedia 记录待识别的媒体并返回错误，不请求 TMDB
+func stubRecognizeMedia(t *testing.T) func() []*meta.VideoMeta {
+	var (
+		mu       sync.Mutex
+		recorded []*meta.VideoMeta
+	)
+	old := recognizeMedia
+	recognizeMedia = func(ctx context.Context, videoMeta *meta.VideoMeta, providers []string) (*schemas.MediaInfo, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, videoMeta)
+		return nil, errors.New("stub")
+	}
+	t.Cleanup(func() { recognizeMedia = old })
+	return func() []*meta.VideoMeta {
+		mu.Lock()
+		defer mu.Unlock()
+		return recorded
+	}
+}
+
+func TestCollectRefreshItemsFromDir(t *testing.T) {
+	root := setupRefreshLibrary(t)
+
+	items, err := collectRefreshItemsFromDir(context.Background(), storage.NewStoragePath(storage.StorageLocal, root))
+	require.NoError(t, err)
+	var paths []string
+	for _, item := range items {
+		paths = append(paths, item.file.GetPath())
+		require.Equal(t, -1, item.season)
+		require.Equal(t, -1, item.episode)
+	}
+	require.ElementsMatch(t, []string{
+		root + "/Movie A (2020)/Movie A (2020).mkv",
+		root + "/Movie B (2021)/Movie B (2021).mkv",
+		root + "/Show (2019)/Season 1/Show S01E02.mkv",
+	}, paths)
+}
+
+func TestRefreshFile(t *testing.T) {
+	root := setupRefreshLibrary(t)
+	recorded := stubRecognizeMedia(t)
+	item := func(name string) refreshItem {
+		return refreshItem{file: storage.NewStoragePath(storage.StorageLocal, root+"/"+name), season: -1, episode: -1}
+	}
+
+	// NFO 和图片齐全时跳过
+	refreshed, err := refreshFile(context.Background(), item("Movie A (2020)/Movie A (2020).mkv"), RefreshMissing)
+	require.NoError(t, err)
+	require.False(t, refreshed)
+	require.Empty(t, recorded())
+
+	// NFO 缺少 TMDB ID 时按名称重新识别
+	_, err = refreshFile(context.Background(), item("Movie B (2021)/Movie B (2021).mkv"), RefreshMissing)
+	require.Error(t, err)
+	require.Len(t, recorded(), 1)
+	require.Equal(t, "Movie B", recorded()[0].GetTitle())
+	require.Zero(t, recorded()[0].TMDBID)
+
+	// 缺少单集缩略图时复用 NFO 中的 TMDB ID 和季集
+	_, err = refreshFile(context.Background(), item("Show (2019)/Season 1/Show S01E02.mkv"), RefreshMissing)
+	require.Error(t, err)
+	require.Len(t, recorded(), 2)
+	require.Equal(t, meta.MediaTypeTV, recorded()[1].MediaType)
+	require.Equal(t, 1399, recorded()[1].TMDBID)
+	require.Equal(t, 1, recorded()[1].Season)
+	require.Equal(t, 2, recorded()[1].Episode)
+
+	// 强制刷新时不检查是否齐全，转移历史记录中的 ID 优先于 NFO
+	historyItem := item("Movie A (2020)/Movie A (2020).mkv")
+	historyItem.mediaType, historyItem.tmdbID = meta.MediaTypeMovie, 551
+	_, err = refreshFile(context.Background(), historyItem, RefreshForce)
+	require.Error(t, err)
+	require.Len(t, recorded(), 3)
+	require.Equal(t, 551, recorded()[2].TMDBID)
+
+	// 缺少 Logo 时重新刮削
+	require.NoError(t, os.Remove(root+"/Movie A (2020)/clearlogo.png"))
+	_, err = refreshFile(context.Background(), item("Movie A (2020)/Movie A (2020).mkv"), RefreshMissing)
+	require.Error(t, err)
+	require.Len(t, recorded(), 4)
+	require.Equal(t, 550, recorded()[3].TMDBID)
+
+	// 光盘文件夹跳过
+	refreshed, err = refreshFile(context.Background(), item("Disc (2000)"), RefreshForce)
+	require.NoError(t, err)
+	require.False(t, refreshed)
+	require.Len(t, recorded(), 4)
+}
+
+func TestRefreshLibrary(t *testing.T) {
+	root := setupRefreshLibrary(t)
+	stubRecognizeMedia(t)
+
+	_, err := RefreshLibrary(nil, false, RefreshMissing)
+	require.Error(t, err)
+
+	task, err := RefreshLibrary(storage.NewStoragePath(storage.StorageLocal, root), false, RefreshMissing)
+	require.NoError(t, err)
+	var report *RefreshReport
+	require.Eventually(t, func() bool {
+		report, err = GetRefreshReport(task.ID)
+		return err == nil && !report.Running && !report.EndTime.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, RefreshMissing, report.Mode)
+	require.Equal(t, 3, report.Total)
+	require.Equal(t, 0, report.Refreshed)
+	require.Equal(t, 1, report.Skipped)
+	require.Equal(t, 2, report.Failed)
+	require.ElementsMatch(t, []RefreshFailure{
+		{Path: storage.NewStoragePath(storage.StorageLocal, root+"/Movie B (2021)/Movie B (2021).mkv").String(), Message: "stub"},
+		{Path: storage.NewStoragePath(storage.StorageLocal, root+"/Show (2019)/Season 1/Show S01E02.mkv").String(), Message: "stub"},
+	}, report.Failures)
+}
@@ -0,0 +1,21 @@
+package scrape_controller_test
+
+import (
+	"MediaTools/internal/controller/scrape_controller"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRefreshMode(t *testing.T) {
+	mode, err := scrape_controller.ParseRefreshMode("")
+	require.NoError(t, err)
+	require.Equal(t, scrape_controller.RefreshMissing, mode)
+
+	mode, err = scrape_controller.ParseRefreshMode("Force")
+	require.NoError(t, err)
+	require.Equal(t, scrape_controller.RefreshForce, mode)
+
+	_, err = scrape_controller.ParseRefreshMode("all")
+	require.Error(t, err)
+}
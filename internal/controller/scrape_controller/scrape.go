@@ -1,7 +1,9 @@
 package scrape_controller
 
 import (
+	"MediaTools/internal/config"
 	"MediaTools/internal/controller/fanart_controller"
+	"MediaTools/internal/controller/provider_controller"
 	"MediaTools/internal/controller/recognize_controller"
 	"MediaTools/internal/schemas/storage"
 	"context"
@@ -9,8 +11,8 @@ import (
 	"MediaTools/internal/controller/storage_controller"
 	"MediaTools/internal/controller/tmdb_controller"
 	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
 	"MediaTools/internal/schemas"
-	"MediaTools/utils"
 	"fmt"
 	"sync"
 
@@ -18,7 +20,7 @@ import (
 )
 
 func Scrape(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) error {
-	var scrapers []func(*storage.StorageFileInfo, *schemas.MediaInfo)
+	var scrapers []func(context.Context, *storage.StorageFileInfo, *schemas.MediaInfo, *Profile)
 	switch info.MediaType {
 	case meta.MediaTypeMovie:
 		scrapers = append(scrapers, ScrapeMovieInfo, ScrapeMovieImage)
@@ -30,13 +32,15 @@ func Scrape(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas
 		return fmt.Errorf("不支持的媒体类型: %s", info.MediaType)
 	}
 
+	profile := MatchProfile(dstFile)
+	logrus.Debugf("刮削 %s 使用输出方案：%s", dstFile, profile.Name)
 	for _, scraper := range scrapers {
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("刮削任务被取消: %v", ctx.Err())
 		default:
 		}
-		scraper(dstFile, info)
+		scraper(ctx, dstFile, info, profile)
 	}
 
 	return nil
@@ -52,91 +56,105 @@ func RecognizeAndScrape(ctx context.Context, dstFile *storage.StorageFileInfo, m
 	if tmdbID != 0 && videoMeta.TMDBID == 0 {
 		videoMeta.TMDBID = tmdbID
 	}
+	if videoMeta.TMDBID == 0 { // 已有 NFO 中的 TMDB ID 作为权威标识，避免模糊搜索
+		if nfoMeta := ReadNFOMeta(dstFile, videoMeta.MediaType); nfoMeta != nil {
+			logrus.Infof("从已有 NFO 文件 %s 中读取到 TMDB ID：%d", nfoMeta.Source, nfoMeta.TMDBID)
+			videoMeta.MediaType = nfoMeta.MediaType
+			videoMeta.TMDBID = nfoMeta.TMDBID
+			if nfoMeta.Episode != -1 {
+				videoMeta.Season, videoMeta.Episode = nfoMeta.Season, nfoMeta.Episode
+			}
+		}
+	}
 
-	info, err := tmdb_controller.RecognizeAndEnrichMedia(ctx, videoMeta)
+	info, err := provider_controller.RecognizeAndEnrichMedia(ctx, videoMeta, libraryProviders(dstFile))
 	if err != nil {
 		return fmt.Errorf("识别媒体信息失败: %v", err)
 	}
 	return Scrape(ctx, dstFile, info)
 }
 
-func ScrapeMovieInfo(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
-	metaData := genMovieMetaInfo(info)
-	xmlData, err := metaData.XML()
+func ScrapeMovieInfo(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas.MediaInfo, profile *Profile) {
+	if !profile.NFO {
+		return
+	}
+	metaData := genMovieMetaInfo(ctx, info)
+	localizeActorThumbs(ctx, mediaDir(dstFile), mediaDir(dstFile), metaData.Actors)
+	if profile.FileInfo && dstFile.Type != storage.FileTypeDirectory {
+		metaData.FileInfo = genFileInfo(dstFile, info.Probe)
+	}
+	profile.apply(metaData)
+
+	infoFile, err := storage_controller.GetPath(profile.MovieNFOPath(dstFile), dstFile.StorageType)
 	if err != nil {
-		logrus.Errorf("生成电影「%s」元数据 XML 失败: %v", info.TMDBInfo.MovieInfo.Title, err)
-	} else {
-		infoFile := dstFile.Parent().Join("movie.info")
-		reader, err := bytes2Reader(xmlData)
-		if err != nil {
-			logrus.Warning("创建 reader 失败: %w", err)
-			return
-		}
-		err = storage_controller.CreateFile(infoFile, reader)
-		if err != nil {
-			logrus.Errorf("创建电影「%s」元数据文件失败: %v", info.TMDBInfo.MovieInfo.Title, err)
-		}
+		logrus.Warningf("获取电影「%s」元数据文件路径失败: %v", info.TMDBInfo.MovieInfo.Title, err)
+		return
+	}
+	written, err := writeNFO(infoFile, metaData)
+	switch {
+	case err != nil:
+		logrus.Errorf("创建电影「%s」元数据文件失败: %v", info.TMDBInfo.MovieInfo.Title, err)
+	case !written:
+		logrus.Infof("电影「%s」元数据文件 %s 已锁定，跳过写入", info.TMDBInfo.MovieInfo.Title, infoFile)
 	}
 }
 
-func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
+func ScrapeMovieImage(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas.MediaInfo, profile *Profile) {
 	var (
 		wg    sync.WaitGroup
 		errCh = make(chan error, 10)
 	)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		err := DownloadTMDBImageAndSave(info.TMDBInfo.MovieInfo.PosterPath, utils.ChangeExt(dstFile.Path, "")+"-poster", dstFile.StorageType)
-		if err != nil {
-			errCh <- fmt.Errorf("刮削电影「%s」海报失败: %v", info.TMDBInfo.MovieInfo.Title, err)
-		}
-	}()
+	if config.Media.Collection.Artwork && info.TMDBInfo.MovieInfo.BelongsToCollection.ID != 0 {
+		wg.Add(1)
+		go func() { // 刮削合集图片
+			defer wg.Done()
+			for _, err := range scrapeCollectionImage(ctx, dstFile, info, profile) {
+				errCh <- err
+			}
+		}()
+	}
 
 	wg.Add(1)
 	go func() { // 刮削 TMDB 图片
 		defer wg.Done()
-		movieImage, err := tmdb_controller.GetMovieImage(info.TMDBID)
+		movieImage, err := tmdb_controller.GetMovieImage(ctx, info.TMDBID)
 		if err != nil {
 			errCh <- fmt.Errorf("获取电影「%s」图片信息失败: %v", info.TMDBInfo.MovieInfo.Title, err)
-			return
+			movieImage = &themoviedb.MovieImage{} // 海报仍可使用电影详情中的默认海报
 		}
 
 		wg.Add(3)
 		go func() {
 			defer wg.Done()
-			if len(movieImage.Backdrops) > 0 { // 剧照
-				var paths []string
-				for _, backdrop := range movieImage.Backdrops {
-					paths = append(paths, backdrop.FilePath)
-				}
-				path := getSupportImage(paths)
-				if path == "" {
-					errCh <- fmt.Errorf("电影「%s」没有合适的剧照", info.TMDBInfo.MovieInfo.Title)
-				} else {
-					err = DownloadTMDBImageAndSave(path, dstFile.Parent().Join("backdrop").GetPath(), dstFile.StorageType)
-					if err != nil {
-						errCh <- fmt.Errorf("刮削电影「%s」剧照失败: %v", info.TMDBInfo.MovieInfo.Title, err)
-					}
-				}
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkPoster)
+			if !ok {
+				return
+			}
+			path := SelectArtwork(tmdbCandidates(movieImage.Posters), ArtworkPoster)
+			if path == "" {
+				path = info.TMDBInfo.MovieInfo.PosterPath
+			}
+			err := DownloadTMDBImageAndSave(ctx, path, target, dstFile.StorageType)
+			if err != nil {
+				errCh <- fmt.Errorf("刮削电影「%s」海报失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 			}
 		}()
 
 		go func() {
 			defer wg.Done()
-			if len(movieImage.Posters) > 0 { // 海报
-				var paths []string
-				for _, poster := range movieImage.Posters {
-					paths = append(paths, poster.FilePath)
-				}
-				path := getSupportImage(paths)
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkFanart)
+			if !ok {
+				return
+			}
+			if len(movieImage.Backdrops) > 0 { // 剧照
+				path := SelectArtwork(tmdbCandidates(movieImage.Backdrops), ArtworkFanart)
 				if path == "" {
-					errCh <- fmt.Errorf("电影「%s」没有合适的海报", info.TMDBInfo.MovieInfo.Title)
+					errCh <- fmt.Errorf("电影「%s」没有合适的剧照", info.TMDBInfo.MovieInfo.Title)
 				} else {
-					err = DownloadTMDBImageAndSave(path, dstFile.Parent().Join("poster").GetPath(), dstFile.StorageType)
+					err := DownloadTMDBImageAndSave(ctx, path, target, dstFile.StorageType)
 					if err != nil {
-						errCh <- fmt.Errorf("刮削电影「%s」海报失败: %v", info.TMDBInfo.MovieInfo.Title, err)
+						errCh <- fmt.Errorf("刮削电影「%s」剧照失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 					}
 				}
 			}
@@ -144,16 +162,16 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 
 		go func() {
 			defer wg.Done()
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkLogo)
+			if !ok {
+				return
+			}
 			if len(movieImage.Logos) > 0 { // Logo
-				var paths []string
-				for _, logo := range movieImage.Logos {
-					paths = append(paths, logo.FilePath)
-				}
-				path := getSupportImage(paths)
+				path := SelectArtwork(tmdbCandidates(movieImage.Logos), ArtworkLogo)
 				if path == "" {
 					errCh <- fmt.Errorf("电影「%s」没有合适的 Logo", info.TMDBInfo.MovieInfo.Title)
 				} else {
-					err = DownloadTMDBImageAndSave(path, dstFile.Parent().Join("logo").GetPath(), dstFile.StorageType)
+					err := DownloadTMDBImageAndSave(ctx, path, target, dstFile.StorageType)
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电影「%s」Logo 失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 					}
@@ -165,7 +183,7 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 	wg.Add(1)
 	go func() { // 刮削 Fanart 图片
 		defer wg.Done()
-		fanartImagesData, err := fanart_controller.GetMovieImagesData(info.IMDBID)
+		fanartImagesData, err := fanart_controller.GetMovieImagesData(ctx, info.IMDBID)
 		if err != nil {
 			errCh <- fmt.Errorf("获取电影「%s」Fanart 图片信息失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 			return
@@ -174,16 +192,16 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 		wg.Add(5)
 		go func() {
 			defer wg.Done()
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkBackground)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.MovieBackground) > 0 { // 背景图
-				var urls []string
-				for _, bg := range fanartImagesData.MovieBackground {
-					urls = append(urls, bg.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.MovieBackground), ArtworkBackground)
 				if url == "" {
 					errCh <- fmt.Errorf("电影「%s」没有合适的 Fanart 背景图", info.TMDBInfo.MovieInfo.Title)
 				} else {
-					err = DownloadFanartImageAndSave(url, dstFile.Parent().Join("background").GetPath(), dstFile.StorageType)
+					err := DownloadFanartImageAndSave(ctx, url, target, dstFile.StorageType)
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电影「%s」Fanart 背景图失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 					}
@@ -193,16 +211,16 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 
 		go func() {
 			defer wg.Done()
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkBanner)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.MovieBanner) > 0 { // 横幅
-				var urls []string
-				for _, banner := range fanartImagesData.MovieBanner {
-					urls = append(urls, banner.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.MovieBanner), ArtworkBanner)
 				if url == "" {
 					errCh <- fmt.Errorf("电影「%s」没有合适的 Fanart 横幅图", info.TMDBInfo.MovieInfo.Title)
 				} else {
-					err = DownloadFanartImageAndSave(url, dstFile.Parent().Join("banner").GetPath(), dstFile.StorageType)
+					err := DownloadFanartImageAndSave(ctx, url, target, dstFile.StorageType)
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电影「%s」Fanart 横幅图失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 					}
@@ -212,22 +230,19 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 
 		go func() {
 			defer wg.Done()
-			fanartClearArtPath := dstFile.Parent().Join("clearart").GetPath()
-			var urls []string
-			if len(fanartImagesData.HDMovieClearArt) > 0 { // Clear Art
-				for _, clearArt := range fanartImagesData.HDMovieClearArt {
-					urls = append(urls, clearArt.URL)
-				}
-			} else if len(fanartImagesData.MovieArt) > 0 { // Clear Art
-				for _, clearArt := range fanartImagesData.MovieArt {
-					urls = append(urls, clearArt.URL)
-				}
+			fanartClearArtPath, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkClearArt)
+			if !ok {
+				return
 			}
-			url := getSupportImage(urls)
+			candidates := fanartCandidates(fanartImagesData.HDMovieClearArt) // 优先使用 HD Clear Art
+			if len(candidates) == 0 {
+				candidates = fanartCandidates(fanartImagesData.MovieArt)
+			}
+			url := SelectArtwork(candidates, ArtworkClearArt)
 			if url == "" {
 				errCh <- fmt.Errorf("电影「%s」没有合适的 Fanart Clear Art 图", info.TMDBInfo.MovieInfo.Title)
 			} else {
-				err = DownloadFanartImageAndSave(url, fanartClearArtPath, dstFile.StorageType)
+				err := DownloadFanartImageAndSave(ctx, url, fanartClearArtPath, dstFile.StorageType)
 				if err != nil {
 					errCh <- fmt.Errorf("刮削电影「%s」Fanart Clear Art 图片失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 				}
@@ -236,17 +251,17 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 
 		go func() {
 			defer wg.Done()
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkDisc)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.MovieDisc) > 0 { // 光盘
-				var urls []string
-				for _, disc := range fanartImagesData.MovieDisc {
-					urls = append(urls, disc.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.MovieDisc), ArtworkDisc)
 				if url == "" {
 					errCh <- fmt.Errorf("电影「%s」没有合适的 Fanart 光盘图", info.TMDBInfo.MovieInfo.Title)
 				} else {
 
-					err = DownloadFanartImageAndSave(url, dstFile.Parent().Join("disc").GetPath(), dstFile.StorageType)
+					err := DownloadFanartImageAndSave(ctx, url, target, dstFile.StorageType)
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电影「%s」Fanart 光盘图片失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 					}
@@ -256,16 +271,16 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 
 		go func() {
 			defer wg.Done()
+			target, ok := profile.artworkTarget(mediaDir(dstFile), ArtworkLandscape)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.MovieThumb) > 0 { // 缩略图
-				var urls []string
-				for _, thumb := range fanartImagesData.MovieThumb {
-					urls = append(urls, thumb.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.MovieThumb), ArtworkLandscape)
 				if url == "" {
 					errCh <- fmt.Errorf("电影「%s」没有合适的 Fanart 缩略图", info.TMDBInfo.MovieInfo.Title)
 				} else {
-					err = DownloadFanartImageAndSave(url, dstFile.Parent().Join("thumb").GetPath(), dstFile.StorageType)
+					err := DownloadFanartImageAndSave(ctx, url, target, dstFile.StorageType)
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电影「%s」Fanart 缩略图失败: %v", info.TMDBInfo.MovieInfo.Title, err)
 					}
@@ -284,68 +299,58 @@ func ScrapeMovieImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo)
 	}
 }
 
-func ScrapeTVInfo(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
+func ScrapeTVInfo(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas.MediaInfo, profile *Profile) {
+	if !profile.NFO {
+		return
+	}
 	tvSeasonDir := dstFile.Parent()
 	tvSerieDir := tvSeasonDir.Parent()
 
-	serieMetaData := genTVSerieMetaInfo(info)
-	xmlData, err := serieMetaData.XML()
-	if err != nil {
-		logrus.Errorf("生成电视剧「%s」元数据 XML 失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
-	} else {
-		infoFile := tvSerieDir.Join("tv.info")
-		reader, err := bytes2Reader(xmlData)
-		if err != nil {
-			logrus.Warningf("创建 reader 失败: %v", err)
-		} else {
-			err = storage_controller.CreateFile(infoFile, reader)
-			if err != nil {
-				logrus.Errorf("创建电视剧「%s」元数据文件失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
-			}
-		}
+	serieMetaData := genTVSerieMetaInfo(ctx, info)
+	localizeActorThumbs(ctx, tvSerieDir, tvSerieDir, serieMetaData.Actors)
+	profile.apply(serieMetaData)
+	infoFile := tvSerieDir.Join(profile.TVShowNFO)
+	written, err := writeNFO(infoFile, serieMetaData)
+	switch {
+	case err != nil:
+		logrus.Errorf("创建电视剧「%s」元数据文件失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
+	case !written:
+		logrus.Infof("电视剧「%s」元数据文件 %s 已锁定，跳过写入", info.TMDBInfo.TVInfo.SerieInfo.Name, infoFile)
 	}
 
 	seasonMetaData := genTVSeasonMetaInfo(info)
-	xmlData, err = seasonMetaData.XML()
-	if err != nil {
-		logrus.Errorf("生成电视剧「%s」第 %d 季元数据 XML 失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, err)
-	} else {
-		infoFile := tvSeasonDir.Join("season.info")
-		reader, err := bytes2Reader(xmlData)
-		if err != nil {
-			logrus.Warning("创建 reader 失败: %w", err)
-		} else {
-			err = storage_controller.CreateFile(infoFile, reader)
-			if err != nil {
-				logrus.Errorf("创建电视剧「%s」第 %d 季元数据文件失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, err)
-			}
-		}
+	profile.apply(seasonMetaData)
+	infoFile = tvSeasonDir.Join(profile.SeasonNFO)
+	written, err = writeNFO(infoFile, seasonMetaData)
+	switch {
+	case err != nil:
+		logrus.Errorf("创建电视剧「%s」第 %d 季元数据文件失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, err)
+	case !written:
+		logrus.Infof("电视剧「%s」第 %d 季元数据文件 %s 已锁定，跳过写入", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, infoFile)
 	}
 
 	episodeMetaData := genTVEpisodeMetaInfo(info)
-	xmlData, err = episodeMetaData.XML()
+	localizeActorThumbs(ctx, tvSerieDir, dstFile.Parent(), episodeMetaData.Actors)
+	if profile.FileInfo && dstFile.Type != storage.FileTypeDirectory {
+		episodeMetaData.FileInfo = genFileInfo(dstFile, info.Probe)
+	}
+	profile.apply(episodeMetaData)
+	infoPath := mediaBase(dstFile) + ".nfo"
+	infoFile, err = storage_controller.GetPath(infoPath, dstFile.StorageType)
 	if err != nil {
-		logrus.Errorf("生成电视剧「%s」第 %d 季第 %d 集元数据 XML 失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, info.TMDBInfo.TVInfo.EpisodeInfo.EpisodeNumber, err)
-	} else {
-		infoPath := utils.ChangeExt(dstFile.Path, ".info")
-		infoFile, err := storage_controller.GetPath(infoPath, dstFile.StorageType)
-		if err != nil {
-			logrus.Warningf("获取 %s:/%s 句柄失败:%v", dstFile.StorageType, infoPath, err)
-		} else {
-			reader, err := bytes2Reader(xmlData)
-			if err != nil {
-				logrus.Warning("创建 reader 失败: %w", err)
-			} else {
-				err = storage_controller.CreateFile(infoFile, reader)
-				if err != nil {
-					logrus.Errorf("创建电视剧「%s」第 %d 季第 %d 集元数据文件失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, info.TMDBInfo.TVInfo.EpisodeInfo.EpisodeNumber, err)
-				}
-			}
-		}
+		logrus.Warningf("获取 %s:/%s 句柄失败:%v", dstFile.StorageType, infoPath, err)
+		return
+	}
+	written, err = writeNFO(infoFile, episodeMetaData)
+	switch {
+	case err != nil:
+		logrus.Errorf("创建电视剧「%s」第 %d 季第 %d 集元数据文件失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, info.TMDBInfo.TVInfo.EpisodeInfo.EpisodeNumber, err)
+	case !written:
+		logrus.Infof("电视剧「%s」第 %d 季第 %d 集元数据文件 %s 已锁定，跳过写入", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, info.TMDBInfo.TVInfo.EpisodeInfo.EpisodeNumber, infoFile)
 	}
 }
 
-func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
+func ScrapeTVImage(ctx context.Context, dstFile *storage.StorageFileInfo, info *schemas.MediaInfo, profile *Profile) {
 	tvSeasonDir := dstFile.Parent()
 	tvSerieDir := tvSeasonDir.Parent()
 
@@ -357,7 +362,8 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 	wg.Add(1)
 	go func() { // 集照片
 		defer wg.Done()
-		err := DownloadTMDBImageAndSave(info.TMDBInfo.TVInfo.EpisodeInfo.StillPath, utils.ChangeExt(dstFile.Path, ""), dstFile.StorageType)
+		target := mediaBase(dstFile) + profile.EpisodeThumbTag
+		err := DownloadTMDBImageAndSave(ctx, info.TMDBInfo.TVInfo.EpisodeInfo.StillPath, target, dstFile.StorageType)
 		if err != nil {
 			errCh <- fmt.Errorf("刮削电视剧「%s」第 %d 季第 %d 集剧照失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, info.TMDBInfo.TVInfo.EpisodeInfo.EpisodeNumber, err)
 		}
@@ -366,26 +372,18 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 	wg.Add(1)
 	go func() { // 季照片
 		defer wg.Done()
-		var seasonPosterName string
-		switch info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber {
-		case 0: // 特别篇
-			seasonPosterName = "special-specials-poster"
-		default:
-			seasonPosterName = fmt.Sprintf("season%02d-poster", info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber)
+		seasonNumber := info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber
+		seasonPosterFile := tvSerieDir.Join(profile.SeasonPosterName(seasonNumber))
+		var path string
+		if seasonImages, err := tmdb_controller.GetTVSeasonImage(ctx, info.TMDBID, seasonNumber); err == nil {
+			path = SelectArtwork(tmdbCandidates(seasonImages.Posters), ArtworkPoster)
 		}
-		seasonPosterFile := tvSerieDir.Join(seasonPosterName)
-		err := DownloadTMDBImageAndSave(info.TMDBInfo.TVInfo.SeasonInfo.PosterPath, seasonPosterFile.GetPath(), seasonPosterFile.GetStorageType())
-		if err != nil {
-			errCh <- fmt.Errorf("刮削电视剧「%s」第 %d 季海报失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SeasonInfo.SeasonNumber, err)
+		if path == "" {
+			path = info.TMDBInfo.TVInfo.SeasonInfo.PosterPath
 		}
-	}()
-
-	wg.Add(1)
-	go func() { // 电视剧剧照
-		defer wg.Done()
-		err := DownloadTMDBImageAndSave(info.TMDBInfo.TVInfo.SerieInfo.BackdropPath, tvSerieDir.Join("backdrop").GetPath(), tvSerieDir.GetStorageType())
+		err := DownloadTMDBImageAndSave(ctx, path, seasonPosterFile.GetPath(), seasonPosterFile.GetStorageType())
 		if err != nil {
-			errCh <- fmt.Errorf("刮削电视剧「%s」剧照失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
+			errCh <- fmt.Errorf("刮削电视剧「%s」第 %d 季海报失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, seasonNumber, err)
 		}
 	}()
 
@@ -393,26 +391,42 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 	go func() { // 其他 TMDB 图片
 		defer wg.Done()
 
-		serieImages, err := tmdb_controller.GetTVSerieImage(info.TMDBID)
+		serieImages, err := tmdb_controller.GetTVSerieImage(ctx, info.TMDBID)
 		if err != nil {
 			errCh <- fmt.Errorf("获取电视剧「%s」图片信息失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
-			return
+			serieImages = &themoviedb.TVSerieImage{} // 剧照仍可使用电视剧详情中的默认剧照
 		}
 
-		wg.Add(2)
+		wg.Add(3)
+
+		go func() { // 电视剧剧照
+			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkFanart)
+			if !ok {
+				return
+			}
+			path := SelectArtwork(tmdbCandidates(serieImages.Backdrops), ArtworkFanart)
+			if path == "" {
+				path = info.TMDBInfo.TVInfo.SerieInfo.BackdropPath
+			}
+			err := DownloadTMDBImageAndSave(ctx, path, target, tvSerieDir.GetStorageType())
+			if err != nil {
+				errCh <- fmt.Errorf("刮削电视剧「%s」剧照失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
+			}
+		}()
 
 		go func() { // 海报
 			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkPoster)
+			if !ok {
+				return
+			}
 			if len(serieImages.Posters) > 0 {
-				var paths []string
-				for _, poster := range serieImages.Posters {
-					paths = append(paths, poster.FilePath)
-				}
-				path := getSupportImage(paths)
+				path := SelectArtwork(tmdbCandidates(serieImages.Posters), ArtworkPoster)
 				if path == "" {
 					errCh <- fmt.Errorf("电视剧「%s」没有合适的海报", info.TMDBInfo.TVInfo.SerieInfo.Name)
 				} else {
-					err = DownloadTMDBImageAndSave(path, tvSerieDir.Join("poster").GetPath(), tvSerieDir.GetStorageType())
+					err := DownloadTMDBImageAndSave(ctx, path, target, tvSerieDir.GetStorageType())
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电视剧「%s」海报失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 					}
@@ -422,14 +436,19 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 
 		go func() { // Logo
 			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkLogo)
+			if !ok {
+				return
+			}
 			if len(serieImages.Logos) > 0 {
-				var paths []string
-				for _, logo := range serieImages.Logos {
-					paths = append(paths, logo.FilePath)
-				}
-				err = DownloadTMDBImageAndSave(getSupportImage(paths), tvSerieDir.Join("logo").GetPath(), tvSerieDir.GetStorageType())
-				if err != nil {
-					errCh <- fmt.Errorf("刮削电视剧「%s」Logo 失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
+				path := SelectArtwork(tmdbCandidates(serieImages.Logos), ArtworkLogo)
+				if path == "" {
+					errCh <- fmt.Errorf("电视剧「%s」没有合适的 Logo", info.TMDBInfo.TVInfo.SerieInfo.Name)
+				} else {
+					err := DownloadTMDBImageAndSave(ctx, path, target, tvSerieDir.GetStorageType())
+					if err != nil {
+						errCh <- fmt.Errorf("刮削电视剧「%s」Logo 失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
+					}
 				}
 			}
 		}()
@@ -439,7 +458,7 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 	go func() { // 刮削 Fanart 图片
 		defer wg.Done()
 
-		fanartImagesData, err := fanart_controller.GetTVImagesData(info.TVDBID)
+		fanartImagesData, err := fanart_controller.GetTVImagesData(ctx, info.TVDBID)
 		if err != nil {
 			errCh <- fmt.Errorf("获取电视剧「%s」Fanart 图片信息失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 			return
@@ -448,16 +467,16 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 		wg.Add(5)
 		go func() { // 背景图
 			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkBackground)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.ShowBackground) > 0 {
-				var urls []string
-				for _, bg := range fanartImagesData.ShowBackground {
-					urls = append(urls, bg.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.ShowBackground), ArtworkBackground)
 				if url == "" {
 					errCh <- fmt.Errorf("电视剧「%s」没有合适的 Fanart 背景图", info.TMDBInfo.TVInfo.SerieInfo.Name)
 				} else {
-					err = DownloadFanartImageAndSave(url, tvSerieDir.Join("background").GetPath(), tvSerieDir.GetStorageType())
+					err := DownloadFanartImageAndSave(ctx, url, target, tvSerieDir.GetStorageType())
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电视剧「%s」Fanart 背景图失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 					}
@@ -467,16 +486,16 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 
 		go func() { // 横幅
 			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkBanner)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.TVBanner) > 0 {
-				var urls []string
-				for _, banner := range fanartImagesData.TVBanner {
-					urls = append(urls, banner.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.TVBanner), ArtworkBanner)
 				if url == "" {
 					errCh <- fmt.Errorf("电视剧「%s」没有合适的 Fanart 横幅图", info.TMDBInfo.TVInfo.SerieInfo.Name)
 				} else {
-					err = DownloadFanartImageAndSave(url, tvSerieDir.Join("banner").GetPath(), tvSerieDir.GetStorageType())
+					err := DownloadFanartImageAndSave(ctx, url, target, tvSerieDir.GetStorageType())
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电视剧「%s」Fanart 横幅图失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 					}
@@ -486,16 +505,16 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 
 		go func() { // 角色图
 			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkCharacterArt)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.CharacterArt) > 0 {
-				var urls []string
-				for _, charArt := range fanartImagesData.CharacterArt {
-					urls = append(urls, charArt.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.CharacterArt), ArtworkCharacterArt)
 				if url == "" {
 					errCh <- fmt.Errorf("电视剧「%s」没有合适的 Fanart 角色图", info.TMDBInfo.TVInfo.SerieInfo.Name)
 				} else {
-					err = DownloadFanartImageAndSave(url, tvSerieDir.Join("characterart").GetPath(), tvSerieDir.GetStorageType())
+					err := DownloadFanartImageAndSave(ctx, url, target, tvSerieDir.GetStorageType())
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电视剧「%s」Fanart 角色图失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 					}
@@ -505,22 +524,19 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 
 		go func() { // 清晰艺术图
 			defer wg.Done()
-			cleanArtPath := tvSerieDir.Join("clearart").GetPath()
-			var urls []string
-			if len(fanartImagesData.HDClearArt) > 0 { // HD clearart
-				for _, clearArt := range fanartImagesData.HDClearArt {
-					urls = append(urls, clearArt.URL)
-				}
-			} else if len(fanartImagesData.ClearArt) > 0 { // clearart
-				for _, clearArt := range fanartImagesData.HDClearArt {
-					urls = append(urls, clearArt.URL)
-				}
+			cleanArtPath, ok := profile.artworkTarget(tvSerieDir, ArtworkClearArt)
+			if !ok {
+				return
 			}
-			url := getSupportImage(urls)
+			candidates := fanartCandidates(fanartImagesData.HDClearArt) // 优先使用 HD Clear Art
+			if len(candidates) == 0 {
+				candidates = fanartCandidates(fanartImagesData.ClearArt)
+			}
+			url := SelectArtwork(candidates, ArtworkClearArt)
 			if url == "" {
 				errCh <- fmt.Errorf("电视剧「%s」没有合适的 Fanart Clear Art 图片", info.TMDBInfo.TVInfo.SerieInfo.Name)
 			} else {
-				err = DownloadFanartImageAndSave(url, cleanArtPath, tvSerieDir.GetStorageType())
+				err := DownloadFanartImageAndSave(ctx, url, cleanArtPath, tvSerieDir.GetStorageType())
 				if err != nil {
 					errCh <- fmt.Errorf("刮削电视剧「%s」Fanart 清晰艺术图失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 				}
@@ -529,16 +545,16 @@ func ScrapeTVImage(dstFile *storage.StorageFileInfo, info *schemas.MediaInfo) {
 
 		go func() { // 缩略图
 			defer wg.Done()
+			target, ok := profile.artworkTarget(tvSerieDir, ArtworkLandscape)
+			if !ok {
+				return
+			}
 			if len(fanartImagesData.TVThumb) > 0 {
-				var urls []string
-				for _, thumb := range fanartImagesData.TVThumb {
-					urls = append(urls, thumb.URL)
-				}
-				url := getSupportImage(urls)
+				url := SelectArtwork(fanartCandidates(fanartImagesData.TVThumb), ArtworkLandscape)
 				if url == "" {
 					errCh <- fmt.Errorf("电视剧「%s」没有合适的 Fanart 缩略图", info.TMDBInfo.TVInfo.SerieInfo.Name)
 				} else {
-					err = DownloadFanartImageAndSave(url, tvSerieDir.Join("thumb").GetPath(), tvSerieDir.GetStorageType())
+					err := DownloadFanartImageAndSave(ctx, url, target, tvSerieDir.GetStorageType())
 					if err != nil {
 						errCh <- fmt.Errorf("刮削电视剧「%s」Fanart 缩略图失败: %v", info.TMDBInfo.TVInfo.SerieInfo.Name, err)
 					}
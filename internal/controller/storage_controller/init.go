@@ -37,6 +37,10 @@ func RegisterStorageProvider(c config.StorageConfig) (*storage.StorageProviderIt
 	defer lock.Unlock()
 
 	logrus.Debugf("开始初始化 %s 存储器...", c.Type)
+	strmTemplate, err := parseStrmTemplate(c.Type, c.StrmURL)
+	if err != nil {
+		return nil, err
+	}
 	var provider storage.StorageProvider
 	switch c.Type {
 	case storage.StorageLocal:
@@ -45,12 +49,17 @@ func RegisterStorageProvider(c config.StorageConfig) (*storage.StorageProviderIt
 	case storage.StorageUnknown:
 		return nil, fmt.Errorf("未知的存储类型: %s", c.Type)
 	}
-	err := provider.Init(c.Data)
+	err = provider.Init(c.Data)
 	if err != nil {
 		return nil, err
 	}
+	if strmTemplate != nil {
+		strmTemplates[c.Type] = strmTemplate
+	} else {
+		delete(strmTemplates, c.Type)
+	}
 	logrus.Infof("%s 存储器已注册", c.Type)
-	item := storage.NewStorageProviderItem(provider)
+	item := newStorageProviderItem(provider)
 	return &item, nil
 }
 
@@ -68,17 +77,20 @@ func ListStorageProviders() []storage.StorageProviderItem {
 
 	providers := make([]storage.StorageProviderItem, 0, len(storageProviders))
 	for _, provider := range storageProviders {
-		providers = append(providers, storage.NewStorageProviderItem(provider))
+		providers = append(providers, newStorageProviderItem(provider))
 	}
 	return providers
 }
 
 func GetStorageProvider(storageType storage.StorageType) (*storage.StorageProviderItem, error) {
-	provider, exists := getStorageProvider(storageType)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	provider, exists := storageProviders[storageType]
 	if !exists {
 		return nil, fmt.Errorf("存储器 %s 不存在", storageType)
 	}
-	item := storage.NewStorageProviderItem(provider)
+	item := newStorageProviderItem(provider)
 	return &item, nil
 }
 
@@ -91,8 +103,9 @@ func UnRegisterStorageProvider(storageType storage.StorageType) (*storage.Storag
 		return nil, fmt.Errorf("存储器 %s 不存在", storageType)
 	}
 
-	item := storage.NewStorageProviderItem(provider)
+	item := newStorageProviderItem(provider)
 	delete(storageProviders, storageType)
+	delete(strmTemplates, storageType)
 	logrus.Infof("已删除存储器: %s", storageType)
 	return &item, nil
 }
@@ -0,0 +1,81 @@
+package storage_controller
+
+import (
+	"MediaTools/internal/schemas/storage"
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+var strmTemplates = make(map[storage.StorageType]*template.Template) // 各存储器的 strm 播放地址模板
+
+// StrmURLData strm 播放地址模板可用的数据
+type StrmURLData struct {
+	StorageType storage.StorageType // 源文件所在存储器类型
+	Path        string              // 源文件路径
+	EscapedPath string              // 逐段 URL 转义后的源文件路径，用于拼接 WebDAV 等地址
+}
+
+// parseStrmTemplate 解析 strm 播放地址模板，模板为空时返回 nil
+func parseStrmTemplate(storageType storage.StorageType, text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(storageType.String()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("解析 strm 播放地址模板失败: %v", err)
+	}
+	return tmpl, nil
+}
+
+// newStorageProviderItem 生成存储器信息，配置了 strm 播放地址模板的存储器支持 Strm 转移方式
+// 调用方需持有 lock
+func newStorageProviderItem(provider storage.StorageProvider) storage.StorageProviderItem {
+	item := storage.NewStorageProviderItem(provider)
+	if _, ok := strmTemplates[provider.GetType()]; ok {
+		item.TransferType = append(item.TransferType, storage.TransferStrm)
+	}
+	return item
+}
+
+// StrmURL 根据源文件所在存储器的模板生成 strm 播放地址
+func StrmURL(srcPath storage.StoragePath) (string, error) {
+	lock.RLock()
+	tmpl, exists := strmTemplates[srcPath.GetStorageType()]
+	lock.RUnlock()
+	if !exists {
+		return "", fmt.Errorf("存储器 %s 未配置 strm 播放地址模板", srcPath.GetStorageType())
+	}
+
+	segments := strings.Split(srcPath.GetPath(), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	data := StrmURLData{
+		StorageType: srcPath.GetStorageType(),
+		Path:        srcPath.GetPath(),
+		EscapedPath: strings.Join(segments, "/"),
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("生成 strm 播放地址失败: %v", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// StrmPath 获取目标文件对应的 strm 文件路径（替换扩展名为 .strm）
+func StrmPath(dstPath storage.StoragePath) storage.StoragePath {
+	name := strings.TrimSuffix(dstPath.GetName(), dstPath.GetExt()) + ".strm"
+	return dstPath.Parent().Join(name)
+}
+
+// Strm 在目标位置生成指向源文件的 strm 文件，目标文件扩展名替换为 .strm
+func Strm(srcPath storage.StoragePath, dstPath storage.StoragePath) error {
+	streamURL, err := StrmURL(srcPath)
+	if err != nil {
+		return err
+	}
+	return CreateFile(StrmPath(dstPath), strings.NewReader(streamURL+"\n"))
+}
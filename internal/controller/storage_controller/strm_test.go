@@ -0,0 +1,58 @@
+package storage_controller_test
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/schemas/storage"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrm(t *testing.T) {
+	root := filepath.ToSlash(t.TempDir())
+	srcFile := storage.NewStoragePath(storage.StorageLocal, root+"/remote/Movie 2020/Movie #1.mkv")
+	dstFile := storage.NewStoragePath(storage.StorageLocal, root+"/library/Movie (2020)/Movie (2020).mkv")
+
+	// 未配置模板时不支持 Strm 转移方式
+	item, err := storage_controller.RegisterStorageProvider(config.StorageConfig{Type: storage.StorageLocal, Data: map[string]string{}})
+	require.NoError(t, err)
+	require.NotContains(t, item.TransferType, storage.TransferStrm)
+	require.Error(t, storage_controller.TransferFile(srcFile, dstFile, storage.TransferStrm))
+
+	_, err = storage_controller.RegisterStorageProvider(config.StorageConfig{
+		Type:    storage.StorageLocal,
+		Data:    map[string]string{},
+		StrmURL: "{{.Path",
+	})
+	require.Error(t, err)
+
+	item, err = storage_controller.RegisterStorageProvider(config.StorageConfig{
+		Type:    storage.StorageLocal,
+		Data:    map[string]string{},
+		StrmURL: "http://127.0.0.1:8080/api/storage/{{.StorageType}}/download?path={{urlquery .Path}}",
+	})
+	require.NoError(t, err)
+	require.Contains(t, item.TransferType, storage.TransferStrm)
+
+	require.NoError(t, storage_controller.TransferFile(srcFile, dstFile, storage.TransferStrm))
+	data, err := os.ReadFile(filepath.Join(root, "library/Movie (2020)/Movie (2020).strm"))
+	require.NoError(t, err)
+	require.Equal(t, "http://127.0.0.1:8080/api/storage/LocalStorage/download?path="+url.QueryEscape(srcFile.GetPath())+"\n", string(data))
+	require.NoFileExists(t, filepath.Join(root, "library/Movie (2020)/Movie (2020).mkv"))
+
+	_, err = storage_controller.RegisterStorageProvider(config.StorageConfig{
+		Type:    storage.StorageLocal,
+		Data:    map[string]string{},
+		StrmURL: "https://dav.example.com/dav{{.EscapedPath}}",
+	})
+	require.NoError(t, err)
+	streamURL, err := storage_controller.StrmURL(storage.NewStoragePath(storage.StorageLocal, "/电影/Movie 2020/Movie #1.mkv"))
+	require.NoError(t, err)
+	require.Equal(t, "https://dav.example.com/dav/%E7%94%B5%E5%BD%B1/Movie%202020/Movie%20%231.mkv", streamURL)
+
+	require.Error(t, storage_controller.TransferDir(srcFile.Parent(), dstFile.Parent(), storage.TransferStrm))
+}
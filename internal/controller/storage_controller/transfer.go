@@ -3,6 +3,7 @@ package storage_controller
 import (
 	"MediaTools/internal/schemas/storage"
 	"fmt"
+	"strings"
 )
 
 func TransferFile(srcPath storage.StoragePath, dstPath storage.StoragePath, transferType storage.TransferType) error {
@@ -21,6 +22,8 @@ func TransferFile(srcPath storage.StoragePath, dstPath storage.StoragePath, tran
 		err = Link(srcPath, dstPath)
 	case storage.TransferSoftLink:
 		err = SoftLink(srcPath, dstPath)
+	case storage.TransferStrm:
+		err = Strm(srcPath, dstPath)
 	default:
 		err = fmt.Errorf("未知传输方式")
 	}
@@ -29,3 +32,30 @@ func TransferFile(srcPath storage.StoragePath, dstPath storage.StoragePath, tran
 	}
 	return nil
 }
+
+// TransferDir 转移整个目录树，保持目录结构不变
+// 逐个转移目录中的文件，移动方式在全部文件转移完成后删除源目录
+// 目录无法通过 strm 文件播放，不支持 Strm 转移方式
+func TransferDir(srcDir storage.StoragePath, dstDir storage.StoragePath, transferType storage.TransferType) error {
+	if transferType == storage.TransferStrm {
+		return fmt.Errorf("不支持使用转移方式 %s 转移目录 %s", transferType, srcDir)
+	}
+	files, err := IterFiles(srcDir)
+	if err != nil {
+		return fmt.Errorf("遍历目录 %s 失败: %v", srcDir, err)
+	}
+	for file, err := range files {
+		if err != nil {
+			return fmt.Errorf("遍历目录 %s 失败: %v", srcDir, err)
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(file.GetPath(), srcDir.GetPath()), "/")
+		err = TransferFile(file, dstDir.Join(rel), transferType)
+		if err != nil {
+			return err
+		}
+	}
+	if transferType == storage.TransferMove {
+		return Delete(srcDir)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package task_controller
+
+import "MediaTools/internal/pkg/task"
+
+func SubmitScrapeTask(name string, fn task.TaskFunc) *task.Task {
+	return scrapeTaskQueue.SubmitTask(name, fn)
+}
+
+func GetScrapeTask(id string) (*task.Task, error) {
+	return scrapeTaskQueue.GetTask(id)
+}
+
+func CancelScrapeTask(id string) (*task.Task, error) {
+	return scrapeTaskQueue.CancelTask(id)
+}
+
+func IterScrapeTasks(yield func(t *task.Task) bool) {
+	scrapeTaskQueue.IterTasks(yield)
+}
@@ -8,4 +8,5 @@ import (
 var (
 	c                 = context.Background() // 全局上下文
 	transferTaskQueue = task.NewTaskQueue(c) // 转移任务队列
+	scrapeTaskQueue   = task.NewTaskQueue(c) // 刮削任务队列
 )
@@ -0,0 +1,51 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+)
+
+// applyAirDate 按播出日期查找对应的季和集
+// 仅在未识别到集数时生效，已指定季数时只在该季中查找，否则从最近的季开始向前查找
+func applyAirDate(ctx context.Context, videoMeta *meta.VideoMeta, info *schemas.MediaInfo) error {
+	if videoMeta.AirDate == "" || videoMeta.Episode != -1 {
+		return nil
+	}
+
+	lock.RLock()
+	defer lock.RUnlock()
+
+	var seasons []int
+	if videoMeta.Season != -1 {
+		seasons = append(seasons, videoMeta.Season)
+	} else if serie := info.TMDBInfo.TVInfo.SerieInfo; serie != nil {
+		for _, season := range serie.Seasons {
+			if season.AirDate != "" && season.AirDate <= videoMeta.AirDate { // 日期格式相同，可直接按字符串比较
+				seasons = append(seasons, season.SeasonNumber)
+			}
+		}
+		slices.SortFunc(seasons, func(a, b int) int { return b - a })
+	}
+
+	for _, seasonNumber := range seasons {
+		seasonDetail, err := client.GetTVSeasonDetail(ctx, info.TMDBID, seasonNumber, nil)
+		if err != nil {
+			logrus.Warningf("获取电视剧（TMDB ID: %d）S%02d详情失败: %v", info.TMDBID, seasonNumber, err)
+			continue
+		}
+		for _, episode := range seasonDetail.Episodes {
+			if episode.AirDate == videoMeta.AirDate {
+				logrus.Infof("按播出日期 %s 找到剧集 S%02dE%02d", videoMeta.AirDate, seasonNumber, episode.EpisodeNumber)
+				videoMeta.Season = seasonNumber
+				videoMeta.Episode = episode.EpisodeNumber
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("未找到播出日期为 %s 的剧集", videoMeta.AirDate)
+}
@@ -0,0 +1,32 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"MediaTools/internal/schemas"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAirDate(t *testing.T) {
+	useFixtureClient(t, map[string]string{"/3/tv/2224/season/2": "tv_season_2.json"}, nil)
+	info := &schemas.MediaInfo{TMDBID: 2224, MediaType: meta.MediaTypeTV}
+	info.TMDBInfo.TVInfo.SerieInfo = &themoviedb.TVSerieDetail{Seasons: []themoviedb.TVSerieSeason{
+		{SeasonNumber: 1, AirDate: "2023-01-05"},
+		{SeasonNumber: 2, AirDate: "2024-01-04"},
+		{SeasonNumber: 3, AirDate: "2025-01-02"}, // 晚于播出日期，不查找
+	}}
+
+	videoMeta := meta.ParseVideoMeta("The.Daily.Show.2024.05.17.1080p.WEB.h264-EDITH.mkv")
+	require.Equal(t, -1, videoMeta.Season)
+	require.NoError(t, applyAirDate(context.Background(), videoMeta, info))
+	require.Equal(t, 2, videoMeta.Season)
+	require.Equal(t, 20, videoMeta.Episode)
+
+	// 指定季数时只在该季中查找
+	videoMeta = meta.ParseVideoMeta("The.Daily.Show.S01.2024.05.17.1080p.WEB.h264-EDITH.mkv")
+	require.Equal(t, 1, videoMeta.Season)
+	require.Error(t, applyAirDate(context.Background(), videoMeta, info))
+}
@@ -0,0 +1,25 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+func GetCollectionDetail(ctx context.Context, collectionID int) (*themoviedb.CollectionDetail, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取合集（TMDB ID: %d）详情", collectionID)
+	return client.GetCollectionDetail(ctx, collectionID, nil)
+}
+
+func GetCollectionImage(ctx context.Context, collectionID int) (*themoviedb.CollectionImage, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取合集（TMDB ID: %d）图片", collectionID)
+	imageLanguage := imageLanguages()
+	return client.GetCollectionImage(ctx, collectionID, nil, &imageLanguage)
+}
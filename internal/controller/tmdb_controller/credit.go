@@ -2,38 +2,39 @@ package tmdb_controller
 
 import (
 	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
 
 	"github.com/sirupsen/logrus"
 )
 
-func GetMovieCredit(movieID int, language *string) (*themoviedb.MovieCredit, error) {
+func GetMovieCredit(ctx context.Context, movieID int, language *string) (*themoviedb.MovieCredit, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电影（TMDB ID: %d）演职员信息", movieID)
-	return client.GetMovieCredit(movieID, language)
+	return client.GetMovieCredit(ctx, movieID, language)
 }
 
-func GetTVSerieCredit(seriesID int, language *string) (*themoviedb.TVSerieCredit, error) {
+func GetTVSerieCredit(ctx context.Context, seriesID int, language *string) (*themoviedb.TVSerieCredit, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）演职员信息", seriesID)
-	return client.GetTVSerieCredit(seriesID, language)
+	return client.GetTVSerieCredit(ctx, seriesID, language)
 }
 
-func GetTVSeasonCredit(seriesID int, seasonNumber int, language *string) (*themoviedb.TVSeasonCredit, error) {
+func GetTVSeasonCredit(ctx context.Context, seriesID int, seasonNumber int, language *string) (*themoviedb.TVSeasonCredit, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）第 %d 季演职员信息", seriesID, seasonNumber)
-	return client.GetTVSeasonCredit(seriesID, seasonNumber, language)
+	return client.GetTVSeasonCredit(ctx, seriesID, seasonNumber, language)
 }
 
-func GetTVEpisodeCredit(seriesID int, seasonNumber int, episodeNumber int, language *string) (*themoviedb.TVEpisodeCredit, error) {
+func GetTVEpisodeCredit(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int, language *string) (*themoviedb.TVEpisodeCredit, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）第 %d 季第 %d 集演职员信息", seriesID, seasonNumber, episodeNumber)
-	return client.GetTVEpisodeCredit(seriesID, seasonNumber, episodeNumber, language)
+	return client.GetTVEpisodeCredit(ctx, seriesID, seasonNumber, episodeNumber, language)
 }
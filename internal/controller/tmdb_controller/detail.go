@@ -2,19 +2,21 @@ package tmdb_controller
 
 import (
 	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
 	"MediaTools/internal/schemas"
+	"context"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
 )
 
-func GetMovieDetail(movieID int) (*schemas.MediaInfo, error) {
+func GetMovieDetail(ctx context.Context, movieID int) (*schemas.MediaInfo, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电影（TMDB ID: %d）详情", movieID)
 
-	detail, err := client.GetMovieDetail(movieID, nil)
+	detail, err := client.GetMovieDetail(ctx, movieID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("获取电影（TMDB ID: %d）详情失败: %v", movieID, err)
 	}
@@ -25,20 +27,21 @@ func GetMovieDetail(movieID int) (*schemas.MediaInfo, error) {
 	mediaInfo.TMDBInfo = schemas.TMDBInfo{
 		MovieInfo: detail,
 	}
-	externalID, err := client.GetMovieExternalID(movieID)
+	externalID, err := client.GetMovieExternalID(ctx, movieID)
 	if err != nil {
 		return nil, fmt.Errorf("获取电影（TMDB ID: %d）外部ID失败: %v", movieID, err)
 	}
 	mediaInfo.IMDBID = externalID.ImdbID
+	applyMovieTranslation(ctx, &mediaInfo)
 	return &mediaInfo, nil
 }
 
-func GetTVSerieDetail(seriesID int) (*schemas.MediaInfo, error) {
+func GetTVSerieDetail(ctx context.Context, seriesID int) (*schemas.MediaInfo, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）详情", seriesID)
-	detail, err := client.GetTVSerieDetail(seriesID, nil)
+	detail, err := client.GetTVSerieDetail(ctx, seriesID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("获取电视剧（TMDB ID: %d）详情失败: %v", seriesID, err)
 	}
@@ -54,22 +57,23 @@ func GetTVSerieDetail(seriesID int) (*schemas.MediaInfo, error) {
 		},
 	}
 
-	externalID, err := client.GetTVSerieExternalID(seriesID)
+	externalID, err := client.GetTVSerieExternalID(ctx, seriesID)
 	if err != nil {
 		return nil, fmt.Errorf("获取电视剧（TMDB ID: %d）外部ID失败: %v", seriesID, err)
 	}
 	mediaInfo.IMDBID = externalID.ImdbID
 	mediaInfo.TVDBID = externalID.TvdbID
+	applyTVSerieTranslation(ctx, &mediaInfo)
 
 	return &mediaInfo, nil
 }
 
-func GetTVSeasonDetail(seriesID int, seasonNumber int) (*schemas.MediaInfo, error) {
+func GetTVSeasonDetail(ctx context.Context, seriesID int, seasonNumber int) (*schemas.MediaInfo, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）S%02d详情", seriesID, seasonNumber)
-	seasonDetail, err := client.GetTVSeasonDetail(seriesID, seasonNumber, nil)
+	seasonDetail, err := client.GetTVSeasonDetail(ctx, seriesID, seasonNumber, nil)
 	if err != nil {
 		return nil, fmt.Errorf("获取电视剧（TMDB ID: %d）S%02d详情失败: %v", seriesID, seasonNumber, err)
 	}
@@ -84,21 +88,22 @@ func GetTVSeasonDetail(seriesID int, seasonNumber int) (*schemas.MediaInfo, erro
 			EpisodeNumber: -1, // 默认值 -1，表示未指定集数
 		},
 	}
-	externalID, err := client.GetTVSeasonExternalID(seriesID, seasonNumber)
+	externalID, err := client.GetTVSeasonExternalID(ctx, seriesID, seasonNumber)
 	if err != nil {
 		return nil, fmt.Errorf("获取电视剧（TMDB ID: %d）S%02d外部ID失败, 错误: %v", seriesID, seasonNumber, err)
 	}
 	mediaInfo.TVDBID = externalID.TvdbID
+	applyTVSeasonTranslation(ctx, seriesID, seasonNumber, &mediaInfo)
 
 	return &mediaInfo, nil
 }
 
-func GetTVEpisodeDetail(seriesID int, seasonNumber int, episodeNumber int) (*schemas.MediaInfo, error) {
+func GetTVEpisodeDetail(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int) (*schemas.MediaInfo, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）S%02dE%02d详情", seriesID, seasonNumber, episodeNumber)
-	episodeDetail, err := client.GetTVEpisodeDetail(seriesID, seasonNumber, episodeNumber, nil)
+	episodeDetail, err := client.GetTVEpisodeDetail(ctx, seriesID, seasonNumber, episodeNumber, nil)
 	if err != nil {
 		return nil, fmt.Errorf("获取电视剧（TMDB ID: %d）S%02dE%02d集详情失败，错误: %v", seriesID, seasonNumber, episodeNumber, err)
 	}
@@ -113,12 +118,25 @@ func GetTVEpisodeDetail(seriesID int, seasonNumber int, episodeNumber int) (*sch
 			EpisodeNumber: episodeNumber,
 		},
 	}
-	externalID, err := client.GetTVEpisodeExternalID(seriesID, seasonNumber, episodeNumber)
+	externalID, err := client.GetTVEpisodeExternalID(ctx, seriesID, seasonNumber, episodeNumber)
 	if err != nil {
 		return nil, fmt.Errorf("获取电视剧（TMDB ID: %d）S%02dE%02d外部ID失败，错误: %v", seriesID, seasonNumber, episodeNumber, err)
 	}
 	mediaInfo.IMDBID = externalID.ImdbID
 	mediaInfo.TVDBID = externalID.TvdbID
+	applyTVEpisodeTranslation(ctx, seriesID, seasonNumber, episodeNumber, &mediaInfo)
 
 	return &mediaInfo, nil
 }
+
+func GetTVEpisodeGroupDetail(ctx context.Context, groupID string) (*themoviedb.TVEpisodeGroupDetail, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取剧集组「%s」详情", groupID)
+	detail, err := client.GetTVEpisodeGroupDetail(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("获取剧集组「%s」详情失败: %v", groupID, err)
+	}
+	return detail, nil
+}
@@ -0,0 +1,80 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FindByID 通过外部 ID（IMDb、TVDB）查找 TMDB 条目
+func FindByID(ctx context.Context, externalID string, source themoviedb.ExternalSource) (*themoviedb.FindResponse, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始通过外部 ID「%s」（%s）查找 TMDB 条目", externalID, source)
+	return client.FindByID(ctx, externalID, source, nil)
+}
+
+// FindTMDBID 通过外部 ID 查找 TMDB ID 和媒体类型
+// mediaType 为已知的媒体类型，MediaTypeUnknown 时不限制
+// 外部 ID 对应单集或单季时返回所属电视剧
+func FindTMDBID(ctx context.Context, externalID string, source themoviedb.ExternalSource, mediaType meta.MediaType) (int, meta.MediaType, error) {
+	resp, err := FindByID(ctx, externalID, source)
+	if err != nil {
+		return 0, meta.MediaTypeUnknown, err
+	}
+	if mediaType != meta.MediaTypeTV && len(resp.MovieResults) > 0 {
+		return resp.MovieResults[0].ID, meta.MediaTypeMovie, nil
+	}
+	if mediaType != meta.MediaTypeMovie {
+		switch {
+		case len(resp.TVResults) > 0:
+			return resp.TVResults[0].ID, meta.MediaTypeTV, nil
+		case len(resp.TVEpisodeResults) > 0:
+			return resp.TVEpisodeResults[0].ShowID, meta.MediaTypeTV, nil
+		case len(resp.TVSeasonResults) > 0:
+			return resp.TVSeasonResults[0].ShowID, meta.MediaTypeTV, nil
+		}
+	}
+	return 0, meta.MediaTypeUnknown, fmt.Errorf("TMDB 中没有外部 ID「%s」（%s）对应的%s", externalID, source, mediaTypeName(mediaType))
+}
+
+func mediaTypeName(mediaType meta.MediaType) string {
+	switch mediaType {
+	case meta.MediaTypeMovie:
+		return "电影"
+	case meta.MediaTypeTV:
+		return "电视剧"
+	default:
+		return "条目"
+	}
+}
+
+// findByExternalIDs 依次使用 IMDb ID、TVDB ID 查找 TMDB ID，都没有时返回 0
+func findByExternalIDs(ctx context.Context, videoMeta *meta.VideoMeta) (int, meta.MediaType) {
+	type externalID struct {
+		id     string
+		source themoviedb.ExternalSource
+	}
+	var ids []externalID
+	if videoMeta.IMDBID != "" {
+		ids = append(ids, externalID{videoMeta.IMDBID, themoviedb.ExternalSourceIMDB})
+	}
+	if videoMeta.TVDBID != 0 {
+		ids = append(ids, externalID{strconv.Itoa(videoMeta.TVDBID), themoviedb.ExternalSourceTVDB})
+	}
+	for _, ext := range ids {
+		tmdbID, mediaType, err := FindTMDBID(ctx, ext.id, ext.source, videoMeta.MediaType)
+		if err != nil {
+			logrus.Warningf("通过外部 ID 查找 TMDB ID 失败: %v", err)
+			continue
+		}
+		logrus.Infof("通过外部 ID「%s」（%s）找到 TMDB ID: %d", ext.id, ext.source, tmdbID)
+		return tmdbID, mediaType
+	}
+	return 0, meta.MediaTypeUnknown
+}
@@ -0,0 +1,34 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/fixture"
+	"MediaTools/internal/pkg/retry"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// useFixtureClient 将 TMDB 客户端替换为请求录制数据的客户端，测试结束时恢复
+// routes: 请求路径（含 /3 前缀）-> testdata 中的录制数据文件名
+// intercept: 请求拦截（可为 nil），用于检查请求参数
+func useFixtureClient(t *testing.T, routes map[string]string, intercept fixture.Intercept) {
+	server := fixture.NewServer(t, routes, intercept)
+	c, err := themoviedb.NewClient("test",
+		themoviedb.CustomAPIURL(server.URL),
+		themoviedb.CustomHTTPClient(server.Client()),
+		themoviedb.CustomRetryPolicy(retry.Policy{MaxAttempts: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second}),
+	)
+	require.NoError(t, err)
+
+	lock.Lock()
+	old := client
+	client = c
+	lock.Unlock()
+	t.Cleanup(func() {
+		lock.Lock()
+		client = old
+		lock.Unlock()
+	})
+}
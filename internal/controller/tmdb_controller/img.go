@@ -1,8 +1,12 @@
 package tmdb_controller
 
 import (
+	"MediaTools/internal/config"
 	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
 	"image"
+	"slices"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -14,41 +18,82 @@ func GetImageURL(path string) string {
 	return client.GetImageURL(path)
 }
 
-func DownloadImage(path string) (image.Image, error) {
+func DownloadImage(ctx context.Context, path string) (image.Image, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
-	return client.DownloadImage(path)
+	return client.DownloadImage(ctx, path)
 }
 
-func GetMovieImage(movieID int) (*themoviedb.MovieImage, error) {
+// DownloadImageData 下载图片原始数据
+func DownloadImageData(ctx context.Context, path string) ([]byte, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return client.DownloadImageData(ctx, path)
+}
+
+func GetMovieImage(ctx context.Context, movieID int) (*themoviedb.MovieImage, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电影（TMDB ID: %d）图片", movieID)
-	return client.GetMovieImage(movieID, nil, nil)
+	imageLanguage := imageLanguages()
+	return client.GetMovieImage(ctx, movieID, nil, &imageLanguage)
 }
 
-func GetTVSerieImage(tvID int) (*themoviedb.TVSerieImage, error) {
+func GetTVSerieImage(ctx context.Context, tvID int) (*themoviedb.TVSerieImage, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）图片", tvID)
-	return client.GetTVSerieImage(tvID, nil, nil)
+	imageLanguage := imageLanguages()
+	return client.GetTVSerieImage(ctx, tvID, &imageLanguage, nil)
 }
 
-func GetTVSeasonImage(tvID, seasonNumber int) (*themoviedb.TVSeasonImage, error) {
+func GetTVSeasonImage(ctx context.Context, tvID, seasonNumber int) (*themoviedb.TVSeasonImage, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）S%02d图片", tvID, seasonNumber)
-	return client.GetTVSeasonImage(tvID, seasonNumber, nil)
+	imageLanguage := imageLanguages()
+	return client.GetTVSeasonImage(ctx, tvID, seasonNumber, nil, &imageLanguage)
 }
 
-func GetTVEpisodeImage(tvID, seasonNumber, episodeNumber int) (*themoviedb.TVEpisodeImage, error) {
+func GetTVEpisodeImage(ctx context.Context, tvID, seasonNumber, episodeNumber int) (*themoviedb.TVEpisodeImage, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	logrus.Infof("开始获取电视剧（TMDB ID: %d）S%02dE%02d图片", tvID, seasonNumber, episodeNumber)
-	return client.GetTVEpisodeImage(tvID, seasonNumber, episodeNumber, nil)
+	return client.GetTVEpisodeImage(ctx, tvID, seasonNumber, episodeNumber, nil)
+}
+
+// imageLanguages 请求图片时的 include_image_language（如 zh,en,null）
+// 包含图片语言优先级中的所有语言，未配置时依次为 TMDB 语言、英文和无文字图片，以及额外配置的图片语言
+func imageLanguages() string {
+	languages := config.Media.Artwork.Languages
+	if len(languages) == 0 {
+		languages = []string{config.TMDB.Language, "en", "null"}
+	}
+	if config.TMDB.IncludeImageLanguage != "" {
+		languages = append(slices.Clone(languages), strings.Split(config.TMDB.IncludeImageLanguage, ",")...)
+	}
+
+	codes := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if i := strings.IndexAny(lang, "-_"); i > 0 { // 图片只区分主语言
+			lang = lang[:i]
+		}
+		switch lang {
+		case "":
+			continue
+		case "00", "xx", "none":
+			lang = "null"
+		}
+		if !slices.Contains(codes, lang) {
+			codes = append(codes, lang)
+		}
+	}
+	return strings.Join(codes, ",")
 }
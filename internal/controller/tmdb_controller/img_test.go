@@ -0,0 +1,37 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/config"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageLanguages(t *testing.T) {
+	tmdbConfig, artworkConfig := config.TMDB, config.Media.Artwork
+	t.Cleanup(func() { config.TMDB, config.Media.Artwork = tmdbConfig, artworkConfig })
+
+	config.TMDB.Language = "zh-CN"
+	config.TMDB.IncludeImageLanguage = ""
+	config.Media.Artwork.Languages = nil
+	require.Equal(t, "zh,en,null", imageLanguages())
+
+	config.Media.Artwork.Languages = []string{"ja-JP", "none", "en"}
+	config.TMDB.IncludeImageLanguage = "en,ko"
+	require.Equal(t, "ja,null,en,ko", imageLanguages())
+
+	// 请求图片时使用图片语言优先级中的所有语言
+	var includeImageLanguage string
+	useFixtureClient(t, nil, func(w http.ResponseWriter, r *http.Request) bool {
+		assert.Equal(t, "/3/movie/550/images", r.URL.Path)
+		includeImageLanguage = r.URL.Query().Get("include_image_language")
+		w.Write([]byte(`{"id":550}`))
+		return true
+	})
+	_, err := GetMovieImage(context.Background(), 550)
+	require.NoError(t, err)
+	require.Equal(t, "ja,null,en,ko", includeImageLanguage)
+}
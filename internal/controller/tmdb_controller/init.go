@@ -2,8 +2,10 @@ package tmdb_controller
 
 import (
 	"MediaTools/internal/config"
+	"MediaTools/internal/database"
 	"MediaTools/internal/outbound"
 	"MediaTools/internal/pkg/themoviedb/v3"
+	"fmt"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -34,11 +36,23 @@ func Init() error {
 	}
 	opts = append(opts, themoviedb.CustomHTTPClient(outbound.GetHTTPClient()))
 
+	cache, err := database.NewCache(config.TMDB.Cache, "tmdb")
+	if err != nil {
+		return fmt.Errorf("创建 TMDB 缓存失败: %w", err)
+	}
+	opts = append(opts,
+		themoviedb.CustomCache(cache),
+		themoviedb.CustomStaleWhileRevalidate(config.TMDB.Cache.StaleWhileRevalidate),
+	)
+
 	c, err := themoviedb.NewClient(config.TMDB.ApiKey, opts...)
 	if err != nil {
 		return err
 	}
 	client = c
+	if err := initTitleIndex(); err != nil {
+		return err
+	}
 	logrus.Info("TMDB Controller 初始化完成")
 	return nil
 
@@ -16,7 +16,7 @@ func MatchMovie(ctx context.Context, searchName string) (*schemas.MediaInfo, err
 	defer lock.RUnlock()
 
 	var firstResult *themoviedb.SearchMovieResponse
-	results, err := SearchMovie(searchName)
+	results, err := SearchMovie(ctx, searchName)
 	if err != nil {
 		return nil, fmt.Errorf("搜索电影「%s」失败: %v", searchName, err)
 	}
@@ -39,22 +39,22 @@ func MatchMovie(ctx context.Context, searchName string) (*schemas.MediaInfo, err
 
 		if utils.FuzzyMatching(searchName, result.Title, result.OriginalTitle) {
 			logrus.Infof("匹配电影「%s」(TMDB ID: %d)", result.Title, result.ID)
-			return GetInfo(result.ID, meta.MediaTypeMovie)
+			return GetInfo(ctx, result.ID, meta.MediaTypeMovie)
 		}
 
-		names, err := getNames(result.ID, meta.MediaTypeMovie)
+		names, err := getNames(ctx, result.ID, meta.MediaTypeMovie)
 		if err != nil {
 			logrus.Warnf("获取电影「%s(%d)」的其他名称失败: %v", result.Title, result.ID, err)
 			continue
 		}
 		if utils.FuzzyMatching(searchName, names...) {
 			logrus.Infof("匹配电影「%s」(TMDB ID: %d) 别名", result.Title, result.ID)
-			return GetInfo(result.ID, meta.MediaTypeMovie)
+			return GetInfo(ctx, result.ID, meta.MediaTypeMovie)
 		}
 
 	}
 	logrus.Warningf("未找到电影「%s」的匹配项，返回第一项: %s", searchName, firstResult.Title)
-	return GetInfo(firstResult.ID, meta.MediaTypeMovie)
+	return GetInfo(ctx, firstResult.ID, meta.MediaTypeMovie)
 }
 
 func MatchTV(ctx context.Context, searchName string) (*schemas.MediaInfo, error) {
@@ -62,7 +62,7 @@ func MatchTV(ctx context.Context, searchName string) (*schemas.MediaInfo, error)
 	defer lock.RUnlock()
 
 	var firstResult *themoviedb.SearchTVResponse
-	results, err := SearchTV(searchName)
+	results, err := SearchTV(ctx, searchName)
 	if err != nil {
 		return nil, fmt.Errorf("搜索电视剧「%s」失败: %v", searchName, err)
 	}
@@ -85,21 +85,21 @@ func MatchTV(ctx context.Context, searchName string) (*schemas.MediaInfo, error)
 
 		if utils.FuzzyMatching(searchName, result.Name, result.OriginalName) {
 			logrus.Infof("匹配电视剧「%s」(TMDB ID: %d)", result.Name, result.ID)
-			return GetInfo(result.ID, meta.MediaTypeTV)
+			return GetInfo(ctx, result.ID, meta.MediaTypeTV)
 		}
 
-		names, err := getNames(result.ID, meta.MediaTypeTV)
+		names, err := getNames(ctx, result.ID, meta.MediaTypeTV)
 		if err != nil {
 			logrus.Warnf("获取电视剧「%s(%d)」的其他名称失败: %v", result.Name, result.ID, err)
 			continue
 		}
 		if utils.FuzzyMatching(searchName, names...) {
 			logrus.Infof("匹配电视剧「%s」(TMDB ID: %d) 别名", result.Name, result.ID)
-			return GetInfo(result.ID, meta.MediaTypeTV)
+			return GetInfo(ctx, result.ID, meta.MediaTypeTV)
 		}
 	}
 	logrus.Warningf("未找到电视剧「%s」的匹配项，返回第一项: %s", searchName, firstResult.Name)
-	return GetInfo(firstResult.ID, meta.MediaTypeTV)
+	return GetInfo(ctx, firstResult.ID, meta.MediaTypeTV)
 }
 
 func MatchMulti(ctx context.Context, searchName string) (*schemas.MediaInfo, error) {
@@ -108,7 +108,7 @@ func MatchMulti(ctx context.Context, searchName string) (*schemas.MediaInfo, err
 
 	logrus.Infof("正在综合搜索「%s」...", searchName)
 	var firstResult *themoviedb.SearchMultiResponse
-	results, err := SearchMulti(searchName)
+	results, err := SearchMulti(ctx, searchName)
 	if err != nil {
 		return nil, fmt.Errorf("综合搜索「%s」失败: %v", searchName, err)
 	}
@@ -138,10 +138,10 @@ func MatchMulti(ctx context.Context, searchName string) (*schemas.MediaInfo, err
 
 		if utils.FuzzyMatching(searchName, result.Title, result.OriginalTitle, result.Name, result.OriginalName) {
 			logrus.Infof("匹配综合搜索结果「%s」(Type: %s TMDB ID: %d)", result.Title, mediaType, result.ID)
-			return GetInfo(result.ID, mediaType)
+			return GetInfo(ctx, result.ID, mediaType)
 		}
 
-		names, err := getNames(result.ID, mediaType)
+		names, err := getNames(ctx, result.ID, mediaType)
 		if err != nil {
 			logrus.Warnf("获取综合搜索结果「%s(Type: %s TMDB ID: %d)」的其他名称失败: %v", result.Title, mediaType, result.ID, err)
 			continue
@@ -149,7 +149,7 @@ func MatchMulti(ctx context.Context, searchName string) (*schemas.MediaInfo, err
 		if utils.FuzzyMatching(searchName, names...) {
 			logrus.Infof("匹配综合搜索结果「%s」(Type: %s TMDB ID: %d) 别名", result.Title, mediaType, result.ID)
 
-			return GetInfo(result.ID, mediaType)
+			return GetInfo(ctx, result.ID, mediaType)
 		}
 	}
 
@@ -158,5 +158,5 @@ func MatchMulti(ctx context.Context, searchName string) (*schemas.MediaInfo, err
 	if mediaType == meta.MediaTypeUnknown {
 		logrus.Warningf("综合搜索结果「%s」的媒体类型(%s)未知", firstResult.Title, firstResult.MediaType)
 	}
-	return GetInfo(firstResult.ID, mediaType)
+	return GetInfo(ctx, firstResult.ID, mediaType)
 }
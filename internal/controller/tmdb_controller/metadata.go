@@ -0,0 +1,58 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+func GetMovieKeyword(ctx context.Context, movieID int) (*themoviedb.MovieKeyword, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取电影（TMDB ID: %d）关键词", movieID)
+	return client.GetMovieKeyword(ctx, movieID)
+}
+
+func GetTVSerieKeyword(ctx context.Context, seriesID int) (*themoviedb.TVSerieKeyword, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取电视剧（TMDB ID: %d）关键词", seriesID)
+	return client.GetTVSerieKeyword(ctx, seriesID)
+}
+
+func GetMovieReleaseDate(ctx context.Context, movieID int) (*themoviedb.MovieReleaseDate, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取电影（TMDB ID: %d）上映日期和分级", movieID)
+	return client.GetMovieReleaseDate(ctx, movieID)
+}
+
+func GetTVSerieContentRating(ctx context.Context, seriesID int) (*themoviedb.TVSerieContentRating, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取电视剧（TMDB ID: %d）内容分级", seriesID)
+	return client.GetTVSerieContentRating(ctx, seriesID)
+}
+
+// language 为 nil 时使用 TMDB 配置的语言
+func GetMovieVideo(ctx context.Context, movieID int, language *string) (*themoviedb.MovieVideo, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取电影（TMDB ID: %d）视频", movieID)
+	return client.GetMovieVideo(ctx, movieID, language)
+}
+
+// language 为 nil 时使用 TMDB 配置的语言
+func GetTVSerieVideo(ctx context.Context, seriesID int, language *string) (*themoviedb.TVSerieVideo, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	logrus.Infof("开始获取电视剧（TMDB ID: %d）视频", seriesID)
+	return client.GetTVSerieVideo(ctx, seriesID, language)
+}
@@ -0,0 +1,85 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/database"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// getOrdering 获取剧集编号方式
+// 优先使用识别规则中指定的方式，其次使用剧集映射中保存的方式，默认按播出顺序
+func getOrdering(ctx context.Context, videoMeta *meta.VideoMeta, seriesID int) string {
+	if videoMeta.Ordering != "" {
+		return videoMeta.Ordering
+	}
+	mapping, err := database.QuerySeriesMappingByTMDBID(ctx, seriesID)
+	switch {
+	case err == nil:
+		if ordering, ok := meta.ParseOrdering(mapping.Ordering); ok {
+			return ordering
+		}
+		logrus.Warningf("电视剧（TMDB ID: %d）的剧集映射编号方式无效：%s", seriesID, mapping.Ordering)
+	case !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, database.ErrNotInitialized):
+		logrus.Warningf("查询电视剧（TMDB ID: %d）剧集映射失败: %v", seriesID, err)
+	}
+	return meta.OrderingAired
+}
+
+// applyOrdering 按剧集编号方式把识别到的季和集换算为 TMDB 播出季和集
+func applyOrdering(ctx context.Context, videoMeta *meta.VideoMeta, info *schemas.MediaInfo) error {
+	if videoMeta.Episode == -1 {
+		return nil
+	}
+	ordering := getOrdering(ctx, videoMeta, info.TMDBID)
+	var locate func(season int, episode int) (int, int, bool)
+	switch ordering {
+	case meta.OrderingAired:
+		return nil
+
+	case meta.OrderingAbsolute:
+		serie := info.TMDBInfo.TVInfo.SerieInfo
+		if serie == nil {
+			return fmt.Errorf("缺少电视剧（TMDB ID: %d）详情，无法换算绝对集数", info.TMDBID)
+		}
+		if videoMeta.Season > 1 { // 已明确指定季数，不再按绝对集数换算
+			return nil
+		}
+		locate = func(_ int, episode int) (int, int, bool) {
+			return serie.AbsoluteToAired(episode)
+		}
+
+	default:
+		group, err := GetTVEpisodeGroupDetail(ctx, ordering)
+		if err != nil {
+			return err
+		}
+		locate = group.Locate
+	}
+
+	groupSeason := -1 // 名称中没有季数时在所有分组中按顺序查找
+	if videoMeta.HasSeason() {
+		groupSeason = videoMeta.Season
+	}
+	season, episode, ok := locate(groupSeason, videoMeta.Episode)
+	if !ok {
+		return fmt.Errorf("按「%s」编号方式未找到 S%02dE%02d 对应的剧集", ordering, videoMeta.Season, videoMeta.Episode)
+	}
+	if videoMeta.EndEpisode != -1 {
+		endSeason, endEpisode, ok := locate(groupSeason, videoMeta.EndEpisode)
+		if ok && endSeason == season {
+			videoMeta.EndEpisode = endEpisode
+		} else {
+			videoMeta.EndEpisode = -1
+		}
+	}
+	logrus.Infof("按「%s」编号方式将 S%02dE%02d 换算为 S%02dE%02d", ordering, videoMeta.Season, videoMeta.Episode, season, episode)
+	videoMeta.Season = season
+	videoMeta.Episode = episode
+	return nil
+}
@@ -3,6 +3,7 @@ package tmdb_controller
 import (
 	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/schemas"
+	"MediaTools/utils"
 	"context"
 	"fmt"
 
@@ -20,7 +21,17 @@ func RecognizeMedia(ctx context.Context, videoMeta *meta.VideoMeta) (*schemas.Me
 
 	// 如果视频元数据中包含 TMDB ID，则直接查询
 	if videoMeta.TMDBID > 0 {
-		return GetInfo(videoMeta.TMDBID, videoMeta.MediaType)
+		return getInfoByTag(ctx, videoMeta)
+	}
+
+	// 如果包含 IMDb ID、TVDB ID，则先换算为 TMDB ID
+	if tmdbID, mediaType := findByExternalIDs(ctx, videoMeta); tmdbID > 0 {
+		return GetInfo(ctx, tmdbID, mediaType)
+	}
+
+	// 如果启用了本地标题索引，先在索引中筛选候选条目
+	if info := matchTitleIndex(ctx, videoMeta); info != nil {
+		return info, nil
 	}
 
 	// 如果没有 TMDB ID，则尝试识别媒体名称
@@ -44,6 +55,57 @@ func RecognizeMedia(ctx context.Context, videoMeta *meta.VideoMeta) (*schemas.Me
 	return nil, fmt.Errorf("未能 %v 识别媒体信息，可能是名称不匹配或 TMDB 中没有相关数据", videoMeta.GetTitles())
 }
 
+// getInfoByTag 通过名称中的 TMDB ID 标记获取媒体信息
+// 未知媒体类型时同一 ID 可能同时对应电影和电视剧，按标题和年份选择；
+// 无法区分时有季集信息的视为电视剧，否则视为电影
+func getInfoByTag(ctx context.Context, videoMeta *meta.VideoMeta) (*schemas.MediaInfo, error) {
+	tmdbID := videoMeta.TMDBID
+	if videoMeta.MediaType != meta.MediaTypeUnknown {
+		return GetInfo(ctx, tmdbID, videoMeta.MediaType)
+	}
+
+	movieDetail, movieErr := GetMovieDetail(ctx, tmdbID)
+	tvDetail, tvErr := GetTVSerieDetail(ctx, tmdbID)
+	switch {
+	case movieErr != nil && tvErr != nil:
+		return nil, fmt.Errorf("未查询到 TMDB ID「%d」信息", tmdbID)
+	case tvErr != nil:
+		return movieDetail, nil
+	case movieErr != nil:
+		return tvDetail, nil
+	}
+
+	movieMatched, tvMatched := matchTagDetail(movieDetail, videoMeta), matchTagDetail(tvDetail, videoMeta)
+	switch {
+	case movieMatched && !tvMatched:
+		logrus.Infof("TMDB ID「%d」按标题和年份识别为电影", tmdbID)
+		return movieDetail, nil
+	case tvMatched && !movieMatched:
+		logrus.Infof("TMDB ID「%d」按标题和年份识别为电视剧", tmdbID)
+		return tvDetail, nil
+	case videoMeta.Season != -1 || videoMeta.Episode != -1:
+		logrus.Infof("TMDB ID「%d」同时匹配到电影和电视剧，包含季集信息，视为电视剧", tmdbID)
+		return tvDetail, nil
+	default:
+		logrus.Infof("TMDB ID「%d」同时匹配到电影和电视剧，视为电影", tmdbID)
+		return movieDetail, nil
+	}
+}
+
+// matchTagDetail 检查详情的标题和年份是否与名称一致
+func matchTagDetail(info *schemas.MediaInfo, videoMeta *meta.VideoMeta) bool {
+	if !matchYear(info, videoMeta.Year) {
+		return false
+	}
+	titles := detailTitles(info)
+	for _, name := range videoMeta.GetTitles() {
+		if utils.FuzzyMatching(name, titles...) {
+			return true
+		}
+	}
+	return false
+}
+
 // RecognizeAndEnrichMedia 识别媒体信息，如果是电视剧类型，还会补充季和集的详细信息（如果有对应信息）
 // videoMeta 识别的元数据
 // 返回识别后的媒体信息，如果是电视剧类型，还会补充季和集的详细信息
@@ -55,9 +117,15 @@ func RecognizeAndEnrichMedia(ctx context.Context, videoMeta *meta.VideoMeta) (*s
 	logrus.Debugf("识别到媒体信息: %+v", info)
 
 	if info.MediaType == meta.MediaTypeTV {
+		if err := applyOrdering(ctx, videoMeta, info); err != nil {
+			logrus.Warningf("换算剧集编号失败: %v", err)
+		}
+		if err := applyAirDate(ctx, videoMeta, info); err != nil {
+			logrus.Warningf("按播出日期查找剧集失败: %v", err)
+		}
 		logrus.Debugf("识别到电视剧类型（S%02dE%02d），开始补充季和集信息...", videoMeta.Season, videoMeta.Episode)
 		if videoMeta.Season != -1 {
-			seasonDetail, err := GetTVSeasonDetail(info.TMDBID, videoMeta.Season)
+			seasonDetail, err := GetTVSeasonDetail(ctx, info.TMDBID, videoMeta.Season)
 			if err != nil {
 				logrus.Warningf("获取电视剧季信息失败: %v", err)
 			} else {
@@ -67,7 +135,7 @@ func RecognizeAndEnrichMedia(ctx context.Context, videoMeta *meta.VideoMeta) (*s
 
 		}
 		if videoMeta.Episode != -1 {
-			episodeDetail, err := GetTVEpisodeDetail(info.TMDBID, videoMeta.Season, videoMeta.Episode)
+			episodeDetail, err := GetTVEpisodeDetail(ctx, info.TMDBID, videoMeta.Season, videoMeta.Episode)
 			if err != nil {
 				logrus.Warningf("获取电视剧集信息失败: %v", err)
 			} else {
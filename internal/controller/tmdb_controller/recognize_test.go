@@ -22,10 +22,11 @@ func init() {
 }
 
 func TestGetInfo(t *testing.T) {
-	info, err := tmdb_controller.GetInfo(271607, meta.MediaTypeTV)
+	ctx := context.Background()
+	info, err := tmdb_controller.GetInfo(ctx, 271607, meta.MediaTypeTV)
 	require.NoError(t, err)
 	require.Equal(t, "薰香花朵凛然绽放", info.TMDBInfo.TVInfo.SerieInfo.Name)
-	info, err = tmdb_controller.GetInfo(874745, meta.MediaTypeMovie)
+	info, err = tmdb_controller.GetInfo(ctx, 874745, meta.MediaTypeMovie)
 	require.NoError(t, err)
 	require.Equal(t, "致深爱你的那个我", info.TMDBInfo.MovieInfo.Title)
 	require.Equal(t, "2022-10-07", info.TMDBInfo.MovieInfo.ReleaseDate)
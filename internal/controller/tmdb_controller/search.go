@@ -2,13 +2,14 @@ package tmdb_controller
 
 import (
 	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
 	"fmt"
 	"iter"
 
 	"github.com/sirupsen/logrus"
 )
 
-func SearchMovie(searchName string) (iter.Seq2[*themoviedb.SearchMovieResponse, error], error) {
+func SearchMovie(ctx context.Context, searchName string) (iter.Seq2[*themoviedb.SearchMovieResponse, error], error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
@@ -18,7 +19,7 @@ func SearchMovie(searchName string) (iter.Seq2[*themoviedb.SearchMovieResponse,
 		Query: searchName,
 		Page:  &page,
 	}
-	resp, err := client.SearchMovie(params)
+	resp, err := client.SearchMovie(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("搜索电影「%s」失败: %v", searchName, err)
 	}
@@ -34,7 +35,7 @@ func SearchMovie(searchName string) (iter.Seq2[*themoviedb.SearchMovieResponse,
 		}
 		if resp.TotalPages > 1 {
 			for page = 2; page <= uint32(resp.TotalPages); page++ {
-				resp, err = client.SearchMovie(params)
+				resp, err = client.SearchMovie(ctx, params)
 				if err != nil {
 					if !yield(nil, fmt.Errorf("搜索电影「%s」第 %d 页失败: %v", searchName, page, err)) {
 						return
@@ -50,7 +51,7 @@ func SearchMovie(searchName string) (iter.Seq2[*themoviedb.SearchMovieResponse,
 	}, nil
 }
 
-func SearchTV(searchName string) (iter.Seq2[*themoviedb.SearchTVResponse, error], error) {
+func SearchTV(ctx context.Context, searchName string) (iter.Seq2[*themoviedb.SearchTVResponse, error], error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
@@ -61,7 +62,7 @@ func SearchTV(searchName string) (iter.Seq2[*themoviedb.SearchTVResponse, error]
 		Query: searchName,
 		Page:  &page,
 	}
-	resp, err := client.SearchTV(params)
+	resp, err := client.SearchTV(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("搜索电视剧「%s」失败: %v", searchName, err)
 	}
@@ -77,7 +78,7 @@ func SearchTV(searchName string) (iter.Seq2[*themoviedb.SearchTVResponse, error]
 		}
 		if resp.TotalPages > 1 {
 			for page = 2; page <= uint32(resp.TotalPages); page++ {
-				resp, err = client.SearchTV(params)
+				resp, err = client.SearchTV(ctx, params)
 				if err != nil {
 					if !yield(nil, fmt.Errorf("搜索电视剧「%s」第 %d 页失败: %v", searchName, page, err)) {
 						return
@@ -93,7 +94,7 @@ func SearchTV(searchName string) (iter.Seq2[*themoviedb.SearchTVResponse, error]
 	}, nil
 }
 
-func SearchMulti(searchName string) (iter.Seq2[*themoviedb.SearchMultiResponse, error], error) {
+func SearchMulti(ctx context.Context, searchName string) (iter.Seq2[*themoviedb.SearchMultiResponse, error], error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
@@ -103,7 +104,7 @@ func SearchMulti(searchName string) (iter.Seq2[*themoviedb.SearchMultiResponse,
 		Query: searchName,
 		Page:  &page,
 	}
-	resp, err := client.SearchMulti(params)
+	resp, err := client.SearchMulti(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("综合搜索「%s」失败: %v", searchName, err)
 	}
@@ -119,7 +120,7 @@ func SearchMulti(searchName string) (iter.Seq2[*themoviedb.SearchMultiResponse,
 		}
 		if resp.TotalPages > 1 {
 			for page = 2; page <= uint32(resp.TotalPages); page++ {
-				resp, err = client.SearchMulti(params)
+				resp, err = client.SearchMulti(ctx, params)
 				if err != nil {
 					if !yield(nil, fmt.Errorf("综合搜索「%s」第 %d 页失败: %v", searchName, page, err)) {
 						return
@@ -0,0 +1,63 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInfoByTag(t *testing.T) {
+	routes := map[string]string{
+		"/3/movie/603":              "movie_603.json",
+		"/3/movie/603/external_ids": "movie_603_external_ids.json",
+		"/3/tv/603":                 "tv_603.json",
+		"/3/tv/603/external_ids":    "tv_603_external_ids.json",
+	}
+	useFixtureClient(t, routes, nil)
+	ctx := context.Background()
+
+	// 同一 ID 同时对应电影和电视剧时按标题和年份选择
+	videoMeta := meta.ParseVideoMeta("The Matrix (1999) {tmdb-603}.mkv")
+	require.Equal(t, meta.MediaTypeUnknown, videoMeta.MediaType)
+	require.Equal(t, 603, videoMeta.TMDBID)
+	info, err := RecognizeMedia(ctx, videoMeta)
+	require.NoError(t, err)
+	require.Equal(t, meta.MediaTypeMovie, info.MediaType)
+	require.Equal(t, "tt0133093", info.IMDBID)
+
+	info, err = RecognizeMedia(ctx, meta.ParseVideoMeta("The Matrix Files (2004) {tmdb-603}.mkv"))
+	require.NoError(t, err)
+	require.Equal(t, meta.MediaTypeTV, info.MediaType)
+
+	// 年份不符时不按标题选择，默认视为电影
+	info, err = RecognizeMedia(ctx, meta.ParseVideoMeta("The Matrix Files (1999) {tmdb-603}.mkv"))
+	require.NoError(t, err)
+	require.Equal(t, meta.MediaTypeMovie, info.MediaType)
+
+	// 无法区分时有季集信息的视为电视剧
+	videoMeta = meta.ParseVideoMeta("Matrix {tmdb-603}.mkv")
+	info, err = RecognizeMedia(ctx, videoMeta)
+	require.NoError(t, err)
+	require.Equal(t, meta.MediaTypeMovie, info.MediaType)
+	videoMeta.Episode = 3
+	info, err = RecognizeMedia(ctx, videoMeta)
+	require.NoError(t, err)
+	require.Equal(t, meta.MediaTypeTV, info.MediaType)
+
+	// 只查询到一种类型时直接使用
+	tvRoutes := maps.Clone(routes)
+	delete(tvRoutes, "/3/movie/603")
+	useFixtureClient(t, tvRoutes, nil)
+	info, err = RecognizeMedia(ctx, meta.ParseVideoMeta("The Matrix (1999) {tmdb-603}.mkv"))
+	require.NoError(t, err)
+	require.Equal(t, meta.MediaTypeTV, info.MediaType)
+
+	// 已知媒体类型时只查询该类型
+	videoMeta = meta.ParseVideoMeta("The Matrix (1999) {tmdb-603}.mkv")
+	videoMeta.MediaType = meta.MediaTypeMovie
+	_, err = RecognizeMedia(ctx, videoMeta)
+	require.Error(t, err)
+}
@@ -0,0 +1,298 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/task_controller"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/pkg/titleindex"
+	"MediaTools/internal/schemas"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	titleIndexMinScore   = 0.7 // 索引候选的最低相似度
+	titleIndexCandidates = 3   // 每个名称最多通过 API 核对的候选数
+)
+
+// TitleIndexStatus 本地标题索引状态
+type TitleIndexStatus struct {
+	Enable    bool      `json:"enable"`             // 是否启用
+	Loading   bool      `json:"loading"`            // 是否正在加载
+	MovieFile string    `json:"movie_file"`         // 已加载的电影导出文件
+	TVFile    string    `json:"tv_file"`            // 已加载的电视剧导出文件
+	Movies    int       `json:"movies"`             // 电影条目数
+	TVs       int       `json:"tvs"`                // 电视剧条目数
+	LoadedAt  time.Time `json:"loaded_at,omitzero"` // 加载完成时间
+	Error     string    `json:"error,omitempty"`    // 最近一次加载的错误
+}
+
+var titleIndex struct {
+	sync.RWMutex
+	movie  *titleindex.Index
+	tv     *titleindex.Index
+	config config.TitleIndexConfig // 已加载索引对应的配置
+	status TitleIndexStatus
+
+	loading    config.TitleIndexConfig // 正在加载的配置
+	generation uint64                  // 每次开始加载或停用时递增，加载完成时不一致说明已被停用或重新加载，丢弃结果
+	cancel     context.CancelFunc      // 取消正在进行的加载
+}
+
+// resetTitleIndexLoad 使正在进行的加载失效，需持有写锁
+func resetTitleIndexLoad() uint64 {
+	if titleIndex.cancel != nil {
+		titleIndex.cancel()
+		titleIndex.cancel = nil
+	}
+	titleIndex.generation++
+	return titleIndex.generation
+}
+
+// initTitleIndex 根据配置加载或清空本地标题索引，配置未变化时不重新加载
+func initTitleIndex() error {
+	cfg := config.TMDB.TitleIndex
+	if !cfg.Enable {
+		titleIndex.Lock()
+		resetTitleIndexLoad()
+		titleIndex.movie, titleIndex.tv = nil, nil
+		titleIndex.config = cfg
+		titleIndex.status = TitleIndexStatus{}
+		titleIndex.Unlock()
+		return nil
+	}
+	if cfg.MovieFile == "" && cfg.TVFile == "" {
+		return fmt.Errorf("启用本地标题索引时需要配置电影或电视剧导出文件路径")
+	}
+
+	titleIndex.RLock()
+	unchanged := titleIndex.config == cfg && !titleIndex.status.LoadedAt.IsZero()
+	if titleIndex.status.Loading {
+		unchanged = titleIndex.loading == cfg
+	}
+	titleIndex.RUnlock()
+	if unchanged {
+		return nil
+	}
+	go func() {
+		if err := RefreshTitleIndex(context.Background()); err != nil {
+			logrus.Warningf("加载本地标题索引失败: %v", err)
+		}
+	}()
+	return nil
+}
+
+// RefreshTitleIndex 从配置的导出文件重新加载本地标题索引
+// 加载期间继续使用旧索引，加载失败时保留旧索引
+// 配置变化后重新加载时取消旧配置的加载，加载期间被停用或重新加载时丢弃本次结果
+func RefreshTitleIndex(ctx context.Context) error {
+	cfg := config.TMDB.TitleIndex
+	if !cfg.Enable {
+		return fmt.Errorf("本地标题索引未启用")
+	}
+
+	titleIndex.Lock()
+	if titleIndex.status.Loading && titleIndex.loading == cfg {
+		titleIndex.Unlock()
+		return fmt.Errorf("本地标题索引正在加载中")
+	}
+	generation := resetTitleIndexLoad()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	titleIndex.cancel = cancel
+	titleIndex.loading = cfg
+	titleIndex.status.Enable = true
+	titleIndex.status.Loading = true
+	titleIndex.Unlock()
+
+	logrus.Info("开始加载本地标题索引...")
+	var (
+		movie, tv         *titleindex.Index
+		movieFile, tvFile string
+		err               error
+	)
+	if cfg.MovieFile != "" {
+		movie, movieFile, err = loadTitleIndex(cfg.MovieFile, titleindex.MovieExportPrefix, cfg.MinPopularity)
+	}
+	if err == nil && ctx.Err() != nil {
+		err = fmt.Errorf("加载本地标题索引被取消: %w", ctx.Err())
+	}
+	if err == nil && cfg.TVFile != "" {
+		tv, tvFile, err = loadTitleIndex(cfg.TVFile, titleindex.TVExportPrefix, cfg.MinPopularity)
+	}
+
+	titleIndex.Lock()
+	defer titleIndex.Unlock()
+	if titleIndex.generation != generation {
+		return fmt.Errorf("本地标题索引已停用或重新加载，丢弃本次加载结果")
+	}
+	titleIndex.cancel = nil
+	titleIndex.status.Loading = false
+	if err != nil {
+		titleIndex.status.Error = err.Error()
+		return err
+	}
+	titleIndex.movie, titleIndex.tv = movie, tv
+	titleIndex.config = cfg
+	titleIndex.status = TitleIndexStatus{
+		Enable:    true,
+		MovieFile: movieFile,
+		TVFile:    tvFile,
+		Movies:    movie.Len(),
+		TVs:       tv.Len(),
+		LoadedAt:  time.Now(),
+	}
+	logrus.Infof("本地标题索引加载完成，电影 %d 条，电视剧 %d 条", movie.Len(), tv.Len())
+	return nil
+}
+
+var loadTitleIndex = buildTitleIndex // 测试时替换
+
+func buildTitleIndex(path string, prefix string, minPopularity float64) (*titleindex.Index, string, error) {
+	entries, file, err := titleindex.LoadFile(path, prefix, minPopularity)
+	if err != nil {
+		return nil, file, err
+	}
+	logrus.Infof("读取导出文件「%s」完成，共 %d 条，开始构建索引...", file, len(entries))
+	return titleindex.Build(entries), file, nil
+}
+
+// SubmitTitleIndexRefresh 提交后台任务重新加载本地标题索引
+func SubmitTitleIndexRefresh() (*task.Task, error) {
+	if !config.TMDB.TitleIndex.Enable {
+		return nil, fmt.Errorf("本地标题索引未启用")
+	}
+	return task_controller.SubmitScrapeTask("刷新本地标题索引", func(ctx context.Context) {
+		if err := RefreshTitleIndex(ctx); err != nil {
+			logrus.Warningf("刷新本地标题索引失败: %v", err)
+		}
+	}), nil
+}
+
+// GetTitleIndexStatus 获取本地标题索引状态
+func GetTitleIndexStatus() TitleIndexStatus {
+	titleIndex.RLock()
+	defer titleIndex.RUnlock()
+	return titleIndex.status
+}
+
+type titleIndexCandidate struct {
+	titleindex.Result
+	mediaType meta.MediaType
+}
+
+// searchTitleIndex 在本地标题索引中搜索候选条目，MediaTypeUnknown 时同时搜索电影和电视剧
+func searchTitleIndex(name string, mediaType meta.MediaType) []titleIndexCandidate {
+	titleIndex.RLock()
+	defer titleIndex.RUnlock()
+
+	var candidates []titleIndexCandidate
+	if mediaType != meta.MediaTypeTV {
+		for _, r := range titleIndex.movie.Search(name, titleIndexCandidates, titleIndexMinScore) {
+			candidates = append(candidates, titleIndexCandidate{r, meta.MediaTypeMovie})
+		}
+	}
+	if mediaType != meta.MediaTypeMovie {
+		for _, r := range titleIndex.tv.Search(name, titleIndexCandidates, titleIndexMinScore) {
+			candidates = append(candidates, titleIndexCandidate{r, meta.MediaTypeTV})
+		}
+	}
+	if len(candidates) > titleIndexCandidates {
+		slices.SortStableFunc(candidates, func(a, b titleIndexCandidate) int {
+			if c := cmp.Compare(b.Score, a.Score); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.Popularity, a.Popularity)
+		})
+		candidates = candidates[:titleIndexCandidates]
+	}
+	return candidates
+}
+
+// matchTitleIndex 通过本地标题索引识别媒体，只通过 API 获取候选条目的详情
+// 已知年份时跳过年份不符的候选；只接受标题归一化后完全相同的候选，
+// 或详情中的标题、原始标题、别名与名称相同的候选，没有可确认的候选时返回 nil，继续通过 API 搜索
+func matchTitleIndex(ctx context.Context, videoMeta *meta.VideoMeta) *schemas.MediaInfo {
+	for _, name := range videoMeta.GetTitles() {
+		for _, c := range searchTitleIndex(name, videoMeta.MediaType) {
+			info, err := GetInfo(ctx, c.ID, c.mediaType)
+			if err != nil {
+				logrus.Warningf("获取本地索引候选「%s」(TMDB ID: %d) 详情失败: %v", c.Title, c.ID, err)
+				continue
+			}
+			if !matchYear(info, videoMeta.Year) {
+				logrus.Debugf("本地索引候选「%s」(TMDB ID: %d) 年份与 %d 不符，跳过", c.Title, c.ID, videoMeta.Year)
+				continue
+			}
+			if !verifyTitleIndexCandidate(ctx, name, c, info) {
+				logrus.Debugf("本地索引候选「%s」(TMDB ID: %d) 的标题与「%s」不符，跳过", c.Title, c.ID, name)
+				continue
+			}
+			logrus.Infof("通过本地标题索引匹配「%s」(TMDB ID: %d，相似度 %.2f)", c.Title, c.ID, c.Score)
+			return info
+		}
+	}
+	return nil
+}
+
+// verifyTitleIndexCandidate 核对候选条目的标题，相似度只用于筛选候选，
+// 如「Frozen 2」与「Frozen」相似度很高，但不是同一部作品
+func verifyTitleIndexCandidate(ctx context.Context, name string, c titleIndexCandidate, info *schemas.MediaInfo) bool {
+	normalized := titleindex.Normalize(name)
+	if normalized == titleindex.Normalize(c.Title) {
+		return true
+	}
+	titles := detailTitles(info)
+	names, err := getNames(ctx, c.ID, c.mediaType)
+	if err != nil {
+		logrus.Warningf("获取本地索引候选「%s」(TMDB ID: %d) 的其他名称失败: %v", c.Title, c.ID, err)
+	}
+	for _, title := range append(titles, names...) {
+		if titleindex.Normalize(title) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// matchYear 检查媒体年份，电影允许相差 1 年，
+// 电视剧的年份可以是首播到最后播出之间某一季的年份，同样允许相差 1 年
+func matchYear(info *schemas.MediaInfo, year int) bool {
+	if year <= 0 {
+		return true
+	}
+	var date, lastDate string
+	switch {
+	case info.MediaType == meta.MediaTypeMovie && info.TMDBInfo.MovieInfo != nil:
+		date = info.TMDBInfo.MovieInfo.ReleaseDate
+	case info.MediaType == meta.MediaTypeTV && info.TMDBInfo.TVInfo.SerieInfo != nil:
+		date = info.TMDBInfo.TVInfo.SerieInfo.FirstAirDate
+		lastDate = info.TMDBInfo.TVInfo.SerieInfo.LastAirDate
+	}
+	infoYear, ok := dateYear(date)
+	if !ok {
+		return true
+	}
+	lastYear := infoYear
+	if y, ok := dateYear(lastDate); ok && y > lastYear {
+		lastYear = y
+	}
+	return year >= infoYear-1 && year <= lastYear+1
+}
+
+// dateYear 解析 YYYY-MM-DD 形式日期中的年份
+func dateYear(date string) (int, bool) {
+	if len(date) < 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(date[:4])
+	return year, err == nil
+}
@@ -0,0 +1,130 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"MediaTools/internal/pkg/titleindex"
+	"MediaTools/internal/schemas"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// useTitleIndex 替换本地标题索引，测试结束时恢复
+func useTitleIndex(t *testing.T, movies, tvs []titleindex.Entry) {
+	titleIndex.Lock()
+	oldMovie, oldTV := titleIndex.movie, titleIndex.tv
+	titleIndex.movie, titleIndex.tv = titleindex.Build(movies), titleindex.Build(tvs)
+	titleIndex.Unlock()
+	t.Cleanup(func() {
+		titleIndex.Lock()
+		titleIndex.movie, titleIndex.tv = oldMovie, oldTV
+		titleIndex.Unlock()
+	})
+}
+
+func TestMatchTitleIndex(t *testing.T) {
+	useFixtureClient(t, map[string]string{
+		"/3/movie/109445":                    "movie_109445.json",
+		"/3/movie/109445/external_ids":       "external_ids.json",
+		"/3/movie/330457":                    "movie_330457.json",
+		"/3/movie/330457/external_ids":       "external_ids.json",
+		"/3/movie/330457/alternative_titles": "movie_330457_alternative_titles.json",
+		"/3/movie/330457/translations":       "movie_330457_translations.json",
+		"/3/movie/383498":                    "movie_383498.json",
+		"/3/movie/383498/external_ids":       "external_ids.json",
+		"/3/tv/1396":                         "tv_1396.json",
+		"/3/tv/1396/external_ids":            "external_ids.json",
+	}, nil)
+	useTitleIndex(t, []titleindex.Entry{
+		{ID: 109445, Title: "Frozen", Popularity: 100},
+		{ID: 330457, Title: "Frozen II", Popularity: 90},
+		{ID: 383498, Title: "Deadpool 2", Popularity: 80},
+	}, []titleindex.Entry{
+		{ID: 1396, Title: "Breaking Bad", Popularity: 100},
+	})
+	ctx := context.Background()
+	match := func(title string, year int, mediaType meta.MediaType) *schemas.MediaInfo {
+		return matchTitleIndex(ctx, &meta.VideoMeta{ENTitle: title, Year: year, MediaType: mediaType, Season: -1, Episode: -1})
+	}
+
+	info := match("Frozen", 2013, meta.MediaTypeMovie)
+	require.NotNil(t, info)
+	require.Equal(t, 109445, info.TMDBID)
+
+	// 相似但不相同的标题需要通过详情中的别名确认
+	info = match("Frozen 2", 0, meta.MediaTypeUnknown)
+	require.NotNil(t, info)
+	require.Equal(t, 330457, info.TMDBID)
+
+	// 无法确认时交给 API 搜索
+	require.Nil(t, match("Deadpool 3", 0, meta.MediaTypeUnknown))
+	require.Nil(t, match("Deadpool 3", 2024, meta.MediaTypeMovie))
+
+	info = match("Breaking Bad", 2012, meta.MediaTypeTV)
+	require.NotNil(t, info)
+	require.Equal(t, 1396, info.TMDBID)
+	require.Nil(t, match("Breaking Bad", 2020, meta.MediaTypeTV))
+}
+
+func TestMatchYear(t *testing.T) {
+	movie := &schemas.MediaInfo{MediaType: meta.MediaTypeMovie}
+	movie.TMDBInfo.MovieInfo = &themoviedb.MovieDetail{ReleaseDate: "2013-11-20"}
+	require.True(t, matchYear(movie, 0))
+	require.True(t, matchYear(movie, 2012))
+	require.True(t, matchYear(movie, 2014))
+	require.False(t, matchYear(movie, 2015))
+
+	tv := &schemas.MediaInfo{MediaType: meta.MediaTypeTV}
+	tv.TMDBInfo.TVInfo.SerieInfo = &themoviedb.TVSerieDetail{FirstAirDate: "2008-01-20", LastAirDate: "2013-09-29"}
+	require.True(t, matchYear(tv, 2007))
+	require.True(t, matchYear(tv, 2011))
+	require.True(t, matchYear(tv, 2014))
+	require.False(t, matchYear(tv, 2006))
+	require.False(t, matchYear(tv, 2015))
+
+	// 没有最后播出日期时只允许首播年份前后 1 年
+	tv.TMDBInfo.TVInfo.SerieInfo.LastAirDate = ""
+	require.True(t, matchYear(tv, 2009))
+	require.False(t, matchYear(tv, 2010))
+}
+
+func TestRefreshTitleIndexDisabledWhileLoading(t *testing.T) {
+	oldConfig, oldLoad := config.TMDB.TitleIndex, loadTitleIndex
+	t.Cleanup(func() {
+		config.TMDB.TitleIndex, loadTitleIndex = oldConfig, oldLoad
+		config.TMDB.TitleIndex.Enable = false
+		require.NoError(t, initTitleIndex())
+	})
+
+	release := make(chan struct{})
+	loadTitleIndex = func(path string, prefix string, minPopularity float64) (*titleindex.Index, string, error) {
+		<-release
+		return titleindex.Build([]titleindex.Entry{{ID: 603, Title: "The Matrix"}}), path, nil
+	}
+	config.TMDB.TitleIndex = config.TitleIndexConfig{Enable: true, MovieFile: "movie_ids.json.gz"}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- RefreshTitleIndex(context.Background()) }()
+	require.Eventually(t, func() bool { return GetTitleIndexStatus().Loading }, time.Second, time.Millisecond)
+	require.Error(t, RefreshTitleIndex(context.Background()), "同一配置正在加载时不重复加载")
+
+	// 加载期间停用，加载完成后不启用索引
+	config.TMDB.TitleIndex.Enable = false
+	require.NoError(t, initTitleIndex())
+	close(release)
+	require.Error(t, <-errCh)
+	require.Equal(t, TitleIndexStatus{}, GetTitleIndexStatus())
+	require.Empty(t, searchTitleIndex("The Matrix", meta.MediaTypeMovie))
+
+	// 重新启用后正常加载
+	config.TMDB.TitleIndex.Enable = true
+	require.NoError(t, RefreshTitleIndex(context.Background()))
+	status := GetTitleIndexStatus()
+	require.True(t, status.Enable)
+	require.Equal(t, 1, status.Movies)
+	require.Len(t, searchTitleIndex("The Matrix", meta.MediaTypeMovie), 1)
+}
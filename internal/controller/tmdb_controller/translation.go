@@ -0,0 +1,220 @@
+package tmdb_controller
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/schemas"
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Translation TMDB 翻译中的文本
+type Translation struct {
+	Language string // ISO 639-1 语言代码
+	Region   string // ISO 3166-1 国家/地区代码
+	Title    string // 标题（电视剧、季、集为名称）
+	Overview string // 简介
+	Tagline  string // 宣传语
+}
+
+// TMDB 在缺少译名时自动生成的集名称（如「第 3 集」「Episode 3」），视为空
+var placeholderEpisodeNameRe = regexp.MustCompile(`^(第\s*\d+\s*[集話话]|Episode\s+\d+|エピソード\s*\d+|Folge\s+\d+|Épisode\s+\d+|Episodio\s+\d+|\d+\s*화)$`)
+
+// IsPlaceholderEpisodeName 判断集名称是否为 TMDB 自动生成的占位名称
+func IsPlaceholderEpisodeName(name string) bool {
+	return placeholderEpisodeNameRe.MatchString(strings.TrimSpace(name))
+}
+
+// splitLanguage 将 zh-CN 形式的语言拆分为语言和地区
+func splitLanguage(language string) (string, string) {
+	lang, region, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"), "-")
+	return strings.ToLower(lang), strings.ToUpper(region)
+}
+
+// MatchTranslation 查找指定语言的翻译，语言带地区时优先匹配相同地区，否则匹配相同语言的第一个翻译
+func MatchTranslation(translations []Translation, language string) (Translation, bool) {
+	lang, region := splitLanguage(language)
+	if lang == "" {
+		return Translation{}, false
+	}
+	if region != "" {
+		for _, t := range translations {
+			if strings.EqualFold(t.Language, lang) && strings.EqualFold(t.Region, region) {
+				return t, true
+			}
+		}
+	}
+	for _, t := range translations {
+		if strings.EqualFold(t.Language, lang) {
+			return t, true
+		}
+	}
+	return Translation{}, false
+}
+
+// FallbackText 按语言链依次查找第一个非空文本
+func FallbackText(translations []Translation, languages []string, get func(Translation) string) string {
+	for _, language := range languages {
+		t, ok := MatchTranslation(translations, language)
+		if !ok {
+			continue
+		}
+		if text := strings.TrimSpace(get(t)); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// fillText 文本为空时按回退语言链填充
+func fillText(field *string, translations []Translation, get func(Translation) string) {
+	if strings.TrimSpace(*field) != "" {
+		return
+	}
+	if text := FallbackText(translations, config.TMDB.FallbackLanguages, get); text != "" {
+		*field = text
+	}
+}
+
+// TranslationTitles 各语言标题，同时以「语言-地区」和「语言」为键（如 en-US、en）
+// 主语言的标题优先作为对应语言的标题；原始语言的翻译没有标题时使用原始标题
+func TranslationTitles(translations []Translation, language string, title string, originalLanguage string, originalTitle string) map[string]string {
+	titles := make(map[string]string)
+	add := func(lang, region, title string) {
+		if title == "" || lang == "" {
+			return
+		}
+		if region != "" {
+			if _, ok := titles[lang+"-"+region]; !ok {
+				titles[lang+"-"+region] = title
+			}
+		}
+		if _, ok := titles[lang]; !ok {
+			titles[lang] = title
+		}
+	}
+
+	lang, region := splitLanguage(language)
+	add(lang, region, title)
+	for _, t := range translations {
+		title := t.Title
+		if title == "" && strings.EqualFold(t.Language, originalLanguage) {
+			title = originalTitle
+		}
+		add(strings.ToLower(t.Language), strings.ToUpper(t.Region), title)
+	}
+	add(strings.ToLower(originalLanguage), "", originalTitle)
+	return titles
+}
+
+// applyMovieTranslation 使用翻译补充电影的标题、简介、宣传语，并记录各语言标题
+func applyMovieTranslation(ctx context.Context, info *schemas.MediaInfo) {
+	detail := info.TMDBInfo.MovieInfo
+	resp, err := client.GetMovieTranslation(ctx, info.TMDBID)
+	if err != nil {
+		logrus.Warningf("获取电影（TMDB ID: %d）翻译失败: %v", info.TMDBID, err)
+		return
+	}
+	translations := make([]Translation, 0, len(resp.Translations))
+	for _, t := range resp.Translations {
+		translations = append(translations, Translation{
+			Language: t.Iso6391,
+			Region:   t.Iso31661,
+			Title:    t.Data.Title,
+			Overview: t.Data.Overview,
+			Tagline:  t.Data.Tagline,
+		})
+	}
+
+	fillText(&detail.Title, translations, func(t Translation) string { return t.Title })
+	fillText(&detail.Overview, translations, func(t Translation) string { return t.Overview })
+	fillText(&detail.Tagline, translations, func(t Translation) string { return t.Tagline })
+	info.Titles = TranslationTitles(translations, config.TMDB.Language, detail.Title, detail.OriginalLanguage, detail.OriginalTitle)
+}
+
+// applyTVSerieTranslation 使用翻译补充电视剧的名称、简介、宣传语，并记录各语言标题
+func applyTVSerieTranslation(ctx context.Context, info *schemas.MediaInfo) {
+	detail := info.TMDBInfo.TVInfo.SerieInfo
+	resp, err := client.GetTVSerieTranslation(ctx, info.TMDBID)
+	if err != nil {
+		logrus.Warningf("获取电视剧（TMDB ID: %d）翻译失败: %v", info.TMDBID, err)
+		return
+	}
+	translations := make([]Translation, 0, len(resp.Translations))
+	for _, t := range resp.Translations {
+		translations = append(translations, Translation{
+			Language: t.Iso6391,
+			Region:   t.Iso31661,
+			Title:    t.Data.Name,
+			Overview: t.Data.Overview,
+			Tagline:  t.Data.Tagline,
+		})
+	}
+
+	fillText(&detail.Name, translations, func(t Translation) string { return t.Title })
+	fillText(&detail.Overview, translations, func(t Translation) string { return t.Overview })
+	fillText(&detail.Tagline, translations, func(t Translation) string { return t.Tagline })
+	info.Titles = TranslationTitles(translations, config.TMDB.Language, detail.Name, detail.OriginalLanguage, detail.OriginalName)
+}
+
+// applyTVSeasonTranslation 季简介为空时使用翻译补充
+// 季名称总有默认值（如「第 1 季」），不做处理
+func applyTVSeasonTranslation(ctx context.Context, seriesID int, seasonNumber int, info *schemas.MediaInfo) {
+	detail := info.TMDBInfo.TVInfo.SeasonInfo
+	if len(config.TMDB.FallbackLanguages) == 0 || strings.TrimSpace(detail.Overview) != "" {
+		return
+	}
+	resp, err := client.GetTVSeasonTranslation(ctx, seriesID, seasonNumber)
+	if err != nil {
+		logrus.Warningf("获取电视剧（TMDB ID: %d）S%02d翻译失败: %v", seriesID, seasonNumber, err)
+		return
+	}
+	translations := make([]Translation, 0, len(resp.Translations))
+	for _, t := range resp.Translations {
+		translations = append(translations, Translation{
+			Language: t.Iso6391,
+			Region:   t.Iso31661,
+			Title:    t.Data.Name,
+			Overview: t.Data.Overview,
+		})
+	}
+	fillText(&detail.Overview, translations, func(t Translation) string { return t.Overview })
+}
+
+// applyTVEpisodeTranslation 集名称、简介为空时使用翻译补充，TMDB 自动生成的集名称视为空
+func applyTVEpisodeTranslation(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int, info *schemas.MediaInfo) {
+	detail := info.TMDBInfo.TVInfo.EpisodeInfo
+	placeholder := IsPlaceholderEpisodeName(detail.Name)
+	if len(config.TMDB.FallbackLanguages) == 0 || (!placeholder && strings.TrimSpace(detail.Name) != "" && strings.TrimSpace(detail.Overview) != "") {
+		return
+	}
+	resp, err := client.GetTVEpisodeTranslation(ctx, seriesID, seasonNumber, episodeNumber)
+	if err != nil {
+		logrus.Warningf("获取电视剧（TMDB ID: %d）S%02dE%02d翻译失败: %v", seriesID, seasonNumber, episodeNumber, err)
+		return
+	}
+	translations := make([]Translation, 0, len(resp.Translations))
+	for _, t := range resp.Translations {
+		name := t.Data.Name
+		if IsPlaceholderEpisodeName(name) {
+			name = ""
+		}
+		translations = append(translations, Translation{
+			Language: t.Iso6391,
+			Region:   t.Iso31661,
+			Title:    name,
+			Overview: t.Data.Overview,
+		})
+	}
+
+	if placeholder {
+		if name := FallbackText(translations, config.TMDB.FallbackLanguages, func(t Translation) string { return t.Title }); name != "" {
+			detail.Name = name
+		}
+	} else {
+		fillText(&detail.Name, translations, func(t Translation) string { return t.Title })
+	}
+	fillText(&detail.Overview, translations, func(t Translation) string { return t.Overview })
+}
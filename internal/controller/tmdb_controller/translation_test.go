@@ -0,0 +1,44 @@
+package tmdb_controller_test
+
+import (
+	"MediaTools/internal/controller/tmdb_controller"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackText(t *testing.T) {
+	translations := []tmdb_controller.Translation{
+		{Language: "zh", Region: "CN", Title: "你的名字。"},
+		{Language: "zh", Region: "TW", Title: "你的名字", Overview: "繁體簡介"},
+		{Language: "ja", Region: "JP", Overview: "あらすじ"},
+		{Language: "en", Region: "US", Title: "Your Name.", Overview: "Overview"},
+	}
+	overview := func(t tmdb_controller.Translation) string { return t.Overview }
+
+	require.Equal(t, "繁體簡介", tmdb_controller.FallbackText(translations, []string{"zh-CN", "zh-TW", "en-US"}, overview))
+	require.Equal(t, "あらすじ", tmdb_controller.FallbackText(translations, []string{"ja"}, overview))
+	require.Equal(t, "Overview", tmdb_controller.FallbackText(translations, []string{"en-GB"}, overview))
+	require.Empty(t, tmdb_controller.FallbackText(translations, []string{"ko-KR"}, overview))
+}
+
+func TestTranslationTitles(t *testing.T) {
+	translations := []tmdb_controller.Translation{
+		{Language: "zh", Region: "TW", Title: "你的名字"},
+		{Language: "ja", Region: "JP"},
+		{Language: "en", Region: "US", Title: "Your Name."},
+	}
+	titles := tmdb_controller.TranslationTitles(translations, "zh-CN", "你的名字。", "ja", "君の名は。")
+	require.Equal(t, "你的名字。", titles["zh"])
+	require.Equal(t, "你的名字。", titles["zh-CN"])
+	require.Equal(t, "你的名字", titles["zh-TW"])
+	require.Equal(t, "君の名は。", titles["ja"])
+	require.Equal(t, "Your Name.", titles["en"])
+}
+
+func TestIsPlaceholderEpisodeName(t *testing.T) {
+	require.True(t, tmdb_controller.IsPlaceholderEpisodeName("第 3 集"))
+	require.True(t, tmdb_controller.IsPlaceholderEpisodeName("Episode 12"))
+	require.False(t, tmdb_controller.IsPlaceholderEpisodeName("Episode of the Dead"))
+	require.False(t, tmdb_controller.IsPlaceholderEpisodeName("三年后的世界"))
+}
@@ -3,6 +3,7 @@ package tmdb_controller
 import (
 	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/schemas"
+	"context"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
@@ -11,14 +12,14 @@ import (
 // 给定TMDB号，查询一条媒体信息
 // tmdbID TMDB ID
 // mtype 媒体类型，未指定（MediaTypeUnknown）时会尝试识别
-func GetInfo(tmdbID int, mtype meta.MediaType) (*schemas.MediaInfo, error) {
+func GetInfo(ctx context.Context, tmdbID int, mtype meta.MediaType) (*schemas.MediaInfo, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
 	if mtype == meta.MediaTypeUnknown {
 		logrus.Infof("未指定 TMDB ID 「%d」的媒体类型", tmdbID)
-		movieDetail, movieErr := GetMovieDetail(tmdbID)
-		tvDetail, tvErr := GetTVSerieDetail(tmdbID)
+		movieDetail, movieErr := GetMovieDetail(ctx, tmdbID)
+		tvDetail, tvErr := GetTVSerieDetail(ctx, tmdbID)
 
 		switch {
 		case movieErr == nil && tvErr == nil:
@@ -36,16 +37,16 @@ func GetInfo(tmdbID int, mtype meta.MediaType) (*schemas.MediaInfo, error) {
 
 	switch mtype {
 	case meta.MediaTypeMovie:
-		return GetMovieDetail(tmdbID)
+		return GetMovieDetail(ctx, tmdbID)
 	case meta.MediaTypeTV:
-		return GetTVSerieDetail(tmdbID)
+		return GetTVSerieDetail(ctx, tmdbID)
 	default:
 		return nil, fmt.Errorf("不支持的媒体类型: 「%s」", mtype)
 	}
 }
 
 // 搜索tmdb中所有的标题和译名
-func getNames(tmdbID int, mtype meta.MediaType) ([]string, error) {
+func getNames(ctx context.Context, tmdbID int, mtype meta.MediaType) ([]string, error) {
 	lock.RLock()
 	defer lock.RUnlock()
 
@@ -53,11 +54,11 @@ func getNames(tmdbID int, mtype meta.MediaType) ([]string, error) {
 
 	switch mtype {
 	case meta.MediaTypeMovie:
-		titleResp, err := client.GetMovieAlternativeTitle(tmdbID, nil)
+		titleResp, err := client.GetMovieAlternativeTitle(ctx, tmdbID, nil)
 		if err != nil {
 			return nil, fmt.Errorf("获取电影「%d」的其他标题失败: %v", tmdbID, err)
 		}
-		translationResp, err := client.GetMovieTranslation(tmdbID)
+		translationResp, err := client.GetMovieTranslation(ctx, tmdbID)
 		if err != nil {
 			return nil, fmt.Errorf("获取电影「%d」的翻译失败: %v", tmdbID, err)
 		}
@@ -68,11 +69,11 @@ func getNames(tmdbID int, mtype meta.MediaType) ([]string, error) {
 			names = append(names, translation.Data.Title)
 		}
 	case meta.MediaTypeTV:
-		titleResp, err := client.GetTVSerieAlternativeTitle(tmdbID, nil)
+		titleResp, err := client.GetTVSerieAlternativeTitle(ctx, tmdbID, nil)
 		if err != nil {
 			return nil, fmt.Errorf("获取电视剧「%d」的其他标题失败: %v", tmdbID, err)
 		}
-		translationResp, err := client.GetTVSerieTranslation(tmdbID)
+		translationResp, err := client.GetTVSerieTranslation(ctx, tmdbID)
 		if err != nil {
 			return nil, fmt.Errorf("获取电视剧「%d」的翻译失败: %v", tmdbID, err)
 		}
@@ -88,6 +89,18 @@ func getNames(tmdbID int, mtype meta.MediaType) ([]string, error) {
 	return names, nil
 }
 
+// detailTitles 详情中的标题和原始标题
+func detailTitles(info *schemas.MediaInfo) []string {
+	switch {
+	case info.MediaType == meta.MediaTypeMovie && info.TMDBInfo.MovieInfo != nil:
+		return []string{info.TMDBInfo.MovieInfo.Title, info.TMDBInfo.MovieInfo.OriginalTitle}
+	case info.MediaType == meta.MediaTypeTV && info.TMDBInfo.TVInfo.SerieInfo != nil:
+		return []string{info.TMDBInfo.TVInfo.SerieInfo.Name, info.TMDBInfo.TVInfo.SerieInfo.OriginalName}
+	default:
+		return nil
+	}
+}
+
 func parseType(s string) meta.MediaType {
 	switch string(s) {
 	case "movie":
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/pkg/cache"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewCache 根据缓存配置创建缓存后端
+// namespace 用于区分不同客户端的持久化缓存
+func NewCache(c config.CacheConfig, namespace string) (cache.Cache, error) {
+	switch strings.ToLower(c.Type) {
+	case "", "memory":
+		return cache.NewMemoryCache(10 * time.Minute)
+
+	case "sqlite":
+		if db == nil {
+			return nil, fmt.Errorf("数据库未初始化")
+		}
+		return cache.NewSQLiteCache(db, namespace)
+
+	default:
+		return nil, fmt.Errorf("不支持的缓存类型: %s", c.Type)
+	}
+}
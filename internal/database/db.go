@@ -34,5 +34,6 @@ func Init() error {
 func AutoMigrate() error {
 	return db.AutoMigrate(
 		&models.MediaTransferHistory{},
+		&models.SeriesMapping{},
 	)
 }
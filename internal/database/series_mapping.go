@@ -0,0 +1,71 @@
+package database
+
+import (
+	"MediaTools/internal/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrNotInitialized = errors.New("数据库未初始化")
+
+// UpdateSeriesMapping 更新剧集映射
+// 如果 TMDB ID 不存在则创建，存在则更新
+func UpdateSeriesMapping(ctx context.Context, mapping *models.SeriesMapping) error {
+	if db == nil {
+		return ErrNotInitialized
+	}
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tmdb_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ordering", "updated_at", "deleted_at"}),
+	}).Create(mapping)
+	if result.Error != nil {
+		return fmt.Errorf("更新剧集映射失败: %w", result.Error)
+	}
+	return nil
+}
+
+// QuerySeriesMappingByTMDBID 根据 TMDB ID 查询剧集映射，未找到时返回 gorm.ErrRecordNotFound
+func QuerySeriesMappingByTMDBID(ctx context.Context, tmdbID int) (*models.SeriesMapping, error) {
+	if db == nil {
+		return nil, ErrNotInitialized
+	}
+	mappings, err := gorm.G[models.SeriesMapping](db).Where("tmdb_id = ?", tmdbID).Limit(1).Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询剧集映射失败: %w", err)
+	}
+	if len(mappings) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &mappings[0], nil
+}
+
+// QuerySeriesMappings 查询所有剧集映射
+func QuerySeriesMappings(ctx context.Context) ([]models.SeriesMapping, error) {
+	if db == nil {
+		return nil, ErrNotInitialized
+	}
+	mappings, err := gorm.G[models.SeriesMapping](db).Order("tmdb_id").Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询剧集映射失败: %w", err)
+	}
+	return mappings, nil
+}
+
+// DeleteSeriesMapping 删除剧集映射
+func DeleteSeriesMapping(ctx context.Context, tmdbID int) error {
+	if db == nil {
+		return ErrNotInitialized
+	}
+	rowsAffected, err := gorm.G[models.SeriesMapping](db).Where("tmdb_id = ?", tmdbID).Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("删除剧集映射失败: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("未找到剧集映射: %d", tmdbID)
+	}
+	return nil
+}
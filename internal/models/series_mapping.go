@@ -0,0 +1,8 @@
+package models
+
+// 剧集映射，按 TMDB ID 记录每部剧集的识别设置
+type SeriesMapping struct {
+	BaseModel
+	TMDBID   int    `json:"tmdb_id" gorm:"uniqueIndex"` // 电视剧 TMDB ID
+	Ordering string `json:"ordering"`                   // 剧集编号方式：aired、absolute 或 TMDB 剧集组 ID
+}
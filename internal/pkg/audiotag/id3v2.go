@@ -0,0 +1,302 @@
+package audiotag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+const maxID3v2Size = 32 << 20 // ID3v2 标签的最大读取长度
+
+// ID3v2.2 使用三字符帧 ID，映射为 ID3v2.3/2.4 的帧 ID
+var id3v22Frames = map[string]string{
+	"TT2": "TIT2", "TP1": "TPE1", "TP2": "TPE2", "TAL": "TALB", "TRK": "TRCK",
+	"TPA": "TPOS", "TYE": "TYER", "TCO": "TCON", "PIC": "APIC",
+}
+
+// readID3v2 读取 ID3v2.2/2.3/2.4 标签，读取后 r 位于标签之后
+// https://id3.org/id3v2.4.0-structure
+func readID3v2(r *mediaio.Reader) (*Tags, error) {
+	header, err := r.ReadFull(10)
+	if err != nil {
+		return nil, fmt.Errorf("读取 ID3v2 标签头失败: %w", err)
+	}
+	version, flags := header[3], header[5]
+	if version < 2 || version > 4 {
+		return nil, fmt.Errorf("不支持的 ID3v2 版本: 2.%d", version)
+	}
+	size := syncsafe(header[6:10])
+	if size > maxID3v2Size {
+		return nil, fmt.Errorf("ID3v2 标签过大: %d", size)
+	}
+	data, err := r.ReadFull(int64(size))
+	if err != nil {
+		return nil, fmt.Errorf("读取 ID3v2 标签失败: %w", err)
+	}
+	if flags&0x10 != 0 { // 带有尾部
+		if err := r.Skip(10); err != nil {
+			return nil, err
+		}
+	}
+	if version < 4 && flags&0x80 != 0 { // 整个标签使用了非同步化
+		data = unsynchronise(data)
+	}
+	if flags&0x40 != 0 && version >= 3 { // 跳过扩展头
+		if len(data) < 4 {
+			return nil, fmt.Errorf("无效的 ID3v2 扩展头")
+		}
+		extSize := int(binary.BigEndian.Uint32(data[:4]))
+		if version == 4 {
+			extSize = int(syncsafe(data[:4]))
+		} else {
+			extSize += 4
+		}
+		if extSize > len(data) {
+			return nil, fmt.Errorf("无效的 ID3v2 扩展头")
+		}
+		data = data[extSize:]
+	}
+
+	tags := &Tags{Format: fmt.Sprintf("id3v2.%d", version)}
+	var year, date string
+	for len(data) > 0 {
+		var (
+			id         string
+			frameSize  int
+			frameFlags uint16
+			headerLen  int
+		)
+		if version == 2 {
+			if len(data) < 6 {
+				break
+			}
+			id = id3v22Frames[string(data[:3])]
+			frameSize = int(data[3])<<16 | int(data[4])<<8 | int(data[5])
+			headerLen = 6
+		} else {
+			if len(data) < 10 {
+				break
+			}
+			id = string(data[:4])
+			if version == 4 {
+				frameSize = int(syncsafe(data[4:8]))
+			} else {
+				frameSize = int(binary.BigEndian.Uint32(data[4:8]))
+			}
+			frameFlags = binary.BigEndian.Uint16(data[8:10])
+			headerLen = 10
+		}
+		if data[0] == 0 || frameSize <= 0 || headerLen+frameSize > len(data) { // 填充或格式错误
+			break
+		}
+		body := data[headerLen : headerLen+frameSize]
+		data = data[headerLen+frameSize:]
+
+		if version == 4 {
+			if frameFlags&0x000C != 0 { // 压缩或加密的帧
+				continue
+			}
+			if frameFlags&0x0001 != 0 { // 带有数据长度指示
+				if len(body) < 4 {
+					continue
+				}
+				body = body[4:]
+			}
+			if frameFlags&0x0002 != 0 { // 帧使用了非同步化
+				body = unsynchronise(body)
+			}
+		} else if version == 3 && frameFlags&0x00C0 != 0 { // 压缩或加密的帧
+			continue
+		}
+
+		switch id {
+		case "TIT2":
+			tags.Title = id3Text(body)
+		case "TPE1":
+			tags.Artist = id3Text(body)
+		case "TPE2":
+			tags.AlbumArtist = id3Text(body)
+		case "TALB":
+			tags.Album = id3Text(body)
+		case "TRCK":
+			tags.Track, tags.TrackTotal = parseNumber(id3Text(body))
+		case "TPOS":
+			tags.Disc, tags.DiscTotal = parseNumber(id3Text(body))
+		case "TYER":
+			year = id3Text(body)
+		case "TDRC", "TDOR":
+			if date == "" {
+				date = id3Text(body)
+			}
+		case "TCON":
+			tags.Genre = id3Genre(id3Text(body))
+		case "APIC":
+			if version == 2 {
+				tags.setPicture(id3v22Picture(body))
+			} else {
+				tags.setPicture(id3Picture(body))
+			}
+		}
+	}
+	tags.Year = parseYear(year)
+	if tags.Year == 0 {
+		tags.Year = parseYear(date)
+	}
+	return tags, nil
+}
+
+// syncsafe 解析同步安全整数（每字节只使用低 7 位）
+func syncsafe(b []byte) uint32 {
+	var n uint32
+	for _, c := range b {
+		n = n<<7 | uint32(c&0x7F)
+	}
+	return n
+}
+
+// unsynchronise 还原非同步化的数据（0xFF 0x00 -> 0xFF）
+func unsynchronise(data []byte) []byte {
+	if !bytes.Contains(data, []byte{0xFF, 0x00}) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		out = append(out, data[i])
+		if data[i] == 0xFF && i+1 < len(data) && data[i+1] == 0x00 {
+			i++
+		}
+	}
+	return out
+}
+
+// id3Text 解析文本帧，多个值时返回第一个
+func id3Text(body []byte) string {
+	if len(body) < 1 {
+		return ""
+	}
+	text, _ := id3String(body[0], body[1:])
+	return strings.TrimSpace(text)
+}
+
+// id3String 按编码解码以 NUL 结尾的字符串，返回字符串和剩余数据
+// 0: ISO-8859-1，1: 带 BOM 的 UTF-16，2: UTF-16BE，3: UTF-8
+func id3String(encoding byte, data []byte) (string, []byte) {
+	switch encoding {
+	case 1, 2:
+		end := len(data)
+		rest := []byte(nil)
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				end, rest = i, data[i+2:]
+				break
+			}
+		}
+		return decodeUTF16(data[:end], encoding == 2), rest
+	default:
+		end := bytes.IndexByte(data, 0)
+		rest := []byte(nil)
+		if end < 0 {
+			end = len(data)
+		} else {
+			rest = data[end+1:]
+		}
+		if encoding == 3 {
+			return string(data[:end]), rest
+		}
+		return decodeLatin1(data[:end]), rest
+	}
+}
+
+func decodeUTF16(b []byte, bigEndian bool) string {
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0xFF && b[1] == 0xFE:
+			bigEndian, b = false, b[2:]
+		case b[0] == 0xFE && b[1] == 0xFF:
+			bigEndian, b = true, b[2:]
+		}
+	}
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		if bigEndian {
+			u = append(u, binary.BigEndian.Uint16(b[i:]))
+		} else {
+			u = append(u, binary.LittleEndian.Uint16(b[i:]))
+		}
+	}
+	return string(utf16.Decode(u))
+}
+
+func decodeLatin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+var id3GenreRefRe = regexp.MustCompile(`^\((\d+)\)`)
+
+// id3Genre 处理 ID3v1 流派引用，如「(17)Rock」返回 Rock，「(17)」返回对应的流派名称
+func id3Genre(s string) string {
+	m := id3GenreRefRe.FindStringSubmatch(s)
+	if m == nil {
+		if name, ok := id3v1GenreName(s); ok {
+			return name
+		}
+		return s
+	}
+	if rest := strings.TrimSpace(s[len(m[0]):]); rest != "" {
+		return rest
+	}
+	if name, ok := id3v1GenreName(m[1]); ok {
+		return name
+	}
+	return s
+}
+
+// id3Picture 解析 APIC 帧：编码、MIME 类型、图片类型、描述、图片数据
+func id3Picture(body []byte) *Picture {
+	if len(body) < 4 {
+		return nil
+	}
+	encoding := body[0]
+	end := bytes.IndexByte(body[1:], 0)
+	if end < 0 {
+		return nil
+	}
+	mime := string(body[1 : 1+end])
+	rest := body[2+end:]
+	if len(rest) < 1 {
+		return nil
+	}
+	picType := int(rest[0])
+	_, data := id3String(encoding, rest[1:])
+	return &Picture{MIMEType: normalizeMIME(mime), Type: picType, Data: data}
+}
+
+// id3v22Picture 解析 ID3v2.2 PIC 帧：编码、三字符图片格式、图片类型、描述、图片数据
+func id3v22Picture(body []byte) *Picture {
+	if len(body) < 6 {
+		return nil
+	}
+	encoding, format, picType := body[0], string(body[1:4]), int(body[4])
+	_, data := id3String(encoding, body[5:])
+	return &Picture{MIMEType: normalizeMIME(format), Type: picType, Data: data}
+}
+
+func normalizeMIME(mime string) string {
+	mime = strings.ToLower(strings.TrimSpace(mime))
+	switch mime {
+	case "jpg", "jpeg", "image/jpg", "":
+		return "image/jpeg"
+	case "png":
+		return "image/png"
+	}
+	return mime
+}
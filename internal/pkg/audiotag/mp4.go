@@ -0,0 +1,92 @@
+package audiotag
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+// readMP4 读取 moov/udta/meta/ilst 中的 iTunes 风格元数据
+func readMP4(r *mediaio.Reader) (*Tags, error) {
+	data, err := mediaio.ReadMoov(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseMoov(data), nil
+}
+
+func parseMoov(data []byte) *Tags {
+	tags := &Tags{Format: "mp4"}
+	mediaio.ForEachBox(data, func(boxType string, udta []byte) {
+		if boxType != "udta" {
+			return
+		}
+		mediaio.ForEachBox(udta, func(boxType string, meta []byte) {
+			if boxType != "meta" {
+				return
+			}
+			// meta 通常是 full box（4 字节版本和标志），部分 QuickTime 文件没有
+			if len(meta) >= 8 && string(meta[4:8]) != "hdlr" {
+				meta = meta[4:]
+			}
+			mediaio.ForEachBox(meta, func(boxType string, ilst []byte) {
+				if boxType == "ilst" {
+					parseIlst(tags, ilst)
+				}
+			})
+		})
+	})
+	return tags
+}
+
+// parseIlst 解析 ilst 中的元数据项，每项包含一个 data box：类型（4 字节）、区域（4 字节）、值
+func parseIlst(tags *Tags, ilst []byte) {
+	mediaio.ForEachBox(ilst, func(key string, item []byte) {
+		mediaio.ForEachBox(item, func(boxType string, data []byte) {
+			if boxType != "data" || len(data) < 8 {
+				return
+			}
+			dataType := binary.BigEndian.Uint32(data[:4]) & 0x00FFFFFF
+			value := data[8:]
+			text := strings.TrimSpace(string(value))
+			switch key {
+			case "\xa9nam":
+				tags.Title = firstValue(tags.Title, text)
+			case "\xa9ART":
+				tags.Artist = firstValue(tags.Artist, text)
+			case "aART":
+				tags.AlbumArtist = firstValue(tags.AlbumArtist, text)
+			case "\xa9alb":
+				tags.Album = firstValue(tags.Album, text)
+			case "\xa9day":
+				tags.Year = parseYear(text)
+			case "\xa9gen":
+				tags.Genre = firstValue(tags.Genre, text)
+			case "gnre": // ID3v1 流派编号 + 1
+				if len(value) >= 2 && tags.Genre == "" {
+					if name, ok := id3v1GenreName(strconv.Itoa(int(binary.BigEndian.Uint16(value)) - 1)); ok {
+						tags.Genre = name
+					}
+				}
+			case "trkn":
+				if len(value) >= 6 {
+					tags.Track = int(binary.BigEndian.Uint16(value[2:4]))
+					tags.TrackTotal = int(binary.BigEndian.Uint16(value[4:6]))
+				}
+			case "disk":
+				if len(value) >= 6 {
+					tags.Disc = int(binary.BigEndian.Uint16(value[2:4]))
+					tags.DiscTotal = int(binary.BigEndian.Uint16(value[4:6]))
+				}
+			case "covr":
+				mime := "image/jpeg"
+				if dataType == 14 {
+					mime = "image/png"
+				}
+				tags.setPicture(&Picture{MIMEType: mime, Type: pictureFrontCover, Data: value})
+			}
+		})
+	})
+}
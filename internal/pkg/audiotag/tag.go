@@ -0,0 +1,148 @@
+package audiotag
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+const maxDiscard = 64 << 20 // 不可 Seek 时最多丢弃的字节数
+
+var (
+	ErrUnsupported = errors.New("不支持的音频格式")
+	ErrTooFar      = mediaio.ErrTooFar
+)
+
+// Tags 音频文件标签
+type Tags struct {
+	Format      string   // 标签格式（id3v2.3、id3v2.4、vorbis、mp4 等）
+	Title       string   // 曲目标题
+	Artist      string   // 艺术家
+	AlbumArtist string   // 专辑艺术家
+	Album       string   // 专辑
+	Track       int      // 音轨号，未知时为 0
+	TrackTotal  int      // 音轨总数
+	Disc        int      // 碟号，未知时为 0
+	DiscTotal   int      // 碟片总数
+	Year        int      // 年份
+	Genre       string   // 流派
+	Picture     *Picture // 内嵌封面，优先使用封面（Front Cover）类型的图片
+}
+
+// Picture 内嵌图片
+type Picture struct {
+	MIMEType string // MIME 类型（image/jpeg、image/png）
+	Type     int    // 图片类型（ID3v2 APIC/FLAC PICTURE 定义，3 为封面）
+	Data     []byte // 图片数据
+}
+
+// 封面图片类型
+const pictureFrontCover = 3
+
+// Ext 按 MIME 类型返回图片扩展名
+func (p *Picture) Ext() string {
+	switch strings.ToLower(p.MIMEType) {
+	case "image/png", "png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".jpg"
+	}
+}
+
+// setPicture 设置内嵌封面，已有封面类型的图片时不再覆盖
+func (t *Tags) setPicture(p *Picture) {
+	if p == nil || len(p.Data) == 0 {
+		return
+	}
+	if t.Picture == nil || (t.Picture.Type != pictureFrontCover && p.Type == pictureFrontCover) {
+		t.Picture = p
+	}
+}
+
+// Read 读取音频文件开头的标签，支持 ID3v2（MP3 等）、FLAC、Ogg Vorbis/Opus 和 MP4（M4A）
+// r 实现 io.Seeker 时直接跳过音频数据，否则顺序读取（最多跳过 maxDiscard 字节）
+func Read(r io.Reader) (*Tags, error) {
+	tr := mediaio.NewReader(r, maxDiscard)
+
+	head, err := tr.Peek(8)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件头失败: %w", err)
+	}
+	switch {
+	case string(head[:3]) == "ID3":
+		tags, err := readID3v2(tr)
+		if err != nil {
+			return nil, err
+		}
+		// 部分 FLAC 文件在开头带有 ID3v2 标签，以 Vorbis 注释为准，缺少的字段使用 ID3v2 补全
+		if head, err := tr.Peek(4); err == nil && string(head) == "fLaC" {
+			if flac, err := readFLAC(tr); err == nil {
+				flac.fill(tags)
+				return flac, nil
+			}
+		}
+		return tags, nil
+	case string(head[:4]) == "fLaC":
+		return readFLAC(tr)
+	case string(head[:4]) == "OggS":
+		return readOgg(tr)
+	case mediaio.IsMP4Box(string(head[4:8])):
+		return readMP4(tr)
+	default:
+		return nil, ErrUnsupported
+	}
+}
+
+// fill 使用 other 补全为空的字段
+func (t *Tags) fill(other *Tags) {
+	fillString := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fillInt := func(dst *int, src int) {
+		if *dst == 0 {
+			*dst = src
+		}
+	}
+	fillString(&t.Title, other.Title)
+	fillString(&t.Artist, other.Artist)
+	fillString(&t.AlbumArtist, other.AlbumArtist)
+	fillString(&t.Album, other.Album)
+	fillString(&t.Genre, other.Genre)
+	fillInt(&t.Track, other.Track)
+	fillInt(&t.TrackTotal, other.TrackTotal)
+	fillInt(&t.Disc, other.Disc)
+	fillInt(&t.DiscTotal, other.DiscTotal)
+	fillInt(&t.Year, other.Year)
+	t.setPicture(other.Picture)
+}
+
+// parseNumber 解析「3」或「3/12」形式的编号
+func parseNumber(s string) (int, int) {
+	num, total, _ := strings.Cut(strings.TrimSpace(s), "/")
+	n, _ := strconv.Atoi(strings.TrimSpace(num))
+	t, _ := strconv.Atoi(strings.TrimSpace(total))
+	return n, t
+}
+
+// parseYear 解析日期字符串开头的年份（2006、2006-05-01、2006-05-01T00:00:00Z 等）
+func parseYear(s string) int {
+	s = strings.TrimSpace(s)
+	if len(s) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(s[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
@@ -0,0 +1,287 @@
+package audiotag_test
+
+import (
+	"MediaTools/internal/pkg/audiotag"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"runtime"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+)
+
+var jpeg = []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F'}
+
+func syncsafe(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+}
+
+func id3Frame(version byte, id string, body []byte) []byte {
+	frame := []byte(id)
+	if version == 4 {
+		frame = append(frame, syncsafe(len(body))...)
+	} else {
+		frame = binary.BigEndian.AppendUint32(frame, uint32(len(body)))
+	}
+	return append(append(frame, 0, 0), body...)
+}
+
+func id3Tag(version byte, frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	body = append(body, make([]byte, 16)...) // 填充
+	tag := append([]byte{'I', 'D', '3', version, 0, 0}, syncsafe(len(body))...)
+	return append(tag, body...)
+}
+
+func utf16Text(s string) []byte {
+	b := []byte{1, 0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+	return b
+}
+
+func utf8Text(s string) []byte {
+	return append([]byte{3}, s...)
+}
+
+func vorbisComment(comments ...string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, 9)
+	b = append(b, "reference"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(comments)))
+	for _, c := range comments {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(c)))
+		b = append(b, c...)
+	}
+	return b
+}
+
+func flacPicture(picType uint32, mime string, data []byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, picType)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(mime)))
+	b = append(b, mime...)
+	b = binary.BigEndian.AppendUint32(b, 0) // 描述
+	b = append(b, make([]byte, 16)...)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+func flacBlock(blockType byte, last bool, data []byte) []byte {
+	if last {
+		blockType |= 0x80
+	}
+	return append([]byte{blockType, byte(len(data) >> 16), byte(len(data) >> 8), byte(len(data))}, data...)
+}
+
+func oggPage(serial uint32, packet []byte) []byte {
+	var segments []byte
+	for n := len(packet); ; n -= 255 {
+		if n < 255 {
+			segments = append(segments, byte(n))
+			break
+		}
+		segments = append(segments, 255)
+	}
+	page := []byte("OggS")
+	page = append(page, 0, 0)
+	page = append(page, make([]byte, 8)...) // granule position
+	page = binary.LittleEndian.AppendUint32(page, serial)
+	page = append(page, make([]byte, 8)...) // 页序号、校验和
+	page = append(page, byte(len(segments)))
+	page = append(page, segments...)
+	return append(page, packet...)
+}
+
+func box(boxType string, children ...[]byte) []byte {
+	body := bytes.Join(children, nil)
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(body)+8))
+	return append(append(buf, boxType...), body...)
+}
+
+func mp4Item(key string, dataType uint32, value []byte) []byte {
+	data := binary.BigEndian.AppendUint32(nil, dataType)
+	data = append(data, 0, 0, 0, 0)
+	return box(key, box("data", append(data, value...)))
+}
+
+// onlyReader 隐藏 io.Seeker，测试顺序读取
+type onlyReader struct{ io.Reader }
+
+func TestReadID3v23(t *testing.T) {
+	apic := append([]byte{0}, "image/jpeg\x00"...)
+	apic = append(apic, 3) // 封面
+	apic = append(apic, 0) // 空描述
+	apic = append(apic, jpeg...)
+	data := append(id3Tag(3,
+		id3Frame(3, "TIT2", utf16Text("夜曲")),
+		id3Frame(3, "TPE1", utf16Text("周杰伦")),
+		id3Frame(3, "TALB", append([]byte{0}, "November's Chopin"...)),
+		id3Frame(3, "TRCK", []byte("\x001/12")),
+		id3Frame(3, "TPOS", []byte("\x001")),
+		id3Frame(3, "TYER", []byte("\x002005")),
+		id3Frame(3, "TCON", []byte("\x00(13)")),
+		id3Frame(3, "APIC", apic),
+	), 0xFF, 0xFB, 0x90, 0x00)
+
+	tags, err := audiotag.Read(onlyReader{bytes.NewReader(data)})
+	require.NoError(t, err)
+	require.Equal(t, "id3v2.3", tags.Format)
+	require.Equal(t, "夜曲", tags.Title)
+	require.Equal(t, "周杰伦", tags.Artist)
+	require.Empty(t, tags.AlbumArtist)
+	require.Equal(t, "November's Chopin", tags.Album)
+	require.Equal(t, 1, tags.Track)
+	require.Equal(t, 12, tags.TrackTotal)
+	require.Equal(t, 1, tags.Disc)
+	require.Equal(t, 2005, tags.Year)
+	require.Equal(t, "Pop", tags.Genre)
+	require.NotNil(t, tags.Picture)
+	require.Equal(t, "image/jpeg", tags.Picture.MIMEType)
+	require.Equal(t, jpeg, tags.Picture.Data)
+	require.Equal(t, ".jpg", tags.Picture.Ext())
+}
+
+func TestReadID3v24(t *testing.T) {
+	data := id3Tag(4,
+		id3Frame(4, "TIT2", utf8Text("Bohemian Rhapsody")),
+		id3Frame(4, "TPE1", utf8Text("Queen")),
+		id3Frame(4, "TPE2", utf8Text("Queen")),
+		id3Frame(4, "TALB", utf8Text("A Night at the Opera")),
+		id3Frame(4, "TRCK", utf8Text("11")),
+		id3Frame(4, "TPOS", utf8Text("1/1")),
+		id3Frame(4, "TDRC", utf8Text("1975-11-21")),
+	)
+	tags, err := audiotag.Read(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "id3v2.4", tags.Format)
+	require.Equal(t, "Bohemian Rhapsody", tags.Title)
+	require.Equal(t, "Queen", tags.AlbumArtist)
+	require.Equal(t, 11, tags.Track)
+	require.Equal(t, 1, tags.DiscTotal)
+	require.Equal(t, 1975, tags.Year)
+	require.Nil(t, tags.Picture)
+}
+
+func TestReadFLAC(t *testing.T) {
+	streamInfo := make([]byte, 34)
+	data := bytes.Join([][]byte{
+		[]byte("fLaC"),
+		flacBlock(0, false, streamInfo),
+		flacBlock(4, false, vorbisComment(
+			"TITLE=Time",
+			"ARTIST=Pink Floyd",
+			"ALBUMARTIST=Pink Floyd",
+			"ALBUM=The Dark Side of the Moon",
+			"TRACKNUMBER=4",
+			"TRACKTOTAL=10",
+			"DISCNUMBER=1",
+			"DATE=1973-03-01",
+			"GENRE=Progressive Rock",
+			"GENRE=Rock",
+		)),
+		flacBlock(6, false, flacPicture(4, "image/png", []byte("back"))),
+		flacBlock(6, true, flacPicture(3, "image/jpeg", jpeg)),
+		{0xFF, 0xF8},
+	}, nil)
+
+	tags, err := audiotag.Read(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "vorbis", tags.Format)
+	require.Equal(t, "Time", tags.Title)
+	require.Equal(t, "Pink Floyd", tags.AlbumArtist)
+	require.Equal(t, "The Dark Side of the Moon", tags.Album)
+	require.Equal(t, 4, tags.Track)
+	require.Equal(t, 10, tags.TrackTotal)
+	require.Equal(t, 1, tags.Disc)
+	require.Equal(t, 1973, tags.Year)
+	require.Equal(t, "Progressive Rock", tags.Genre)
+	require.Equal(t, jpeg, tags.Picture.Data) // 封面优先于其他图片
+
+	// 带 ID3v2 标签的 FLAC 以 Vorbis 注释为准
+	withID3 := append(id3Tag(3, id3Frame(3, "TIT2", []byte("\x00Wrong")), id3Frame(3, "TCON", []byte("\x00Rock"))), data...)
+	tags, err = audiotag.Read(bytes.NewReader(withID3))
+	require.NoError(t, err)
+	require.Equal(t, "Time", tags.Title)
+}
+
+func TestReadOgg(t *testing.T) {
+	picture := base64.StdEncoding.EncodeToString(flacPicture(3, "image/jpeg", jpeg))
+	head := append([]byte("OpusHead"), make([]byte, 11)...)
+	comment := append([]byte("OpusTags"), vorbisComment(
+		"title=Clair de Lune",
+		"artist=Claude Debussy",
+		"album=Suite bergamasque",
+		"tracknumber=3/4",
+		"date=1905",
+		"METADATA_BLOCK_PICTURE="+picture,
+	)...)
+	data := append(oggPage(1, head), oggPage(1, comment)...)
+
+	tags, err := audiotag.Read(onlyReader{bytes.NewReader(data)})
+	require.NoError(t, err)
+	require.Equal(t, "Clair de Lune", tags.Title)
+	require.Equal(t, "Claude Debussy", tags.Artist)
+	require.Equal(t, 3, tags.Track)
+	require.Equal(t, 4, tags.TrackTotal)
+	require.Equal(t, 1905, tags.Year)
+	require.Equal(t, jpeg, tags.Picture.Data)
+}
+
+func TestReadMP4(t *testing.T) {
+	trkn := []byte{0, 0, 0, 5, 0, 12, 0, 0}
+	disk := []byte{0, 0, 0, 2, 0, 2}
+	ilst := box("ilst",
+		mp4Item("\xa9nam", 1, []byte("One More Time")),
+		mp4Item("\xa9ART", 1, []byte("Daft Punk")),
+		mp4Item("aART", 1, []byte("Daft Punk")),
+		mp4Item("\xa9alb", 1, []byte("Discovery")),
+		mp4Item("\xa9day", 1, []byte("2001-03-12T08:00:00Z")),
+		mp4Item("gnre", 0, []byte{0, 4}),
+		mp4Item("trkn", 0, trkn),
+		mp4Item("disk", 0, disk),
+		mp4Item("covr", 14, []byte("png-data")),
+	)
+	meta := box("meta", []byte{0, 0, 0, 0}, box("hdlr", make([]byte, 24)), ilst)
+	data := bytes.Join([][]byte{
+		box("ftyp", []byte("M4A \x00\x00\x00\x00")),
+		box("mdat", make([]byte, 1024)),
+		box("moov", box("mvhd", make([]byte, 100)), box("udta", meta)),
+	}, nil)
+
+	tags, err := audiotag.Read(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "mp4", tags.Format)
+	require.Equal(t, "One More Time", tags.Title)
+	require.Equal(t, "Daft Punk", tags.AlbumArtist)
+	require.Equal(t, "Discovery", tags.Album)
+	require.Equal(t, 2001, tags.Year)
+	require.Equal(t, "Dance", tags.Genre)
+	require.Equal(t, 5, tags.Track)
+	require.Equal(t, 12, tags.TrackTotal)
+	require.Equal(t, 2, tags.Disc)
+	require.Equal(t, ".png", tags.Picture.Ext())
+}
+
+func TestReadMP4MoovToEOF(t *testing.T) {
+	ilst := box("ilst", mp4Item("\xa9nam", 1, []byte("One More Time")))
+	moov := box("moov", box("udta", box("meta", []byte{0, 0, 0, 0}, ilst)))
+	binary.BigEndian.PutUint32(moov, 0) // 长度为 0 表示延伸到文件末尾
+	data := append(box("ftyp", []byte("M4A \x00\x00\x00\x00")), moov...)
+
+	// 只按实际数据分配内存，不按 moov 的最大读取长度预先分配
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	tags, err := audiotag.Read(bytes.NewReader(data))
+	runtime.ReadMemStats(&after)
+	require.NoError(t, err)
+	require.Equal(t, "One More Time", tags.Title)
+	require.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+}
+
+func TestReadUnsupported(t *testing.T) {
+	_, err := audiotag.Read(bytes.NewReader([]byte("RIFF\x00\x00\x00\x00WAVE")))
+	require.ErrorIs(t, err, audiotag.ErrUnsupported)
+}
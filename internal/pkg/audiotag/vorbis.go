@@ -0,0 +1,261 @@
+package audiotag
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+const maxMetadataBlockSize = 16 << 20 // FLAC 元数据块、Ogg 注释包的最大读取长度
+
+// FLAC 元数据块类型
+const (
+	flacBlockVorbisComment = 4
+	flacBlockPicture       = 6
+)
+
+// readFLAC 读取 FLAC 元数据块中的 Vorbis 注释和图片
+// https://xiph.org/flac/format.html#metadata_block
+func readFLAC(r *mediaio.Reader) (*Tags, error) {
+	if err := r.Skip(4); err != nil { // fLaC
+		return nil, err
+	}
+	tags := &Tags{Format: "vorbis"}
+	for {
+		header, err := r.ReadFull(4)
+		if err != nil {
+			return nil, fmt.Errorf("读取 FLAC 元数据块失败: %w", err)
+		}
+		last := header[0]&0x80 != 0
+		blockType := header[0] & 0x7F
+		size := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+
+		switch blockType {
+		case flacBlockVorbisComment, flacBlockPicture:
+			if size > maxMetadataBlockSize {
+				return nil, fmt.Errorf("FLAC 元数据块过大: %d", size)
+			}
+			data, err := r.ReadFull(size)
+			if err != nil {
+				return nil, fmt.Errorf("读取 FLAC 元数据块失败: %w", err)
+			}
+			if blockType == flacBlockVorbisComment {
+				parseVorbisComment(tags, data)
+			} else {
+				tags.setPicture(parseFLACPicture(data))
+			}
+		default:
+			if err := r.Skip(size); err != nil {
+				return nil, err
+			}
+		}
+		if last {
+			return tags, nil
+		}
+	}
+}
+
+// parseVorbisComment 解析 Vorbis 注释：厂商字符串和「字段=值」列表（长度均为小端序）
+// https://xiph.org/vorbis/doc/v-comment.html
+func parseVorbisComment(tags *Tags, data []byte) {
+	readString := func() (string, bool) {
+		if len(data) < 4 {
+			return "", false
+		}
+		n := binary.LittleEndian.Uint32(data[:4])
+		if uint64(n) > uint64(len(data)-4) {
+			return "", false
+		}
+		s := string(data[4 : 4+n])
+		data = data[4+n:]
+		return s, true
+	}
+	if _, ok := readString(); !ok { // 厂商字符串
+		return
+	}
+	if len(data) < 4 {
+		return
+	}
+	count := binary.LittleEndian.Uint32(data[:4])
+	data = data[4:]
+
+	var trackTotal, discTotal, date, year string
+	for range count {
+		comment, ok := readString()
+		if !ok {
+			break
+		}
+		key, value, ok := strings.Cut(comment, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToUpper(key) {
+		case "TITLE":
+			tags.Title = firstValue(tags.Title, value)
+		case "ARTIST":
+			tags.Artist = firstValue(tags.Artist, value)
+		case "ALBUMARTIST", "ALBUM ARTIST", "ALBUM_ARTIST":
+			tags.AlbumArtist = firstValue(tags.AlbumArtist, value)
+		case "ALBUM":
+			tags.Album = firstValue(tags.Album, value)
+		case "TRACKNUMBER":
+			tags.Track, tags.TrackTotal = parseNumber(value)
+		case "TRACKTOTAL", "TOTALTRACKS":
+			trackTotal = value
+		case "DISCNUMBER":
+			tags.Disc, tags.DiscTotal = parseNumber(value)
+		case "DISCTOTAL", "TOTALDISCS":
+			discTotal = value
+		case "DATE":
+			date = firstValue(date, value)
+		case "YEAR":
+			year = firstValue(year, value)
+		case "GENRE":
+			tags.Genre = firstValue(tags.Genre, value)
+		case "METADATA_BLOCK_PICTURE":
+			if b, err := base64.StdEncoding.DecodeString(value); err == nil {
+				tags.setPicture(parseFLACPicture(b))
+			}
+		}
+	}
+	if tags.TrackTotal == 0 {
+		tags.TrackTotal, _ = parseNumber(trackTotal)
+	}
+	if tags.DiscTotal == 0 {
+		tags.DiscTotal, _ = parseNumber(discTotal)
+	}
+	if tags.Year = parseYear(date); tags.Year == 0 {
+		tags.Year = parseYear(year)
+	}
+}
+
+// firstValue 同一字段出现多次时使用第一个值
+func firstValue(current, value string) string {
+	if current != "" {
+		return current
+	}
+	return value
+}
+
+// parseFLACPicture 解析 FLAC PICTURE 块（大端序）：图片类型、MIME 类型、描述、宽高等、图片数据
+// https://xiph.org/flac/format.html#metadata_block_picture
+func parseFLACPicture(data []byte) *Picture {
+	readUint32 := func() (uint32, bool) {
+		if len(data) < 4 {
+			return 0, false
+		}
+		n := binary.BigEndian.Uint32(data[:4])
+		data = data[4:]
+		return n, true
+	}
+	readBytes := func() ([]byte, bool) {
+		n, ok := readUint32()
+		if !ok || uint64(n) > uint64(len(data)) {
+			return nil, false
+		}
+		b := data[:n]
+		data = data[n:]
+		return b, true
+	}
+
+	picType, ok := readUint32()
+	if !ok {
+		return nil
+	}
+	mime, ok := readBytes()
+	if !ok {
+		return nil
+	}
+	if _, ok := readBytes(); !ok { // 描述
+		return nil
+	}
+	if len(data) < 16 { // 宽、高、色深、索引色数
+		return nil
+	}
+	data = data[16:]
+	pic, ok := readBytes()
+	if !ok {
+		return nil
+	}
+	return &Picture{MIMEType: normalizeMIME(string(mime)), Type: int(picType), Data: pic}
+}
+
+// Ogg 注释包的前缀
+var oggCommentPrefixes = []string{
+	"\x03vorbis", // Vorbis
+	"OpusTags",   // Opus
+}
+
+// readOgg 读取 Ogg Vorbis/Opus 第一个逻辑流的注释包（第二个数据包）
+// https://xiph.org/ogg/doc/framing.html
+func readOgg(r *mediaio.Reader) (*Tags, error) {
+	var (
+		packets [][]byte
+		packet  []byte
+		serial  uint32
+		first   = true
+	)
+	for len(packets) < 2 {
+		header, err := r.ReadFull(27)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("未找到 Ogg 注释包")
+			}
+			return nil, err
+		}
+		if string(header[:4]) != "OggS" {
+			return nil, fmt.Errorf("无效的 Ogg 页")
+		}
+		pageSerial := binary.LittleEndian.Uint32(header[14:18])
+		segments, err := r.ReadFull(int64(header[26]))
+		if err != nil {
+			return nil, err
+		}
+		var size int64
+		for _, s := range segments {
+			size += int64(s)
+		}
+		if first {
+			serial, first = pageSerial, false
+		} else if pageSerial != serial { // 其他逻辑流
+			if err := r.Skip(size); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		body, err := r.ReadFull(size)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range segments {
+			packet = append(packet, body[:s]...)
+			body = body[s:]
+			if len(packet) > maxMetadataBlockSize {
+				return nil, fmt.Errorf("Ogg 注释包过大")
+			}
+			if s < 255 { // 数据包结束
+				packets = append(packets, packet)
+				packet = nil
+				if len(packets) == 2 {
+					break
+				}
+			}
+		}
+	}
+
+	comment := packets[1]
+	for _, prefix := range oggCommentPrefixes {
+		if strings.HasPrefix(string(comment), prefix) {
+			tags := &Tags{Format: "vorbis"}
+			parseVorbisComment(tags, comment[len(prefix):])
+			return tags, nil
+		}
+	}
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,133 @@
+package bangumi
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type BangumiClient struct {
+	api         string
+	accessToken string
+	userAgent   string
+	client      *http.Client
+	limiter     *limiter.Limiter
+	retry       retry.Policy
+	loader      *cache.Loader
+	cacheTTL    time.Duration
+}
+
+func NewClient(opts ...Options) (*BangumiClient, error) {
+	opt := &options{
+		apiURL:    "https://api.bgm.tv",
+		userAgent: "MediaTools (https://github.com/AkimioJR/MediaTools)",
+		client:    &http.Client{},
+		limiter:   limiter.NewLimiter(time.Second, 10), // 每秒最多10次请求
+		retry:     retry.DefaultPolicy,
+		cacheTTL:  7 * 24 * time.Hour,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if opt.cache == nil {
+		memCache, err := cache.NewMemoryCache(10 * time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("create cache for BangumiClient failed: %w", err)
+		}
+		opt.cache = memCache
+	}
+
+	client := BangumiClient{
+		api:         opt.apiURL,
+		accessToken: opt.accessToken,
+		userAgent:   opt.userAgent,
+		client:      opt.client,
+		limiter:     opt.limiter,
+		retry:       opt.retry,
+		loader:      cache.NewLoader(opt.cache, opt.stale),
+		cacheTTL:    opt.cacheTTL,
+	}
+	return &client, nil
+}
+
+// DoRequest 发送请求，接口均为只读查询，请求体也作为缓存键的一部分
+func (client *BangumiClient) DoRequest(ctx context.Context, method string, path string, query url.Values, body any, resp any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("marshal request body failed: %w", err)
+		}
+	}
+	cacheKey := method + "|" + path + "|" + query.Encode() + "|" + string(payload)
+	data, err := client.loader.Load(ctx, cacheKey, client.cacheTTL, func(ctx context.Context) ([]byte, error) {
+		return client.fetch(ctx, method, path, query, payload)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, resp)
+	if err != nil {
+		return fmt.Errorf("unmarshal response failed: %w", err)
+	}
+	return nil
+}
+
+// 发送请求并返回原始响应体
+func (client *BangumiClient) fetch(ctx context.Context, method string, path string, query url.Values, payload []byte) ([]byte, error) {
+	u := client.api + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	res, err := client.retry.Do(ctx, client.client, client.limiter, func(ctx context.Context) (*http.Request, error) {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("create request failed: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", client.userAgent)
+		if client.accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+client.accessToken)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("do request failed: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		_ = json.NewDecoder(res.Body).Decode(&errResp)
+		return nil, fmt.Errorf("request failed, status code: %d, message: %s", res.StatusCode, errResp.Description)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+	return data, nil
+}
+
+// CacheStats 获取缓存统计信息
+func (client *BangumiClient) CacheStats() (*cache.Stats, error) {
+	return client.loader.Cache().Stats()
+}
+
+// PurgeCache 清理缓存，expiredOnly 为 true 时只清理已过期的条目
+func (client *BangumiClient) PurgeCache(expiredOnly bool) (int64, error) {
+	return client.loader.Cache().Purge(expiredOnly)
+}
@@ -0,0 +1,71 @@
+package bangumi_test
+
+import (
+	"MediaTools/internal/pkg/bangumi/v0"
+	"MediaTools/internal/pkg/fixture"
+	"MediaTools/internal/pkg/retry"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureClient 创建请求录制数据的客户端，check 用于检查请求内容（可为 nil）
+func newFixtureClient(t *testing.T, routes map[string]string, check func(r *http.Request)) *bangumi.BangumiClient {
+	server := fixture.NewServer(t, routes, func(w http.ResponseWriter, r *http.Request) bool {
+		assert.NotEmpty(t, r.Header.Get("User-Agent"))
+		if check != nil {
+			check(r)
+		}
+		return false
+	})
+
+	c, err := bangumi.NewClient(
+		bangumi.CustomAPIURL(server.URL),
+		bangumi.CustomHTTPClient(server.Client()),
+		bangumi.CustomRetryPolicy(retry.Policy{MaxAttempts: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second}),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestSearchSubjects(t *testing.T) {
+	c := newFixtureClient(t, map[string]string{"/v0/search/subjects": "search.json"}, func(r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var req bangumi.SearchRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "药屋少女的呢喃", req.Keyword)
+		assert.Equal(t, []bangumi.SubjectType{bangumi.SubjectTypeAnime}, req.Filter.Type)
+	})
+	subjects, err := c.SearchSubjects(context.Background(), bangumi.SearchRequest{
+		Keyword: "药屋少女的呢喃",
+		Filter:  bangumi.SearchFilter{Type: []bangumi.SubjectType{bangumi.SubjectTypeAnime}},
+	})
+	require.NoError(t, err)
+	require.Len(t, subjects, 2)
+	require.Equal(t, 326895, subjects[0].ID)
+	require.Equal(t, "药屋少女的呢喃", subjects[0].NameCN)
+}
+
+func TestGetSubjectAndEpisodes(t *testing.T) {
+	c := newFixtureClient(t, map[string]string{
+		"/v0/subjects/326895": "subject.json",
+		"/v0/episodes":        "episodes.json",
+	}, nil)
+	subject, err := c.GetSubject(context.Background(), 326895)
+	require.NoError(t, err)
+	require.Equal(t, 7.9, subject.Rating.Score)
+	require.Equal(t, 24, subject.TotalEpisodes)
+
+	episodes, err := c.GetEpisodes(context.Background(), 326895, bangumi.EpisodeTypeMain)
+	require.NoError(t, err)
+	require.Len(t, episodes, 2)
+	require.Equal(t, "无情的药师", episodes[1].NameCN)
+
+	_, err = c.GetSubject(context.Background(), 1)
+	require.Error(t, err)
+}
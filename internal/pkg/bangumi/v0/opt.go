@@ -0,0 +1,80 @@
+package bangumi
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
+	"net/http"
+	"time"
+)
+
+type options struct {
+	apiURL      string // Bangumi API URL
+	accessToken string // 个人令牌
+	userAgent   string // User-Agent，Bangumi 要求请求携带
+	client      *http.Client
+	limiter     *limiter.Limiter
+	retry       retry.Policy
+	cache       cache.Cache   // 缓存后端
+	cacheTTL    time.Duration // 缓存时间
+	stale       bool          // 是否开启 stale-while-revalidate
+}
+type Options func(opt *options)
+
+func CustomAPIURL(apiURL string) Options {
+	return func(opt *options) {
+		opt.apiURL = apiURL
+	}
+}
+
+// 个人令牌，可选，用于获取 NSFW 条目
+// https://next.bgm.tv/demo/access-token
+func CustomAccessToken(token string) Options {
+	return func(opt *options) {
+		opt.accessToken = token
+	}
+}
+
+func CustomUserAgent(userAgent string) Options {
+	return func(opt *options) {
+		opt.userAgent = userAgent
+	}
+}
+
+func CustomHTTPClient(client *http.Client) Options {
+	return func(opt *options) {
+		opt.client = client
+	}
+}
+
+func CustomLimiter(d time.Duration, maxCount uint64) Options {
+	return func(opt *options) {
+		opt.limiter = limiter.NewLimiter(d, maxCount)
+	}
+}
+
+func CustomRetryPolicy(policy retry.Policy) Options {
+	return func(opt *options) {
+		opt.retry = policy
+	}
+}
+
+// 自定义缓存后端，默认使用内存缓存
+func CustomCache(c cache.Cache) Options {
+	return func(opt *options) {
+		opt.cache = c
+	}
+}
+
+func CustomCacheTTL(ttl time.Duration) Options {
+	return func(opt *options) {
+		opt.cacheTTL = ttl
+	}
+}
+
+// 缓存过期后先返回旧数据，并在后台刷新缓存
+func CustomStaleWhileRevalidate(enable bool) Options {
+	return func(opt *options) {
+		opt.stale = enable
+	}
+}
@@ -0,0 +1,98 @@
+package bangumi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type SearchFilter struct {
+	Type    []SubjectType `json:"type,omitempty"`
+	AirDate []string      `json:"air_date,omitempty"` // 如 >=2020-01-01、<2021-01-01
+	NSFW    *bool         `json:"nsfw,omitempty"`
+}
+
+type SearchRequest struct {
+	Keyword string       `json:"keyword"`
+	Sort    string       `json:"sort,omitempty"` // match、heat、rank、score
+	Filter  SearchFilter `json:"filter"`
+}
+
+type SearchResponse struct {
+	Total  int       `json:"total"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+	Data   []Subject `json:"data"`
+}
+
+// 搜索条目
+// https://bangumi.github.io/api/#/%E6%9D%A1%E7%9B%AE/searchSubjects
+func (client *BangumiClient) SearchSubjects(ctx context.Context, req SearchRequest) ([]Subject, error) {
+	var resp SearchResponse
+	if err := client.DoRequest(ctx, http.MethodPost, "/v0/search/subjects", url.Values{}, req, &resp); err != nil {
+		return nil, NewBangumiError(fmt.Sprintf("搜索「%s」失败", req.Keyword), err)
+	}
+	return resp.Data, nil
+}
+
+// 获取条目详情
+// https://bangumi.github.io/api/#/%E6%9D%A1%E7%9B%AE/getSubjectById
+func (client *BangumiClient) GetSubject(ctx context.Context, subjectID int) (*Subject, error) {
+	var resp Subject
+	if err := client.DoRequest(ctx, http.MethodGet, "/v0/subjects/"+strconv.Itoa(subjectID), url.Values{}, nil, &resp); err != nil {
+		return nil, NewBangumiError(fmt.Sprintf("获取条目「%d」详情失败", subjectID), err)
+	}
+	return &resp, nil
+}
+
+// 章节类型
+type EpisodeType int
+
+const (
+	EpisodeTypeMain    EpisodeType = 0 // 本篇
+	EpisodeTypeSpecial EpisodeType = 1 // 特别篇
+)
+
+type Episode struct {
+	ID       int         `json:"id"`
+	Type     EpisodeType `json:"type"`
+	Name     string      `json:"name"`
+	NameCN   string      `json:"name_cn"`
+	Sort     float64     `json:"sort"` // 在所有章节中的序号
+	Ep       float64     `json:"ep"`   // 在同类型章节中的序号
+	AirDate  string      `json:"airdate"`
+	Duration string      `json:"duration"`
+	Desc     string      `json:"desc"`
+}
+
+type EpisodesResponse struct {
+	Total  int       `json:"total"`
+	Limit  int       `json:"limit"`
+	Offset int       `json:"offset"`
+	Data   []Episode `json:"data"`
+}
+
+// 获取条目的章节列表
+// https://bangumi.github.io/api/#/%E7%AB%A0%E8%8A%82/getEpisodes
+func (client *BangumiClient) GetEpisodes(ctx context.Context, subjectID int, episodeType EpisodeType) ([]Episode, error) {
+	var episodes []Episode
+	for offset := 0; ; {
+		params := url.Values{}
+		params.Set("subject_id", strconv.Itoa(subjectID))
+		params.Set("type", strconv.Itoa(int(episodeType)))
+		params.Set("limit", "100")
+		params.Set("offset", strconv.Itoa(offset))
+
+		var resp EpisodesResponse
+		if err := client.DoRequest(ctx, http.MethodGet, "/v0/episodes", params, nil, &resp); err != nil {
+			return nil, NewBangumiError(fmt.Sprintf("获取条目「%d」章节失败", subjectID), err)
+		}
+		episodes = append(episodes, resp.Data...)
+		offset += len(resp.Data)
+		if len(resp.Data) == 0 || offset >= resp.Total {
+			return episodes, nil
+		}
+	}
+}
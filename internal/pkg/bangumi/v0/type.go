@@ -0,0 +1,78 @@
+package bangumi
+
+import "fmt"
+
+type ErrorResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type BangumiError struct {
+	err error
+	msg string
+}
+
+func (e *BangumiError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("Bangumi 错误: %s", e.msg)
+	}
+	return fmt.Sprintf("Bangumi 错误: %s - %s", e.msg, e.err.Error())
+}
+
+func (e *BangumiError) Unwrap() error {
+	return e.err
+}
+
+func NewBangumiError(msg string, err error) *BangumiError {
+	return &BangumiError{
+		err: err,
+		msg: msg,
+	}
+}
+
+// 条目类型
+// https://bangumi.github.io/api/#/model-SubjectType
+type SubjectType int
+
+const (
+	SubjectTypeBook  SubjectType = 1 // 书籍
+	SubjectTypeAnime SubjectType = 2 // 动画
+	SubjectTypeMusic SubjectType = 3 // 音乐
+	SubjectTypeGame  SubjectType = 4 // 游戏
+	SubjectTypeReal  SubjectType = 6 // 三次元（电视剧、电影等）
+)
+
+type Images struct {
+	Large  string `json:"large"`
+	Common string `json:"common"`
+	Medium string `json:"medium"`
+	Small  string `json:"small"`
+	Grid   string `json:"grid"`
+}
+
+type Rating struct {
+	Rank  int     `json:"rank"`
+	Total int     `json:"total"`
+	Score float64 `json:"score"`
+}
+
+type Tag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type Subject struct {
+	ID            int         `json:"id"`
+	Type          SubjectType `json:"type"`
+	Name          string      `json:"name"`    // 原名
+	NameCN        string      `json:"name_cn"` // 中文名
+	Summary       string      `json:"summary"`
+	Date          string      `json:"date"`     // 放送开始日期
+	Platform      string      `json:"platform"` // TV、剧场版、OVA、WEB 等
+	Images        Images      `json:"images"`
+	Rating        Rating      `json:"rating"`
+	Eps           int         `json:"eps"`
+	TotalEpisodes int         `json:"total_episodes"`
+	Tags          []Tag       `json:"tags"`
+	NSFW          bool        `json:"nsfw"`
+}
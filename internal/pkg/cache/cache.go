@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var ErrNotFound = errors.New("缓存不存在")
+
+// 缓存条目
+type Entry struct {
+	Data      []byte
+	ExpiresAt time.Time
+}
+
+// Expired 判断缓存条目是否已过期
+func (e *Entry) Expired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// 缓存统计信息
+type Stats struct {
+	Entries   int64  `json:"entries"`    // 缓存条目数
+	Expired   int64  `json:"expired"`    // 已过期条目数
+	Size      int64  `json:"size"`       // 缓存数据大小（字节）
+	Hits      uint64 `json:"hits"`       // 命中次数
+	StaleHits uint64 `json:"stale_hits"` // 命中过期缓存次数
+	Misses    uint64 `json:"misses"`     // 未命中次数
+}
+
+// Cache 缓存后端
+// 过期的条目不会被立即删除，由调用方决定是否继续使用
+type Cache interface {
+	Get(key string) (*Entry, error)                       // 获取缓存条目，不存在时返回 ErrNotFound
+	Set(key string, data []byte, ttl time.Duration) error // 写入缓存条目
+	Purge(expiredOnly bool) (int64, error)                // 清理缓存，返回清理的条目数
+	Stats() (*Stats, error)                               // 获取缓存统计信息
+}
+
+// 命中统计
+type counter struct {
+	hits      atomic.Uint64
+	staleHits atomic.Uint64
+	misses    atomic.Uint64
+}
+
+func (c *counter) record(entry *Entry) {
+	switch {
+	case entry == nil:
+		c.misses.Add(1)
+	case entry.Expired():
+		c.staleHits.Add(1)
+	default:
+		c.hits.Add(1)
+	}
+}
+
+func (c *counter) fill(stats *Stats) {
+	stats.Hits = c.hits.Load()
+	stats.StaleHits = c.staleHits.Load()
+	stats.Misses = c.misses.Load()
+}
@@ -0,0 +1,119 @@
+package cache_test
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newCaches(t *testing.T) map[string]cache.Cache {
+	mem, err := cache.NewMemoryCache(time.Minute)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"))
+	require.NoError(t, err)
+	sql, err := cache.NewSQLiteCache(db, "test")
+	require.NoError(t, err)
+
+	return map[string]cache.Cache{"memory": mem, "sqlite": sql}
+}
+
+func TestCache(t *testing.T) {
+	for name, c := range newCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Get("missing")
+			require.ErrorIs(t, err, cache.ErrNotFound)
+
+			require.NoError(t, c.Set("fresh", []byte("a"), time.Hour))
+			require.NoError(t, c.Set("expired", []byte("b"), -time.Second))
+
+			entry, err := c.Get("fresh")
+			require.NoError(t, err)
+			require.Equal(t, []byte("a"), entry.Data)
+			require.False(t, entry.Expired())
+
+			entry, err = c.Get("expired")
+			require.NoError(t, err)
+			require.True(t, entry.Expired())
+
+			stats, err := c.Stats()
+			require.NoError(t, err)
+			require.Equal(t, int64(2), stats.Entries)
+			require.Equal(t, int64(1), stats.Expired)
+			require.Equal(t, uint64(1), stats.Hits)
+			require.Equal(t, uint64(1), stats.StaleHits)
+			require.Equal(t, uint64(1), stats.Misses)
+
+			count, err := c.Purge(true)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), count)
+			_, err = c.Get("expired")
+			require.ErrorIs(t, err, cache.ErrNotFound)
+
+			count, err = c.Purge(false)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), count)
+			_, err = c.Get("fresh")
+			require.ErrorIs(t, err, cache.ErrNotFound)
+		})
+	}
+}
+
+func TestLoaderStaleWhileRevalidate(t *testing.T) {
+	c, err := cache.NewMemoryCache(time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, c.Set("key", []byte("old"), -time.Second))
+
+	ctx := context.Background()
+	unreachable := func(context.Context) ([]byte, error) { return nil, errors.New("unreachable") }
+
+	// 未开启时，过期缓存不会被使用
+	_, err = cache.NewLoader(c, false).Load(ctx, "key", time.Hour, unreachable)
+	require.Error(t, err)
+
+	// 开启后，API 不可达时返回过期缓存
+	data, err := cache.NewLoader(c, true).Load(ctx, "key", time.Hour, unreachable)
+	require.NoError(t, err)
+	require.Equal(t, []byte("old"), data)
+
+	// 后台刷新成功后写入新数据
+	done := make(chan struct{})
+	data, err = cache.NewLoader(c, true).Load(ctx, "key", time.Hour, func(context.Context) ([]byte, error) {
+		defer close(done)
+		return []byte("new"), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte("old"), data)
+	<-done
+	require.Eventually(t, func() bool {
+		entry, err := c.Get("key")
+		return err == nil && string(entry.Data) == "new"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMemoryCacheEvict(t *testing.T) {
+	_, err := cache.NewMemoryCache(0)
+	require.Error(t, err)
+
+	c, err := cache.NewMemoryCache(50 * time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, c.Set("old", []byte("a"), time.Hour))
+	time.Sleep(100 * time.Millisecond)
+
+	// 超过保留时间的条目不再返回，写入时清理
+	_, err = c.Get("old")
+	require.ErrorIs(t, err, cache.ErrNotFound)
+	require.NoError(t, c.Set("new", []byte("b"), time.Hour))
+	stats, err := c.Stats()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.Entries)
+	count, err := c.Purge(false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Loader 在缓存之上封装读取逻辑
+// 开启 stale-while-revalidate 后，过期缓存会被直接返回，同时在后台刷新；
+// 后台刷新失败（如 API 不可达）时继续保留旧数据
+type Loader struct {
+	cache      Cache
+	stale      bool
+	refreshing sync.Map // 正在后台刷新的 key
+	flight     flightGroup
+}
+
+func NewLoader(cache Cache, staleWhileRevalidate bool) *Loader {
+	return &Loader{cache: cache, stale: staleWhileRevalidate}
+}
+
+func (l *Loader) Cache() Cache {
+	return l.cache
+}
+
+// Load 读取缓存，未命中或过期时调用 fetch 获取数据并写入缓存
+// 相同 key 的并发请求只会调用一次 fetch
+func (l *Loader) Load(ctx context.Context, key string, ttl time.Duration, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	entry, err := l.cache.Get(key)
+	if err == nil {
+		if !entry.Expired() {
+			return entry.Data, nil
+		}
+		if l.stale {
+			l.revalidate(ctx, key, ttl, fetch)
+			return entry.Data, nil
+		}
+	}
+
+	return l.flight.Do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		data, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		_ = l.cache.Set(key, data, ttl)
+		return data, nil
+	})
+}
+
+// 后台刷新不随请求的 ctx 取消
+func (l *Loader) revalidate(ctx context.Context, key string, ttl time.Duration, fetch func(ctx context.Context) ([]byte, error)) {
+	if _, loaded := l.refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer l.refreshing.Delete(key)
+		if data, err := fetch(ctx); err == nil {
+			_ = l.cache.Set(key, data, ttl)
+		}
+	}()
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryCache 内存缓存，进程重启后失效
+// 已淘汰的条目在写入时按 lifeWindow 的间隔清理，不使用后台 goroutine，丢弃缓存时无需关闭
+type MemoryCache struct {
+	lifeWindow time.Duration
+	mu         sync.RWMutex
+	entries    map[string]*memoryEntry
+	nextSweep  time.Time // 下次清理已淘汰条目的时间
+	counter
+}
+
+type memoryEntry struct {
+	Entry
+	evictAt time.Time // 超过该时间后从内存中淘汰
+}
+
+// NewMemoryCache 创建内存缓存
+// lifeWindow 为条目在内存中的最长保留时间，超过后即使未过期也会被淘汰，必须大于 0
+func NewMemoryCache(lifeWindow time.Duration) (*MemoryCache, error) {
+	if lifeWindow <= 0 {
+		return nil, fmt.Errorf("内存缓存的保留时间必须大于 0: %s", lifeWindow)
+	}
+	return &MemoryCache{
+		lifeWindow: lifeWindow,
+		entries:    make(map[string]*memoryEntry),
+		nextSweep:  time.Now().Add(lifeWindow),
+	}, nil
+}
+
+// sweep 清理已淘汰的条目，调用方需持有写锁
+func (m *MemoryCache) sweep(now time.Time) {
+	for key, e := range m.entries {
+		if now.After(e.evictAt) {
+			delete(m.entries, key)
+		}
+	}
+	m.nextSweep = now.Add(m.lifeWindow)
+}
+
+func (m *MemoryCache) Get(key string) (*Entry, error) {
+	m.mu.RLock()
+	e, ok := m.entries[key]
+	m.mu.RUnlock()
+	if !ok || time.Now().After(e.evictAt) {
+		m.record(nil)
+		return nil, ErrNotFound
+	}
+	entry := e.Entry
+	m.record(&entry)
+	return &entry, nil
+}
+
+func (m *MemoryCache) Set(key string, data []byte, ttl time.Duration) error {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now.After(m.nextSweep) {
+		m.sweep(now)
+	}
+	m.entries[key] = &memoryEntry{
+		Entry:   Entry{Data: data, ExpiresAt: now.Add(ttl)},
+		evictAt: now.Add(m.lifeWindow),
+	}
+	return nil
+}
+
+func (m *MemoryCache) Purge(expiredOnly bool) (int64, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for key, e := range m.entries {
+		if now.After(e.evictAt) { // 已淘汰的条目不计入清理数量
+			delete(m.entries, key)
+			continue
+		}
+		if !expiredOnly || e.Expired() {
+			delete(m.entries, key)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MemoryCache) Stats() (*Stats, error) {
+	var stats Stats
+	now := time.Now()
+	m.mu.RLock()
+	for _, e := range m.entries {
+		if now.After(e.evictAt) {
+			continue
+		}
+		stats.Entries++
+		stats.Size += int64(len(e.Data))
+		if e.Expired() {
+			stats.Expired++
+		}
+	}
+	m.mu.RUnlock()
+	m.fill(&stats)
+	return &stats, nil
+}
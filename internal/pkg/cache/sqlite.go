@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 持久化缓存条目
+type cacheEntry struct {
+	Namespace string    `gorm:"primaryKey"`
+	Key       string    `gorm:"primaryKey"`
+	Data      []byte    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (cacheEntry) TableName() string {
+	return "http_caches"
+}
+
+// SQLiteCache 基于 SQLite 数据库的持久化缓存
+// 不同客户端通过 namespace 区分，共用同一张表
+type SQLiteCache struct {
+	db        *gorm.DB
+	namespace string
+	counter
+}
+
+// NewSQLiteCache 创建持久化缓存，并自动迁移缓存表
+func NewSQLiteCache(db *gorm.DB, namespace string) (*SQLiteCache, error) {
+	if err := db.AutoMigrate(&cacheEntry{}); err != nil {
+		return nil, fmt.Errorf("迁移缓存表失败: %w", err)
+	}
+	return &SQLiteCache{db: db, namespace: namespace}, nil
+}
+
+func (s *SQLiteCache) Get(key string) (*Entry, error) {
+	var row cacheEntry
+	result := s.db.Where("namespace = ? AND key = ?", s.namespace, key).Limit(1).Find(&row)
+	if result.Error != nil {
+		s.record(nil)
+		return nil, fmt.Errorf("读取缓存失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		s.record(nil)
+		return nil, ErrNotFound
+	}
+	entry := Entry{Data: row.Data, ExpiresAt: row.ExpiresAt}
+	s.record(&entry)
+	return &entry, nil
+}
+
+func (s *SQLiteCache) Set(key string, data []byte, ttl time.Duration) error {
+	row := cacheEntry{
+		Namespace: s.namespace,
+		Key:       key,
+		Data:      data,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	err := s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteCache) Purge(expiredOnly bool) (int64, error) {
+	query := s.db.Where("namespace = ?", s.namespace)
+	if expiredOnly {
+		query = query.Where("expires_at < ?", time.Now())
+	}
+	result := query.Delete(&cacheEntry{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("清理缓存失败: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (s *SQLiteCache) Stats() (*Stats, error) {
+	var stats Stats
+	err := s.db.Model(&cacheEntry{}).
+		Select("COUNT(*) AS entries, COALESCE(SUM(LENGTH(data)), 0) AS size").
+		Where("namespace = ?", s.namespace).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("统计缓存失败: %w", err)
+	}
+	err = s.db.Model(&cacheEntry{}).
+		Where("namespace = ? AND expires_at < ?", s.namespace, time.Now()).
+		Count(&stats.Expired).Error
+	if err != nil {
+		return nil, fmt.Errorf("统计缓存失败: %w", err)
+	}
+	s.fill(&stats)
+	return &stats, nil
+}
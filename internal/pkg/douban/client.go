@@ -0,0 +1,126 @@
+package douban
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type DoubanClient struct {
+	api       string
+	searchAPI string
+	cookie    string
+	userAgent string
+	client    *http.Client
+	limiter   *limiter.Limiter
+	retry     retry.Policy
+	loader    *cache.Loader
+	cacheTTL  time.Duration
+}
+
+func NewClient(opts ...Options) (*DoubanClient, error) {
+	opt := &options{
+		apiURL:    "https://m.douban.com",
+		searchURL: "https://movie.douban.com",
+		userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+		client:    &http.Client{},
+		limiter:   limiter.NewLimiter(time.Second, 2), // 豆瓣限制严格，每秒最多2次请求
+		retry:     retry.DefaultPolicy,
+		cacheTTL:  7 * 24 * time.Hour,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if opt.cache == nil {
+		memCache, err := cache.NewMemoryCache(10 * time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("create cache for DoubanClient failed: %w", err)
+		}
+		opt.cache = memCache
+	}
+
+	client := DoubanClient{
+		api:       opt.apiURL,
+		searchAPI: opt.searchURL,
+		cookie:    opt.cookie,
+		userAgent: opt.userAgent,
+		client:    opt.client,
+		limiter:   opt.limiter,
+		retry:     opt.retry,
+		loader:    cache.NewLoader(opt.cache, opt.stale),
+		cacheTTL:  opt.cacheTTL,
+	}
+	return &client, nil
+}
+
+// DoRequest 发送 GET 请求
+// base: 接口地址，referer: 豆瓣接口会校验 Referer
+func (client *DoubanClient) DoRequest(ctx context.Context, base string, path string, query url.Values, referer string, resp any) error {
+	u := base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	data, err := client.loader.Load(ctx, http.MethodGet+"|"+u, client.cacheTTL, func(ctx context.Context) ([]byte, error) {
+		return client.fetch(ctx, u, referer)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, resp)
+	if err != nil {
+		return fmt.Errorf("unmarshal response failed: %w", err)
+	}
+	return nil
+}
+
+// 发送请求并返回原始响应体
+func (client *DoubanClient) fetch(ctx context.Context, u string, referer string) ([]byte, error) {
+	res, err := client.retry.Do(ctx, client.client, client.limiter, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create request failed: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", client.userAgent)
+		if referer != "" {
+			req.Header.Set("Referer", referer)
+		}
+		if client.cookie != "" {
+			req.Header.Set("Cookie", client.cookie)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("do request failed: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		_ = json.NewDecoder(res.Body).Decode(&errResp)
+		return nil, fmt.Errorf("request failed, status code: %d, message: %s", res.StatusCode, errResp.Msg)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+	return data, nil
+}
+
+// CacheStats 获取缓存统计信息
+func (client *DoubanClient) CacheStats() (*cache.Stats, error) {
+	return client.loader.Cache().Stats()
+}
+
+// PurgeCache 清理缓存，expiredOnly 为 true 时只清理已过期的条目
+func (client *DoubanClient) PurgeCache(expiredOnly bool) (int64, error) {
+	return client.loader.Cache().Purge(expiredOnly)
+}
@@ -0,0 +1,57 @@
+package douban_test
+
+import (
+	"MediaTools/internal/pkg/douban"
+	"MediaTools/internal/pkg/fixture"
+	"MediaTools/internal/pkg/retry"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureClient 创建请求录制数据的客户端，未录制的路径返回豆瓣的 subject_not_found 错误
+func newFixtureClient(t *testing.T, routes map[string]string) *douban.DoubanClient {
+	server := fixture.NewServer(t, routes, func(w http.ResponseWriter, r *http.Request) bool {
+		assert.NotEmpty(t, r.Header.Get("Referer"))
+		if _, ok := routes[r.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"msg":"subject_not_found","code":1404}`))
+			return true
+		}
+		return false
+	})
+
+	c, err := douban.NewClient(
+		douban.CustomAPIURL(server.URL),
+		douban.CustomSearchURL(server.URL),
+		douban.CustomHTTPClient(server.Client()),
+		douban.CustomRetryPolicy(retry.Policy{MaxAttempts: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second}),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestSuggest(t *testing.T) {
+	c := newFixtureClient(t, map[string]string{"/j/subject_suggest": "suggest.json"})
+	items, err := c.Suggest(context.Background(), "肖申克")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, "1292052", items[0].ID)
+	require.False(t, items[0].IsTV())
+	require.Equal(t, "celebrity", items[1].Type)
+}
+
+func TestGetSubject(t *testing.T) {
+	c := newFixtureClient(t, map[string]string{"/rexxar/api/v2/movie/1292052": "movie.json"})
+	subject, err := c.GetSubject(context.Background(), douban.SubjectTypeMovie, "1292052")
+	require.NoError(t, err)
+	require.Equal(t, "肖申克的救赎", subject.Title)
+	require.Equal(t, 9.7, subject.Rating.Value)
+
+	_, err = c.GetSubject(context.Background(), douban.SubjectTypeMovie, "1")
+	require.ErrorContains(t, err, "subject_not_found")
+}
@@ -0,0 +1,86 @@
+package douban
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
+	"net/http"
+	"time"
+)
+
+type options struct {
+	apiURL    string // 豆瓣移动版接口地址（rexxar）
+	searchURL string // 豆瓣电影搜索建议接口地址
+	cookie    string // 登录 Cookie，可选
+	userAgent string
+	client    *http.Client
+	limiter   *limiter.Limiter
+	retry     retry.Policy
+	cache     cache.Cache   // 缓存后端
+	cacheTTL  time.Duration // 缓存时间
+	stale     bool          // 是否开启 stale-while-revalidate
+}
+type Options func(opt *options)
+
+func CustomAPIURL(apiURL string) Options {
+	return func(opt *options) {
+		opt.apiURL = apiURL
+	}
+}
+
+func CustomSearchURL(searchURL string) Options {
+	return func(opt *options) {
+		opt.searchURL = searchURL
+	}
+}
+
+// 登录 Cookie，可选，请求频繁被限制时使用
+func CustomCookie(cookie string) Options {
+	return func(opt *options) {
+		opt.cookie = cookie
+	}
+}
+
+func CustomUserAgent(userAgent string) Options {
+	return func(opt *options) {
+		opt.userAgent = userAgent
+	}
+}
+
+func CustomHTTPClient(client *http.Client) Options {
+	return func(opt *options) {
+		opt.client = client
+	}
+}
+
+func CustomLimiter(d time.Duration, maxCount uint64) Options {
+	return func(opt *options) {
+		opt.limiter = limiter.NewLimiter(d, maxCount)
+	}
+}
+
+func CustomRetryPolicy(policy retry.Policy) Options {
+	return func(opt *options) {
+		opt.retry = policy
+	}
+}
+
+// 自定义缓存后端，默认使用内存缓存
+func CustomCache(c cache.Cache) Options {
+	return func(opt *options) {
+		opt.cache = c
+	}
+}
+
+func CustomCacheTTL(ttl time.Duration) Options {
+	return func(opt *options) {
+		opt.cacheTTL = ttl
+	}
+}
+
+// 缓存过期后先返回旧数据，并在后台刷新缓存
+func CustomStaleWhileRevalidate(enable bool) Options {
+	return func(opt *options) {
+		opt.stale = enable
+	}
+}
@@ -0,0 +1,75 @@
+package douban
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type SuggestItem struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	SubTitle string `json:"sub_title"` // 原名
+	Year     string `json:"year"`
+	Type     string `json:"type"`    // movie、celebrity
+	Episode  string `json:"episode"` // 电视剧集数，电影为空
+	Img      string `json:"img"`
+	URL      string `json:"url"`
+}
+
+// IsTV 是否为电视剧
+func (item SuggestItem) IsTV() bool {
+	return item.Episode != ""
+}
+
+// 搜索建议，返回电影、电视剧和影人
+// https://movie.douban.com/j/subject_suggest?q=
+func (client *DoubanClient) Suggest(ctx context.Context, query string) ([]SuggestItem, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	var resp []SuggestItem
+	if err := client.DoRequest(ctx, client.searchAPI, "/j/subject_suggest", params, client.searchAPI+"/", &resp); err != nil {
+		return nil, NewDoubanError(fmt.Sprintf("搜索「%s」失败", query), err)
+	}
+	return resp, nil
+}
+
+type Rating struct {
+	Count     int     `json:"count"`
+	Max       int     `json:"max"`
+	StarCount float64 `json:"star_count"`
+	Value     float64 `json:"value"`
+}
+
+type Subject struct {
+	ID            string   `json:"id"`
+	Type          string   `json:"type"` // movie、tv
+	Title         string   `json:"title"`
+	OriginalTitle string   `json:"original_title"`
+	Intro         string   `json:"intro"`
+	Year          string   `json:"year"`
+	IsTV          bool     `json:"is_tv"`
+	EpisodesCount int      `json:"episodes_count"`
+	Genres        []string `json:"genres"`
+	Countries     []string `json:"countries"`
+	Languages     []string `json:"languages"`
+	Pubdate       []string `json:"pubdate"`
+	Durations     []string `json:"durations"`
+	CardSubtitle  string   `json:"card_subtitle"`
+	Rating        *Rating  `json:"rating"` // 评价人数不足时为 null
+	Pic           struct {
+		Large  string `json:"large"`
+		Normal string `json:"normal"`
+	} `json:"pic"`
+}
+
+// 获取电影或电视剧详情
+// https://m.douban.com/rexxar/api/v2/{movie,tv}/{id}
+func (client *DoubanClient) GetSubject(ctx context.Context, subjectType SubjectType, subjectID string) (*Subject, error) {
+	var resp Subject
+	referer := client.api + "/" + string(subjectType) + "/subject/" + subjectID + "/"
+	if err := client.DoRequest(ctx, client.api, "/rexxar/api/v2/"+string(subjectType)+"/"+url.PathEscape(subjectID), url.Values{}, referer, &resp); err != nil {
+		return nil, NewDoubanError(fmt.Sprintf("获取条目「%s」详情失败", subjectID), err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,40 @@
+package douban
+
+import "fmt"
+
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Msg     string `json:"msg"`
+	Request string `json:"request"`
+}
+
+type DoubanError struct {
+	err error
+	msg string
+}
+
+func (e *DoubanError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("豆瓣错误: %s", e.msg)
+	}
+	return fmt.Sprintf("豆瓣错误: %s - %s", e.msg, e.err.Error())
+}
+
+func (e *DoubanError) Unwrap() error {
+	return e.err
+}
+
+func NewDoubanError(msg string, err error) *DoubanError {
+	return &DoubanError{
+		err: err,
+		msg: msg,
+	}
+}
+
+// 条目类型
+type SubjectType string
+
+const (
+	SubjectTypeMovie SubjectType = "movie" // 电影
+	SubjectTypeTV    SubjectType = "tv"    // 电视剧（包括动画、综艺等）
+)
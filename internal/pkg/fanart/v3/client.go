@@ -1,11 +1,16 @@
 package fanart
 
 import (
+	"MediaTools/internal/pkg/cache"
 	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"image"
+	_ "image/jpeg"
+	_ "image/png"
 	"io"
 	"net/http"
 	"net/url"
@@ -15,112 +20,179 @@ import (
 )
 
 type FanartClient struct {
-	api     string
-	apiKey  string
-	client  *http.Client
-	limiter *limiter.Limiter
-	cache   *bigcache.BigCache
+	api        string
+	apiKey     string
+	client     *http.Client
+	limiter    *limiter.Limiter
+	retry      retry.Policy
+	loader     *cache.Loader
+	cacheTTL   time.Duration
+	imageCache *bigcache.BigCache
 }
 
 func NewClient(apiKey string, opts ...Options) (*FanartClient, error) {
 	opt := &options{
-		apiURL: "https://webservice.fanart.tv",
-		client: &http.Client{},
+		apiURL:   "https://webservice.fanart.tv",
+		client:   &http.Client{},
+		limiter:  limiter.NewLimiter(time.Second, 20), // 每秒最多20次请求
+		retry:    retry.DefaultPolicy,
+		cacheTTL: 30 * 24 * time.Hour,
 	}
 	for _, o := range opts {
 		o(opt)
 	}
 
-	cache, err := bigcache.NewBigCache(bigcache.DefaultConfig(10 * time.Minute))
+	if opt.cache == nil {
+		memCache, err := cache.NewMemoryCache(10 * time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("create cache for FanartClient failed: %w", err)
+		}
+		opt.cache = memCache
+	}
+	imageCache, err := bigcache.NewBigCache(bigcache.DefaultConfig(10 * time.Minute))
 	if err != nil {
-		return nil, fmt.Errorf("create cache for FanartClient failed: %w", err)
+		return nil, fmt.Errorf("create image cache for FanartClient failed: %w", err)
 	}
 
 	client := FanartClient{
-		api:     opt.apiURL,
-		apiKey:  apiKey,
-		client:  opt.client,
-		limiter: limiter.NewLimiter(1*time.Second, 20), // 每秒最多20次请求
-		cache:   cache,
+		api:        opt.apiURL,
+		apiKey:     apiKey,
+		client:     opt.client,
+		limiter:    opt.limiter,
+		retry:      opt.retry,
+		loader:     cache.NewLoader(opt.cache, opt.stale),
+		cacheTTL:   opt.cacheTTL,
+		imageCache: imageCache,
 	}
 	return &client, nil
 }
 
-func (client *FanartClient) DoRequest(method string, path string, query url.Values, body io.Reader, resp any) error {
+func (client *FanartClient) DoRequest(ctx context.Context, method string, path string, query url.Values, body io.Reader, resp any) error {
 	var (
 		data []byte
 		err  error
 	)
-	cacheKey := method + "|" + path + "|" + query.Encode()
 	if method == http.MethodGet && body == nil {
-		if data, err = client.cache.Get(cacheKey); err == nil {
-			return json.Unmarshal(data, resp)
-		}
+		cacheKey := method + "|" + path + "|" + query.Encode()
+		data, err = client.loader.Load(ctx, cacheKey, client.cacheTTL, func(ctx context.Context) ([]byte, error) {
+			return client.fetch(ctx, method, path, query, body)
+		})
+	} else {
+		data, err = client.fetch(ctx, method, path, query, body)
+	}
+	if err != nil {
+		return err
 	}
 
-	client.limiter.Acquire()
-	query.Set("api_key", client.apiKey)
-	url := client.api + "/v3" + path + "?" + query.Encode()
-	req, err := http.NewRequest(method, url, body)
+	err = json.Unmarshal(data, resp)
 	if err != nil {
-		return fmt.Errorf("create request failed: %w", err)
+		return fmt.Errorf("unmarshal response failed: %w", err)
+	}
+	return nil
+}
+
+// 发送请求并返回原始响应体
+func (client *FanartClient) fetch(ctx context.Context, method string, path string, query url.Values, body io.Reader) ([]byte, error) {
+	params := url.Values{}
+	for k, v := range query {
+		params[k] = v
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	res, err := client.client.Do(req)
+	params.Set("api_key", client.apiKey)
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read request body failed: %w", err)
+		}
+	}
+
+	url := client.api + "/v3" + path + "?" + params.Encode()
+	res, err := client.retry.Do(ctx, client.client, client.limiter, func(ctx context.Context) (*http.Request, error) {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("create request failed: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		return fmt.Errorf("do request failed: %w", err)
+		return nil, fmt.Errorf("do request failed: %w", err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
 		var errResp ErrorResponse
 		if err := json.NewDecoder(res.Body).Decode(&errResp); err != nil {
-			return fmt.Errorf("request failed, status code: %d, message: %s", res.StatusCode, errResp.ErrorMessage)
+			return nil, fmt.Errorf("decode error response failed: %w", err)
 		}
-		return fmt.Errorf("decode error response failed: %w", err)
+		return nil, fmt.Errorf("request failed, status code: %d, message: %s", res.StatusCode, errResp.ErrorMessage)
 	}
-	data, err = io.ReadAll(res.Body)
+	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return fmt.Errorf("read response body failed: %w", err)
+		return nil, fmt.Errorf("read response body failed: %w", err)
 	}
-	err = json.Unmarshal(data, resp)
+	return data, nil
+}
+
+// CacheStats 获取缓存统计信息
+func (client *FanartClient) CacheStats() (*cache.Stats, error) {
+	return client.loader.Cache().Stats()
+}
+
+// PurgeCache 清理缓存，expiredOnly 为 true 时只清理已过期的条目
+func (client *FanartClient) PurgeCache(expiredOnly bool) (int64, error) {
+	count, err := client.loader.Cache().Purge(expiredOnly)
 	if err != nil {
-		return fmt.Errorf("unmarshal response failed: %w", err)
+		return 0, err
 	}
-
-	// 写入缓存
-	if method == http.MethodGet && body == nil {
-		if data, err = json.Marshal(resp); err == nil {
-			_ = client.cache.Set(cacheKey, data)
-		}
+	if !expiredOnly {
+		_ = client.imageCache.Reset()
 	}
-	return nil
+	return count, nil
 }
 
-func (client *FanartClient) DownloadImage(url string) (image.Image, error) {
+// DownloadImageData 下载图片原始数据
+func (client *FanartClient) DownloadImageData(ctx context.Context, url string) ([]byte, error) {
 	cacheKey := "image|" + url
+	data, err := client.imageCache.Get(cacheKey)
+	if err == nil {
+		return data, nil
+	}
 
-	var (
-		data []byte
-		err  error
-	)
-	data, err = client.cache.Get(cacheKey)
+	resp, err := client.retry.Do(ctx, client.client, nil, func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	})
 	if err != nil {
-		resp, err := client.client.Get(url)
-		if err != nil {
-			return nil, NewFanartError(fmt.Sprintf("下载图片「%s」失败", url), err)
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
-			return nil, NewFanartError(fmt.Sprintf("下载图片「%s」失败，HTTP code: %d", url, resp.StatusCode), nil)
-		}
-		defer resp.Body.Close()
-		data, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, NewFanartError(fmt.Sprintf("读取图片「%s」响应体失败", url), err)
-		}
+		return nil, NewFanartError(fmt.Sprintf("下载图片「%s」失败", url), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewFanartError(fmt.Sprintf("下载图片「%s」失败，HTTP code: %d", url, resp.StatusCode), nil)
+	}
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, NewFanartError(fmt.Sprintf("读取图片「%s」响应体失败", url), err)
 	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return nil, NewFanartError(fmt.Sprintf("解码图片「%s」失败", url), err)
+	}
+
+	go func() {
+		client.imageCache.Set(cacheKey, data)
+	}()
+	return data, nil
+}
 
+func (client *FanartClient) DownloadImage(ctx context.Context, url string) (image.Image, error) {
+	data, err := client.DownloadImageData(ctx, url)
+	if err != nil {
+		return nil, err
+	}
 	img, _, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
 		return nil, NewFanartError(fmt.Sprintf("解码图片「%s」失败", url), err)
@@ -0,0 +1,92 @@
+package fanart_test
+
+import (
+	"MediaTools/internal/pkg/fanart/v3"
+	"MediaTools/internal/pkg/retry"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockClient(t *testing.T, handler http.HandlerFunc) *fanart.FanartClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := fanart.NewClient("test",
+		fanart.CustomAPIURL(server.URL),
+		fanart.CustomHTTPClient(server.Client()),
+		fanart.CustomRetryPolicy(retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    time.Second,
+		}),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClientThrottled(t *testing.T) {
+	var count atomic.Int32
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/movies/tt0133093", r.URL.Path)
+		switch count.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"name":"The Matrix","imdb_id":"tt0133093"}`))
+		}
+	})
+
+	data, err := c.GetMovieImagesData(context.Background(), "tt0133093")
+	require.NoError(t, err)
+	require.Equal(t, "The Matrix", data.Name)
+	require.Equal(t, int32(3), count.Load())
+}
+
+func TestClientCanceled(t *testing.T) {
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetMovieImagesData(ctx, "tt0133093")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientDeduplicate(t *testing.T) {
+	var count atomic.Int32
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"name":"Game of Thrones","thetvdb_id":"121361"}`))
+	})
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetTVImagesData(context.Background(), 121361)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), count.Load())
+}
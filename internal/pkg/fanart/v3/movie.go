@@ -1,6 +1,7 @@
 package fanart
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -23,9 +24,10 @@ type MovieImagesData struct {
 
 // 获取电影的图片数据
 // https://fanarttv.docs.apiary.io/#reference/movies/get-movies/get-images-for-movie
-func (client *FanartClient) GetMovieImagesData(imdbID string) (*MovieImagesData, error) {
+func (client *FanartClient) GetMovieImagesData(ctx context.Context, imdbID string) (*MovieImagesData, error) {
 	var resp MovieImagesData
 	err := client.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/movies/"+imdbID,
 		url.Values{},
@@ -1,6 +1,7 @@
 package fanart
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -26,9 +27,10 @@ type TVImagesData struct {
 
 // 获取剧集的图片数据
 // https://fanarttv.docs.apiary.io/#reference/tv/get-show/get-images-for-show
-func (client *FanartClient) GetTVImagesData(thetvdbID int) (*TVImagesData, error) {
+func (client *FanartClient) GetTVImagesData(ctx context.Context, thetvdbID int) (*TVImagesData, error) {
 	var resp TVImagesData
 	err := client.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(thetvdbID)+"/images",
 		url.Values{},
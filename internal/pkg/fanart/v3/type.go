@@ -21,6 +21,10 @@ func (e *FanartError) Error() string {
 	return fmt.Sprintf("Fanart 错误: %s - %s", e.msg, e.err.Error())
 }
 
+func (e *FanartError) Unwrap() error {
+	return e.err
+}
+
 func NewFanartError(msg string, err error) *FanartError {
 	return &FanartError{
 		err: err,
@@ -50,6 +54,16 @@ func (info BaseInfo) getLang() string {
 	return info.Lang
 }
 
+// GetBaseInfo 获取图片基础信息
+func (info BaseInfo) GetBaseInfo() BaseInfo {
+	return info
+}
+
+// Image 带有基础信息的 Fanart 图片
+type Image interface {
+	GetBaseInfo() BaseInfo
+}
+
 type img interface {
 	getLang() string
 }
@@ -0,0 +1,45 @@
+// Package fixture 提供返回 testdata 中录制数据的 HTTP 测试服务器，供各客户端的测试使用
+package fixture
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Intercept 在按路由返回录制数据之前处理请求，返回 true 表示已写入响应
+// 运行在服务器的 goroutine 中，检查失败时只能使用 t.Errorf（或 testify 的 assert）记录，不能使用 require
+type Intercept func(w http.ResponseWriter, r *http.Request) bool
+
+// NewServer 启动测试服务器，测试结束时自动关闭
+// routes: 请求路径 -> testdata 中的录制数据文件名，未配置的路径返回 404
+// intercept: 请求拦截（可为 nil），用于检查请求头、模拟登录等
+func NewServer(t testing.TB, routes map[string]string, intercept Intercept) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if intercept != nil && intercept(w, r) {
+			return
+		}
+		name, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		Write(t, w, name)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Write 以 JSON 响应返回 testdata 中的录制数据，读取失败时记录测试失败并响应 500
+func Write(t testing.TB, w http.ResponseWriter, name string) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Errorf("读取录制数据 %s 失败: %v", name, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
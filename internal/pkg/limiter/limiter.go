@@ -1,47 +1,89 @@
 package limiter
 
 import (
-	"sync/atomic"
+	"context"
+	"sync"
 	"time"
 )
 
+// Limiter 令牌桶限流器
+// 桶容量为 maxCount，每 d 时间补充 maxCount 个令牌
 type Limiter struct {
-	d        time.Duration
-	maxCount uint64
-	count    atomic.Uint64
+	mu       sync.Mutex
+	interval time.Duration // 补充一个令牌所需时间
+	burst    float64       // 桶容量
+	tokens   float64       // 当前令牌数，为负数时表示已被预约的令牌
+	last     time.Time     // 上次补充令牌的时间
 }
 
 func NewLimiter(d time.Duration, maxCount uint64) *Limiter {
-	l := &Limiter{
-		d:        d,
-		maxCount: maxCount,
+	if maxCount == 0 {
+		maxCount = 1
+	}
+	return &Limiter{
+		interval: d / time.Duration(maxCount),
+		burst:    float64(maxCount),
+		tokens:   float64(maxCount),
+		last:     time.Now(),
 	}
-	go l.resetLoop()
-	return l
 }
 
-func (l *Limiter) resetLoop() {
-	ticker := time.NewTicker(l.d)
-	defer ticker.Stop()
-	for range ticker.C {
-		l.count.Store(0)
+// 预约一个令牌，返回需要等待的时间
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.interval > 0 {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	} else {
+		l.tokens = l.burst
 	}
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
 }
 
-// Acquire 原子方式实现，超过限额时自旋等待
-func (l *Limiter) Acquire() {
-	for {
-		cur := l.count.Load()
-		if cur < l.maxCount {
-			if l.count.CompareAndSwap(cur, cur+1) {
-				return
-			}
-		} else {
-			time.Sleep(10 * time.Millisecond) // 忙等，避免CPU空转
-		}
+// 归还预约的令牌
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// Wait 阻塞直到获取到令牌或 ctx 结束
+func (l *Limiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
 	}
 }
 
+// Acquire 阻塞直到获取到令牌
+func (l *Limiter) Acquire() {
+	_ = l.Wait(context.Background())
+}
+
 // NewLimitFunc 返回一个带限流功能的函数
 func NewLimitFunc[In any, Out any](d time.Duration, maxCount uint64, fn func(In) Out) func(In) Out {
 	l := NewLimiter(d, maxCount)
@@ -0,0 +1,32 @@
+package limiter_test
+
+import (
+	"MediaTools/internal/pkg/limiter"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterWait(t *testing.T) {
+	l := limiter.NewLimiter(100*time.Millisecond, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	require.NoError(t, l.Wait(ctx))
+	require.NoError(t, l.Wait(ctx))
+	require.Less(t, time.Since(start), 20*time.Millisecond) // 桶内令牌无需等待
+
+	require.NoError(t, l.Wait(ctx))
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond) // 令牌耗尽后等待补充
+}
+
+func TestLimiterWaitCanceled(t *testing.T) {
+	l := limiter.NewLimiter(time.Hour, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,93 @@
+package mediaio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const maxMoovSize = 64 << 20 // moov 的最大读取长度
+
+// 可能出现在 MP4/MOV 文件开头的 box 类型
+var mp4TopBoxes = map[string]struct{}{
+	"ftyp": {}, "moov": {}, "mdat": {}, "free": {}, "skip": {}, "wide": {}, "pnot": {},
+}
+
+// IsMP4Box 判断是否为 MP4/MOV 文件开头的 box 类型
+func IsMP4Box(boxType string) bool {
+	_, ok := mp4TopBoxes[boxType]
+	return ok
+}
+
+// ReadMoov 跳过顶层的其他 box，读取 moov 的内容
+// moov 不完整时返回已读取的部分
+func ReadMoov(r *Reader) ([]byte, error) {
+	var hdr [16]byte
+	for {
+		if _, err := io.ReadFull(r, hdr[:8]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("未找到 moov")
+			}
+			return nil, err
+		}
+		size := int64(binary.BigEndian.Uint32(hdr[:4]))
+		boxType := string(hdr[4:8])
+		headerLen := int64(8)
+		switch size {
+		case 0: // 延伸到文件末尾
+			if boxType != "moov" {
+				return nil, fmt.Errorf("未找到 moov")
+			}
+			size = maxMoovSize + headerLen
+		case 1: // 64 位长度
+			if _, err := io.ReadFull(r, hdr[8:16]); err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(hdr[8:16]))
+			headerLen = 16
+		}
+		if size < headerLen {
+			return nil, fmt.Errorf("无效的 box 长度: %s", boxType)
+		}
+
+		if boxType != "moov" {
+			if err := r.Skip(size - headerLen); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if size-headerLen > maxMoovSize {
+			return nil, fmt.Errorf("moov 过大: %d", size)
+		}
+		data, err := r.ReadFull(size - headerLen)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+		return data, nil
+	}
+}
+
+// ForEachBox 遍历缓冲区中的子 box，遇到格式错误时停止
+func ForEachBox(buf []byte, fn func(boxType string, data []byte)) {
+	for len(buf) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(buf[:4]))
+		boxType := string(buf[4:8])
+		headerLen := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(buf))
+		case 1:
+			if len(buf) < 16 {
+				return
+			}
+			size = binary.BigEndian.Uint64(buf[8:16])
+			headerLen = 16
+		}
+		if size < headerLen || size > uint64(len(buf)) {
+			return
+		}
+		fn(boxType, buf[headerLen:size])
+		buf = buf[size:]
+	}
+}
@@ -0,0 +1,80 @@
+// Package mediaio 提供解析媒体文件头部时共用的读取工具
+package mediaio
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrTooFar = errors.New("头部信息位置过远")
+
+// Reader 支持预读和跳过的读取器
+// 底层读取器实现 io.Seeker 时直接 Seek 跳过数据，否则顺序丢弃（最多丢弃 maxDiscard 字节）
+type Reader struct {
+	r          io.Reader
+	seeker     io.Seeker
+	pending    []byte // 预读但未消费的数据
+	discarded  int64  // 已丢弃的字节数
+	maxDiscard int64  // 不可 Seek 时最多丢弃的字节数
+}
+
+// NewReader 创建读取器，maxDiscard 为不可 Seek 时最多丢弃的字节数
+func NewReader(r io.Reader, maxDiscard int64) *Reader {
+	reader := &Reader{r: r, maxDiscard: maxDiscard}
+	if s, ok := r.(io.Seeker); ok {
+		reader.seeker = s
+	}
+	return reader
+}
+
+// Peek 预读 n 字节，不消费数据
+func (r *Reader) Peek(n int) ([]byte, error) {
+	if len(r.pending) < n {
+		buf := make([]byte, n-len(r.pending))
+		if _, err := io.ReadFull(r.r, buf); err != nil {
+			return nil, err
+		}
+		r.pending = append(r.pending, buf...)
+	}
+	return r.pending[:n], nil
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	if len(r.pending) > 0 {
+		n := copy(p, r.pending)
+		r.pending = r.pending[n:]
+		return n, nil
+	}
+	return r.r.Read(p)
+}
+
+// ReadFull 读取 n 字节，按实际读到的数据分配内存（不按声明的长度预先分配，避免损坏的文件占用大量内存）
+// 数据不足时返回已读取的部分和 io.ErrUnexpectedEOF
+func (r *Reader) ReadFull(n int64) ([]byte, error) {
+	buf, err := io.ReadAll(io.LimitReader(r, n))
+	if err == nil && int64(len(buf)) < n {
+		err = io.ErrUnexpectedEOF
+	}
+	return buf, err
+}
+
+// Skip 跳过 n 字节
+func (r *Reader) Skip(n int64) error {
+	if k := min(int64(len(r.pending)), n); k > 0 {
+		r.pending = r.pending[k:]
+		n -= k
+	}
+	if n == 0 {
+		return nil
+	}
+	if r.seeker != nil {
+		_, err := r.seeker.Seek(n, io.SeekCurrent)
+		return err
+	}
+	if n > r.maxDiscard-r.discarded { // 不使用 r.discarded+n，避免长度字段异常时溢出
+		return ErrTooFar
+	}
+	r.discarded += n
+	_, err := io.CopyN(io.Discard, r.r, n)
+	return err
+}
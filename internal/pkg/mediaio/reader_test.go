@@ -0,0 +1,34 @@
+package mediaio_test
+
+import (
+	"MediaTools/internal/pkg/mediaio"
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderSkip(t *testing.T) {
+	data := []byte("0123456789abcdef")
+	// 使用 io.MultiReader 隐藏 Seek 方法，按顺序丢弃数据
+	r := mediaio.NewReader(io.MultiReader(bytes.NewReader(data)), 8)
+
+	head, err := r.Peek(4)
+	require.NoError(t, err)
+	require.Equal(t, "0123", string(head))
+
+	require.NoError(t, r.Skip(6)) // 预读的 4 字节不计入丢弃字节数
+	buf, err := r.ReadFull(2)
+	require.NoError(t, err)
+	require.Equal(t, "67", string(buf))
+
+	require.ErrorIs(t, r.Skip(7), mediaio.ErrTooFar)
+	require.ErrorIs(t, r.Skip(math.MaxInt64), mediaio.ErrTooFar)
+	require.NoError(t, r.Skip(6))
+
+	buf, err = r.ReadFull(4)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, "ef", string(buf))
+}
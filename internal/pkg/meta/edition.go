@@ -0,0 +1,90 @@
+package meta
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// 版本（剪辑版本）识别正则及其规范名称
+// 英文关键字需要出现在年份之后，避免误伤标题（如 The Final Cut）
+var editionRes = []struct {
+	re      *regexp.Regexp
+	edition string
+}{
+	{regexp.MustCompile(`(?i)\bdirector'?s[\s._-]cut\b`), "Director's Cut"},
+	{regexp.MustCompile(`(?i)\bextended(?:[\s._-](?:cut|edition|version))?\b`), "Extended"},
+	{regexp.MustCompile(`(?i)\btheatrical(?:[\s._-](?:cut|edition|version))?\b`), "Theatrical"},
+	{regexp.MustCompile(`(?i)\bIMAX(?:[\s._-](?:edition|version))?\b`), "IMAX"},
+	{regexp.MustCompile(`(?i)\bcriterion(?:[\s._-]collection)?\b`), "Criterion"},
+	{regexp.MustCompile(`(?i)\bunrated(?:[\s._-](?:cut|edition|version))?\b`), "Unrated"},
+	{regexp.MustCompile(`(?i)\buncut\b`), "Uncut"},
+	{regexp.MustCompile(`(?i)\bremastered\b`), "Remastered"},
+	{regexp.MustCompile(`(?i)\bfinal[\s._-]cut\b`), "Final Cut"},
+	{regexp.MustCompile(`(?i)\bultimate[\s._-](?:cut|edition)\b`), "Ultimate Edition"},
+	{regexp.MustCompile(`(?i)\bspecial[\s._-]edition\b`), "Special Edition"},
+	{regexp.MustCompile(`(?i)\b(?:\d{1,3}(?:th|st|nd|rd)[\s._-])?anniversary[\s._-]edition\b`), "Anniversary Edition"},
+}
+
+// 中文版本关键字，不要求出现在年份之后
+var editionCNRes = []struct {
+	re      *regexp.Regexp
+	edition string
+}{
+	{regexp.MustCompile(`导演剪辑版|导剪版`), "Director's Cut"},
+	{regexp.MustCompile(`加长版`), "Extended"},
+	{regexp.MustCompile(`院线版|剧场公映版`), "Theatrical"},
+	{regexp.MustCompile(`未删减版`), "Uncut"},
+}
+
+var (
+	editionTagRe  = regexp.MustCompile(`\{edition-([^}]+)\}`) // Jellyfin/Plex 的 {edition-xxx} 标记
+	editionYearRe = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+)
+
+// parseEdition 识别名称中的版本（导演剪辑版、加长版、IMAX 等），返回去掉版本关键字后的名称
+// 识别到多个版本时按出现顺序以空格连接
+func (meta *VideoMeta) parseEdition(title string) string {
+	type found struct {
+		pos     int
+		edition string
+	}
+	var editions []found
+
+	if m := editionTagRe.FindStringSubmatchIndex(title); m != nil {
+		editions = append(editions, found{m[0], strings.TrimSpace(title[m[2]:m[3]])})
+		title = title[:m[0]] + " " + title[m[1]:]
+	}
+
+	yearLoc := editionYearRe.FindStringIndex(title)
+	for _, item := range editionRes {
+		loc := item.re.FindStringIndex(title)
+		if loc == nil || yearLoc == nil || loc[0] < yearLoc[1] {
+			continue
+		}
+		editions = append(editions, found{loc[0], item.edition})
+		title = title[:loc[0]] + " " + title[loc[1]:]
+	}
+	for _, item := range editionCNRes {
+		loc := item.re.FindStringIndex(title)
+		if loc == nil || strings.TrimSpace(title[:loc[0]]) == "" {
+			continue
+		}
+		editions = append(editions, found{loc[0], item.edition})
+		title = title[:loc[0]] + " " + title[loc[1]:]
+	}
+
+	if len(editions) == 0 {
+		return title
+	}
+	sort.SliceStable(editions, func(i, j int) bool { return editions[i].pos < editions[j].pos })
+	names := make([]string, 0, len(editions))
+	for _, e := range editions {
+		if !slices.Contains(names, e.edition) {
+			names = append(names, e.edition)
+		}
+	}
+	meta.Edition = strings.Join(names, " ")
+	return title
+}
@@ -0,0 +1,40 @@
+package meta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Jellyfin/Plex/Radarr/Sonarr 等工具在文件或目录名中使用的外部 ID 标记
+// 如 {tmdb-603}、[tmdbid-603]、[tmdbid=603]、{imdb-tt0133093}、{tvdb-81189}
+var externalIDTagRe = regexp.MustCompile(`(?i)[\[{]\s*(tmdb|tmdbid|imdb|imdbid|tvdb|tvdbid)\s*[-=]\s*(tt\d+|\d+)\s*[\]}]`)
+
+// parseExternalIDs 识别名称中的外部 ID 标记，返回去掉标记后的名称
+// 同一来源出现多次时使用第一个
+func (meta *VideoMeta) parseExternalIDs(title string) string {
+	return externalIDTagRe.ReplaceAllStringFunc(title, func(tag string) string {
+		m := externalIDTagRe.FindStringSubmatch(tag)
+		source, id := strings.ToLower(m[1]), strings.ToLower(m[2])
+		switch strings.TrimSuffix(source, "id") {
+		case "tmdb":
+			if n, err := strconv.Atoi(id); err == nil && meta.TMDBID == 0 {
+				meta.TMDBID = n
+			}
+		case "imdb":
+			if strings.HasPrefix(id, "tt") && meta.IMDBID == "" {
+				meta.IMDBID = id
+			}
+		case "tvdb":
+			if n, err := strconv.Atoi(id); err == nil && meta.TVDBID == 0 {
+				meta.TVDBID = n
+			}
+		}
+		return " "
+	})
+}
+
+// hasExternalID 是否包含任一外部 ID
+func (meta *VideoMeta) hasExternalID() bool {
+	return meta.TMDBID != 0 || meta.IMDBID != "" || meta.TVDBID != 0
+}
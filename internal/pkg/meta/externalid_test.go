@@ -0,0 +1,51 @@
+package meta_test
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExternalIDs(t *testing.T) {
+	testCases := []struct {
+		title  string
+		name   string
+		year   int
+		tmdbID int
+		imdbID string
+		tvdbID int
+	}{
+		{title: "The Matrix (1999) {tmdb-603}.mkv", name: "The Matrix", year: 1999, tmdbID: 603},
+		{title: "The Matrix (1999) [tmdbid-603]", name: "The Matrix", year: 1999, tmdbID: 603},
+		{title: "The Matrix (1999) [tmdbid=603]", name: "The Matrix", year: 1999, tmdbID: 603},
+		{title: "[tmdbid-603] The Matrix (1999)", name: "The Matrix", year: 1999, tmdbID: 603},
+		{title: "The Matrix (1999) {imdb-tt0133093}", name: "The Matrix", year: 1999, imdbID: "tt0133093"},
+		{title: "Friends (1994) {tvdb-79168} [imdbid-tt0108778]", name: "Friends", year: 1994, imdbID: "tt0108778", tvdbID: 79168},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			vm := meta.ParseVideoMeta(tc.title)
+			require.Equal(t, tc.name, vm.GetTitle())
+			require.Equal(t, tc.year, vm.Year)
+			require.Equal(t, tc.tmdbID, vm.TMDBID)
+			require.Equal(t, tc.imdbID, vm.IMDBID)
+			require.Equal(t, tc.tvdbID, vm.TVDBID)
+		})
+	}
+
+	// 自定义媒体规则 {[tmdbid=xxx;...]} 由 recognize_controller 解析
+	require.Zero(t, meta.ParseVideoMeta("DOUBLE DECKER {[tmdbid=82046;type=tv;s=1]}").TMDBID)
+}
+
+func TestMergeFolderExternalIDs(t *testing.T) {
+	file := meta.ParseVideoMeta("Friends.S01E01.1080p.mkv")
+	folders := []*meta.VideoMeta{
+		meta.ParseVideoMeta("Season 1"),
+		meta.ParseVideoMeta("Friends (1994) {tvdb-79168}"),
+	}
+	sources := meta.MergeFolderMeta(file, folders...)
+	require.Equal(t, 79168, file.TVDBID)
+	require.Equal(t, meta.SourceGrandparent, sources["tvdb_id"])
+	require.Equal(t, 1994, file.Year)
+}
@@ -0,0 +1,162 @@
+package meta
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExtraType 附加内容类型枚举
+type ExtraType uint8
+
+const (
+	ExtraTypeNone            ExtraType = iota // 正片
+	ExtraTypeTrailer                          // 预告片
+	ExtraTypeFeaturette                       // 特辑
+	ExtraTypeBehindTheScenes                  // 幕后花絮
+	ExtraTypeDeletedScene                     // 删减片段
+	ExtraTypeInterview                        // 访谈
+	ExtraTypeNonCredit                        // 无字幕 OP/ED（NCOP/NCED）
+	ExtraTypeSpecial                          // 特别篇（SP/OVA/OAD），归入第 0 季
+)
+
+func (t ExtraType) String() string {
+	switch t {
+	case ExtraTypeTrailer:
+		return "Trailer"
+	case ExtraTypeFeaturette:
+		return "Featurette"
+	case ExtraTypeBehindTheScenes:
+		return "BehindTheScenes"
+	case ExtraTypeDeletedScene:
+		return "DeletedScene"
+	case ExtraTypeInterview:
+		return "Interview"
+	case ExtraTypeNonCredit:
+		return "NonCredit"
+	case ExtraTypeSpecial:
+		return "Special"
+	default:
+		return ""
+	}
+}
+
+func ParseExtraType(s string) ExtraType {
+	switch strings.ToLower(s) {
+	case "trailer":
+		return ExtraTypeTrailer
+	case "featurette":
+		return ExtraTypeFeaturette
+	case "behindthescenes":
+		return ExtraTypeBehindTheScenes
+	case "deletedscene":
+		return ExtraTypeDeletedScene
+	case "interview":
+		return ExtraTypeInterview
+	case "noncredit":
+		return ExtraTypeNonCredit
+	case "special":
+		return ExtraTypeSpecial
+	default:
+		return ExtraTypeNone
+	}
+}
+
+func (t ExtraType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+func (t *ExtraType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*t = ParseExtraType(s)
+	return nil
+}
+
+// IsExtra 是否为需要放入附加内容目录的类型（特别篇作为第 0 季剧集处理，不属于此类）
+func (t ExtraType) IsExtra() bool {
+	return t != ExtraTypeNone && t != ExtraTypeSpecial
+}
+
+// FolderName 附加内容在 Jellyfin/Plex 中对应的子目录名称
+func (t ExtraType) FolderName() string {
+	switch t {
+	case ExtraTypeTrailer:
+		return "trailers"
+	case ExtraTypeFeaturette:
+		return "featurettes"
+	case ExtraTypeBehindTheScenes:
+		return "behind the scenes"
+	case ExtraTypeDeletedScene:
+		return "deleted scenes"
+	case ExtraTypeInterview:
+		return "interviews"
+	case ExtraTypeNonCredit:
+		return "extras"
+	default:
+		return ""
+	}
+}
+
+var extraTypeRes = []struct {
+	re        *regexp.Regexp
+	extraType ExtraType
+}{
+	{regexp.MustCompile(`(?i)\b(?:trailers?|teasers?)\b|预告片?`), ExtraTypeTrailer},
+	{regexp.MustCompile(`(?i)\bfeaturettes?\b|\bmaking[\s._-]of\b|特辑`), ExtraTypeFeaturette},
+	{regexp.MustCompile(`(?i)\bbehind[\s._-]the[\s._-]scenes\b|花絮|幕后`), ExtraTypeBehindTheScenes},
+	{regexp.MustCompile(`(?i)\bdeleted[\s._-]scenes?\b|删减片段`), ExtraTypeDeletedScene},
+	{regexp.MustCompile(`(?i)\binterviews?\b|访谈|采访`), ExtraTypeInterview},
+	{regexp.MustCompile(`(?i)\bNC[\s._-]?(?:OP|ED)\d{0,2}[a-z]?\b|\bcreditless[\s._-](?:opening|ending)\b|无字幕(?:OP|ED)`), ExtraTypeNonCredit},
+}
+
+var (
+	specialRe        = regexp.MustCompile(`(?i)\b(?:SP|OVA|OAD)[\s._-]?(\d{1,3})?\b|\bspecials?\b|特别篇`)
+	extraTagRe       = regexp.MustCompile(`(?i)[\[【][^\]】]*[\]】]|\b\d{3,4}[pi]\b|\b[248]K\b`) // 关键字后允许出现的标签和分辨率
+	extraRemainderRe = regexp.MustCompile(`^[\s._-]*(?:\d{1,3}[\s._-]*)?$`)
+	extraNumberRe    = regexp.MustCompile(`^[\s._-]*(\d{1,3})\b`)
+	extraEpisodeRe   = regexp.MustCompile(`(?i)\bS\d{1,3}[\s._-]?E\d{1,4}\b`)
+)
+
+// parseExtra 识别附加内容类型，返回去掉附加内容关键字后的名称
+// 仅识别名称末尾的关键字（如 Movie-trailer、Show - NCED2 [1080p]），名称中间的关键字（如 The Interview 2014、Trailer Park Boys）不视为附加内容
+func (meta *VideoMeta) parseExtra(title string) string {
+	for _, item := range extraTypeRes {
+		loc := item.re.FindStringIndex(title)
+		if loc == nil || !isExtraKeyword(title, loc) {
+			continue
+		}
+		meta.ExtraType = item.extraType
+		return title[:loc[0]] + " " + title[loc[1]:]
+	}
+
+	if extraEpisodeRe.MatchString(title) { // 包含 SxxExx 时以季集为准，不识别为特别篇
+		return title
+	}
+	if m := specialRe.FindStringSubmatchIndex(title); m != nil && isExtraKeyword(title, m[:2]) {
+		meta.ExtraType = ExtraTypeSpecial
+		meta.MediaType = MediaTypeTV
+		meta.Season = 0
+		meta.TotalSeason = 1
+		if m[2] != -1 {
+			meta.Episode, _ = strconv.Atoi(title[m[2]:m[3]])
+			meta.TotalEpisode = 1
+			return title[:m[0]] + " " + title[m[1]:]
+		}
+		if n := extraNumberRe.FindStringSubmatchIndex(title[m[1]:]); n != nil { // 如「特别篇 02」
+			meta.Episode, _ = strconv.Atoi(title[m[1]+n[2] : m[1]+n[3]])
+			meta.TotalEpisode = 1
+			return title[:m[0]] + " " + title[m[1]+n[1]:]
+		}
+		return title[:m[0]] + " " + title[m[1]:]
+	}
+	return title
+}
+
+// isExtraKeyword 关键字位于名称末尾，后面只有编号、标签或分辨率
+func isExtraKeyword(title string, loc []int) bool {
+	return extraRemainderRe.MatchString(extraTagRe.ReplaceAllString(title[loc[1]:], " "))
+}
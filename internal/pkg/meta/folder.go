@@ -0,0 +1,162 @@
+package meta
+
+import (
+	"MediaTools/encode"
+	"regexp"
+	"slices"
+)
+
+// 字段来源
+const (
+	SourceFile        = "file"        // 文件名
+	SourceParent      = "parent"      // 父目录名
+	SourceGrandparent = "grandparent" // 祖父目录名
+)
+
+var folderSources = []string{SourceParent, SourceGrandparent}
+
+var specialsFolderRe = regexp.MustCompile(`(?i)^(specials?|sp|特别篇|特典)$`)
+
+// isSeasonFolder 是否为季目录（如 Season 2、S02、第二季、Specials），季目录不包含标题
+func isSeasonFolder(folder *VideoMeta) bool {
+	return specialsFolderRe.MatchString(folder.OrginalTitle) ||
+		(folder.Season != -1 && folder.GetTitle() == "")
+}
+
+// MergeFolderMeta 将父目录、祖父目录解析到的元数据合并到文件元数据中
+// folders 依次为父目录、祖父目录的元数据（可为 nil）
+// 合并优先级：
+//   - 标题：文件优先，文件没有标题时使用最近的包含标题的目录
+//   - 年份：标题来自目录或文件没有年份时使用该目录的年份
+//   - 季：季目录优先，其次文件，最后其他目录
+//   - 集、日期、分段：仅使用文件
+//   - 附加内容类型：文件优先，其次附加内容目录（父目录）
+//   - TMDB ID、IMDb ID、TVDB ID、媒体类型、版本、资源信息：文件优先，缺失时使用最近的目录
+//
+// 返回各字段的来源（SourceFile、SourceParent、SourceGrandparent）
+func MergeFolderMeta(file *VideoMeta, folders ...*VideoMeta) map[string]string {
+	sources := make(map[string]string)
+	if file.GetTitle() != "" {
+		sources["title"] = SourceFile
+	}
+	if file.Year != 0 {
+		sources["year"] = SourceFile
+	}
+	if file.Episode != -1 {
+		sources["episode"] = SourceFile
+	}
+
+	// 标题和年份：文件名包含标题时不使用目录标题（下载目录等普通目录名不是标题），仅在文件没有年份时使用目录年份
+	for i, folder := range folders {
+		if i >= len(folderSources) || folder == nil || isSeasonFolder(folder) || folder.GetTitle() == "" {
+			continue
+		}
+		if file.GetTitle() == "" {
+			file.CNTitle = folder.CNTitle
+			file.ENTitle = folder.ENTitle
+			sources["title"] = folderSources[i]
+		}
+		if folder.Year != 0 && (file.Year == 0 || sources["title"] == folderSources[i]) {
+			file.Year = folder.Year
+			sources["year"] = folderSources[i]
+		}
+		break // 只使用最近的包含标题的目录
+	}
+
+	// 附加内容：文件名没有附加内容关键字时使用父目录（如 Trailers、Interviews 等附加内容目录）
+	if file.ExtraType != ExtraTypeNone {
+		sources["extra_type"] = SourceFile
+	} else if len(folders) > 0 && folders[0] != nil && folders[0].ExtraType.IsExtra() && folders[0].GetTitle() == "" {
+		file.ExtraType = folders[0].ExtraType
+		sources["extra_type"] = SourceParent
+	}
+
+	// 季
+	seasonSource := ""
+	if file.Season != -1 {
+		seasonSource = SourceFile
+	}
+	for i, folder := range folders {
+		if i >= len(folderSources) || folder == nil {
+			continue
+		}
+		if isSeasonFolder(folder) {
+			file.Season, file.EndSeason, file.TotalSeason = folder.Season, folder.EndSeason, max(folder.TotalSeason, 1)
+			if specialsFolderRe.MatchString(folder.OrginalTitle) {
+				file.Season, file.EndSeason = 0, -1
+			}
+			seasonSource = folderSources[i]
+			break
+		}
+		if seasonSource == "" && folder.Season != -1 {
+			file.Season, file.EndSeason, file.TotalSeason = folder.Season, folder.EndSeason, folder.TotalSeason
+			seasonSource = folderSources[i]
+		}
+	}
+	if seasonSource != "" {
+		sources["season"] = seasonSource
+	}
+
+	// 其他字段，文件缺失时使用最近的目录
+	fill := func(field string, missing func(m *VideoMeta) bool, set func(dst, src *VideoMeta)) {
+		if !missing(file) {
+			sources[field] = SourceFile
+			return
+		}
+		for i, folder := range folders {
+			if i >= len(folderSources) || folder == nil || missing(folder) {
+				continue
+			}
+			set(file, folder)
+			sources[field] = folderSources[i]
+			return
+		}
+	}
+	fill("tmdb_id",
+		func(m *VideoMeta) bool { return m.TMDBID == 0 },
+		func(dst, src *VideoMeta) { dst.TMDBID = src.TMDBID })
+	fill("imdb_id",
+		func(m *VideoMeta) bool { return m.IMDBID == "" },
+		func(dst, src *VideoMeta) { dst.IMDBID = src.IMDBID })
+	fill("tvdb_id",
+		func(m *VideoMeta) bool { return m.TVDBID == 0 },
+		func(dst, src *VideoMeta) { dst.TVDBID = src.TVDBID })
+	fill("media_type",
+		func(m *VideoMeta) bool { return m.MediaType == MediaTypeUnknown },
+		func(dst, src *VideoMeta) { dst.MediaType = src.MediaType })
+	fill("resource_type",
+		func(m *VideoMeta) bool { return m.ResourceType == ResourceTypeUnknown },
+		func(dst, src *VideoMeta) { dst.ResourceType = src.ResourceType })
+	fill("resource_effect",
+		func(m *VideoMeta) bool { return len(m.ResourceEffect) == 0 },
+		func(dst, src *VideoMeta) { dst.ResourceEffect = slices.Clone(src.ResourceEffect) })
+	fill("resource_pix",
+		func(m *VideoMeta) bool { return m.ResourcePix == ResourcePixUnknown },
+		func(dst, src *VideoMeta) { dst.ResourcePix = src.ResourcePix })
+	fill("video_encode",
+		func(m *VideoMeta) bool { return m.VideoEncode == encode.VideoEncodeUnknown },
+		func(dst, src *VideoMeta) { dst.VideoEncode = src.VideoEncode })
+	fill("audio_encode",
+		func(m *VideoMeta) bool { return m.AudioEncode == encode.AudioEncodeUnknown },
+		func(dst, src *VideoMeta) { dst.AudioEncode = src.AudioEncode })
+	fill("platform",
+		func(m *VideoMeta) bool { return m.Platform == UnknownStreamingPlatform },
+		func(dst, src *VideoMeta) { dst.Platform = src.Platform })
+	fill("edition",
+		func(m *VideoMeta) bool { return m.Edition == "" },
+		func(dst, src *VideoMeta) { dst.Edition = src.Edition })
+	fill("release_groups",
+		func(m *VideoMeta) bool { return len(m.ReleaseGroups) == 0 },
+		func(dst, src *VideoMeta) { dst.ReleaseGroups = slices.Clone(src.ReleaseGroups) })
+
+	if file.MediaType == MediaTypeUnknown && (file.Season != -1 || file.Episode != -1) {
+		file.MediaType = MediaTypeTV
+		sources["media_type"] = sources["season"]
+	}
+	for field, source := range sources {
+		if source == "" {
+			delete(sources, field)
+		}
+	}
+	return sources
+}
@@ -0,0 +1,110 @@
+package meta_test
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFolderMeta(t *testing.T) {
+	testCases := []struct {
+		file    string
+		folders []string // 父目录、祖父目录
+		title   string
+		year    int
+		season  string
+		episode string
+		sources map[string]string
+	}{
+		{
+			file:    "03.mkv",
+			folders: []string{"Season 2", "Show Title (2019)"},
+			title:   "Show Title",
+			year:    2019,
+			season:  "S02",
+			episode: "E03",
+			sources: map[string]string{"title": meta.SourceGrandparent, "year": meta.SourceGrandparent, "season": meta.SourceParent, "episode": meta.SourceFile, "media_type": meta.SourceFile},
+		},
+		{
+			file:    "EP03.mkv",
+			folders: []string{"[Group] Show Title"},
+			title:   "Show Title",
+			season:  "S01",
+			episode: "E03",
+			sources: map[string]string{"title": meta.SourceParent, "season": meta.SourceFile, "episode": meta.SourceFile, "media_type": meta.SourceFile},
+		},
+		{
+			file:    "Show.Title.S01E02.1080p.mkv",
+			folders: []string{"Specials", "Show Title"},
+			title:   "Show Title",
+			season:  "S00",
+			episode: "E02",
+			sources: map[string]string{"title": meta.SourceFile, "season": meta.SourceParent, "episode": meta.SourceFile, "media_type": meta.SourceFile, "resource_pix": meta.SourceFile},
+		},
+		{
+			file:    "Iron.Man.2008.1080p.mkv",
+			folders: []string{"Marvel"},
+			title:   "Iron Man",
+			year:    2008,
+			sources: map[string]string{"title": meta.SourceFile, "year": meta.SourceFile, "resource_pix": meta.SourceFile},
+		},
+		{
+			file:    "Breaking.Bad.S01E03.1080p.mkv",
+			folders: []string{"Downloads", "user", "home"},
+			title:   "Breaking Bad",
+			season:  "S01",
+			episode: "E03",
+			sources: map[string]string{"title": meta.SourceFile, "season": meta.SourceFile, "episode": meta.SourceFile, "media_type": meta.SourceFile, "resource_pix": meta.SourceFile},
+		},
+		{
+			file:    "Breaking.Bad.S01E03.1080p.mkv",
+			folders: []string{"complete", "downloads", "media", "mnt"},
+			title:   "Breaking Bad",
+			season:  "S01",
+			episode: "E03",
+			sources: map[string]string{"title": meta.SourceFile, "season": meta.SourceFile, "episode": meta.SourceFile, "media_type": meta.SourceFile, "resource_pix": meta.SourceFile},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.file, func(t *testing.T) {
+			file := meta.ParseVideoMeta(tc.file)
+			var folders []*meta.VideoMeta
+			for _, folder := range tc.folders {
+				folders = append(folders, meta.ParseVideoMeta(folder))
+			}
+			sources := meta.MergeFolderMeta(file, folders...)
+			require.Equal(t, tc.title, file.GetTitle(), "标题不匹配")
+			require.Equal(t, tc.year, file.Year, "年份不匹配")
+			require.Equal(t, tc.season, file.GetSeasonStr(), "季信息不匹配")
+			require.Equal(t, tc.episode, file.GetEpisodeStr(), "集信息不匹配")
+			require.Equal(t, tc.sources, sources, "字段来源不匹配")
+		})
+	}
+}
+
+func TestMergeFolderExtraType(t *testing.T) {
+	testCases := []struct {
+		file      string
+		folders   []string
+		title     string
+		extraType meta.ExtraType
+	}{
+		{"Making the Movie.mkv", []string{"Featurettes", "Movie (2010)"}, "Making the Movie", meta.ExtraTypeFeaturette},
+		{"Cast.mkv", []string{"Interviews", "Movie (2010)"}, "Cast", meta.ExtraTypeInterview},
+		{"Movie.2010-trailer.mkv", []string{"Interviews"}, "Movie", meta.ExtraTypeTrailer},
+		{"The.Interview.2014.1080p.mkv", []string{"The Interview (2014)"}, "The Interview", meta.ExtraTypeNone},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.file, func(t *testing.T) {
+			file := meta.ParseVideoMeta(tc.file)
+			var folders []*meta.VideoMeta
+			for _, folder := range tc.folders {
+				folders = append(folders, meta.ParseVideoMeta(folder))
+			}
+			meta.MergeFolderMeta(file, folders...)
+			require.Equal(t, tc.title, file.GetTitle(), "标题不匹配")
+			require.Equal(t, tc.extraType, file.ExtraType, "附加内容类型不匹配")
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // VideoMeta 解析视频媒体名字信息结构体
@@ -22,6 +23,8 @@ type VideoMeta struct {
 	Year           int       // 年份
 	MediaType      MediaType // 媒体类型
 	TMDBID         int       // TMDB ID
+	IMDBID         string    // IMDb ID（如 tt0133093）
+	TVDBID         int       // TVDB ID
 
 	// 资源信息
 	ResourceType   ResourceType       // 来源/介质
@@ -36,12 +39,17 @@ type VideoMeta struct {
 	Customization  []string           // 自定义词
 
 	// 电视剧相关·
-	Season       int // 季
-	EndSeason    int // 结束季
-	TotalSeason  int // 总季数
-	Episode      int // 集
-	EndEpisode   int // 结束集
-	TotalEpisode int // 总集数
+	Season       int    // 季
+	EndSeason    int    // 结束季
+	TotalSeason  int    // 总季数
+	Episode      int    // 集
+	EndEpisode   int    // 结束集
+	TotalEpisode int    // 总集数
+	Ordering     string // 剧集编号方式，为空时按播出顺序，见 OrderingAired、OrderingAbsolute，其他值视为 TMDB 剧集组 ID
+	AirDate      string // 播出日期（2006-01-02），按日期命名的日播节目、综艺使用
+
+	ExtraType ExtraType // 附加内容类型（预告片、特辑、NCOP/NCED、特别篇等）
+	Edition   string    // 版本（Director's Cut、Extended、IMAX 等）
 }
 
 // 获取标题
@@ -67,6 +75,11 @@ func (meta *VideoMeta) GetTitles() []string {
 	return titles
 }
 
+// HasSeason 是否明确指定了季数（名称、目录或规则中包含季数），电视剧缺少季数时默认的第 1 季不算
+func (meta *VideoMeta) HasSeason() bool {
+	return meta.Season != -1 && meta.TotalSeason > 0
+}
+
 func (meta *VideoMeta) GetSeasons() []int {
 	if meta.MediaType != MediaTypeTV {
 		return nil
@@ -147,13 +160,28 @@ func ParseVideoMeta(title string) *VideoMeta {
 		meta.IsFile = true
 	}
 
+	title = meta.parseExternalIDs(title) // 识别外部 ID 标记并去掉（需在去掉发布组之前，避免开头的 [tmdbid-xxx] 被当作发布组）
+
 	loc := nameNoBeginRe.FindStringIndex(title) // 去掉名称中第1个[]的内容（一般是发布组）
 	if loc != nil {
 		title = title[:loc[0]] + title[loc[1]:]
+		for _, m := range absoluteEpisodeRe.FindAllStringSubmatchIndex(title, -1) { // 字幕组的三、四位绝对集数
+			episode, _ := strconv.Atoi(title[m[2]:m[3]])
+			if episode >= YearMin && episode <= YearMax { // 「Title - 2022 [1080p]」中的是年份
+				continue
+			}
+			meta.Episode = episode
+			meta.TotalEpisode = 1
+			meta.MediaType = MediaTypeTV
+			title = title[:m[0]] + " " + title[m[5]:]
+			break
+		}
 	}
+	title = meta.parseEdition(title)                        // 识别版本并去掉关键字（需在特别篇之前，避免 Special Edition 被识别为特别篇）
+	title = meta.parseExtra(title)                          // 识别附加内容并去掉关键字
 	title = yearRangeRe.ReplaceAllString(title, "${1}${2}") // 把xxxx-xxxx年份换成前一个年份，常出现在季集上
 	title = fileSizeRe.ReplaceAllString(title, "")          // 把大小去掉
-	title = dateFmtRe.ReplaceAllString(title, "")           // 把年月日去掉
+	title = meta.parseAirDate(title)                        // 提取年月日并去掉
 	title = strings.TrimSpace(title)                        // 去掉首尾空格
 	meta.ProcessedTitle = title
 
@@ -215,6 +243,34 @@ func ParseVideoMeta(title string) *VideoMeta {
 	return meta
 }
 
+// parseAirDate 提取名称中的年月日作为播出日期，返回去掉日期后的名称
+// 位于名称开头的日期一般是发布或下载日期，不作为播出日期
+func (meta *VideoMeta) parseAirDate(title string) string {
+	for _, re := range []*regexp.Regexp{dateFmtRe, dateCompactRe} {
+		for _, m := range re.FindAllStringSubmatchIndex(title, -1) {
+			if meta.AirDate != "" {
+				break
+			}
+			if strings.TrimSpace(title[:m[0]]) == "" {
+				continue
+			}
+			year, _ := strconv.Atoi(title[m[2]:m[3]])
+			month, _ := strconv.Atoi(title[m[4]:m[5]])
+			day, _ := strconv.Atoi(title[m[6]:m[7]])
+			date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+			if year < YearMin || year > YearMax || date.Month() != time.Month(month) || date.Day() != day {
+				continue // 无效日期
+			}
+			meta.AirDate = date.Format(time.DateOnly)
+			if meta.MediaType == MediaTypeUnknown {
+				meta.MediaType = MediaTypeTV
+			}
+		}
+		title = re.ReplaceAllString(title, "")
+	}
+	return title
+}
+
 // 识别 Part
 func (meta *VideoMeta) parsePart(s *parseState) {
 	if meta.GetTitle() == "" {
@@ -625,8 +681,8 @@ func (meta *VideoMeta) parseSeason(s *parseState) {
 	} else if strings.ToUpper(token) == "SEASON" && meta.Season == -1 {
 		// 遇到SEASON关键词
 		s.lastType = lastTokenTypeSeason
-	} else if meta.MediaType == MediaTypeTV && meta.Season == -1 {
-		// 如果已确定为电视剧类型但没有季数，默认为第1季
+	} else if meta.MediaType == MediaTypeTV && meta.Season == -1 && meta.AirDate == "" {
+		// 如果已确定为电视剧类型但没有季数，默认为第1季（按播出日期命名的剧集在识别后按日期查找季）
 		defaultSeason := 1
 		meta.Season = defaultSeason
 	}
@@ -1303,19 +1359,19 @@ func (meta *VideoMeta) isInEpisode(episode int) bool {
 	return episode >= meta.Episode && episode <= meta.EndEpisode
 }
 
+// ParseVideoMetaByPath 根据文件路径解析媒体数据
+// 结合文件名、父目录和祖父目录名称解析，合并规则见 MergeFolderMeta
 func ParseVideoMetaByPath(p string) *VideoMeta {
-	idx := 3
-	parts := strings.Split(p, "/")
-	names := make([]string, idx)
-	for i := len(parts) - 1; i >= 0; i-- {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	file := ParseVideoMeta(parts[len(parts)-1])
+	var folders []*VideoMeta
+	for i := len(parts) - 2; i >= 0 && len(folders) < len(folderSources); i-- {
 		name := strings.TrimSpace(nameMovieWordsRe.ReplaceAllString(parts[i], ""))
-		if name != "" {
-			idx--
-			names[idx] = name
-		}
-		if idx == 0 { // 只取最后三个部分作为名称
-			break
+		if name == "" {
+			continue
 		}
+		folders = append(folders, ParseVideoMeta(name))
 	}
-	return ParseVideoMeta(strings.Join(names, " "))
+	MergeFolderMeta(file, folders...)
+	return file
 }
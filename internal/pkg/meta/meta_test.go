@@ -1114,6 +1114,24 @@ func TestParseVideoMeta(t *testing.T) {
 				version:        1,
 			},
 		},
+		{
+			input: "[SubsPlease] One Piece - 1089 (1080p) [8B4A3E5C].mkv",
+			expected: expectedMeta{
+				mediaType:      meta.MediaTypeTV,
+				cntitle:        "",
+				entitle:        "One Piece",
+				year:           0,
+				part:           "",
+				season:         "S01",
+				episode:        "E1089",
+				resourcePix:    meta.ResourcePix1080p,
+				resourceType:   meta.ResourceTypeUnknown,
+				resourceEffect: make([]meta.ResourceEffect, 0),
+				videoEncode:    encode.VideoEncodeUnknown,
+				audioEncode:    encode.AudioEncodeUnknown,
+				version:        1,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1137,6 +1155,118 @@ func TestParseVideoMeta(t *testing.T) {
 	}
 }
 
+func TestParseAbsoluteEpisodeYear(t *testing.T) {
+	testCases := []struct {
+		input   string
+		title   string
+		year    int
+		episode string
+	}{
+		{"[Nekomoe kissaten] Suzume no Tojimari - 2022 [BDRip 1080p].mkv", "Suzume no Tojimari", 2022, ""},
+		{"[VCB-Studio] Kimi no Na wa - 2016 [Ma10p_1080p].mkv", "Kimi no Na wa", 2016, ""},
+		{"[SubsPlease] One Piece - 1089v2 [1080p].mkv", "One Piece", 0, "E1089"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			m := meta.ParseVideoMeta(tc.input)
+			require.Equal(t, tc.title, m.GetTitle(), "标题不匹配")
+			require.Equal(t, tc.year, m.Year, "年份不匹配")
+			require.Equal(t, tc.episode, m.GetEpisodeStr(), "集信息不匹配")
+		})
+	}
+}
+
+func TestHasSeason(t *testing.T) {
+	require.False(t, meta.ParseVideoMeta("[SubsPlease] One Piece - 1089 (1080p).mkv").HasSeason()) // 默认的第 1 季
+	require.True(t, meta.ParseVideoMeta("Show.S01E05.1080p.mkv").HasSeason())
+	require.True(t, meta.ParseVideoMeta("Show Season 2 EP05.mkv").HasSeason())
+	require.False(t, meta.ParseVideoMeta("Movie.2020.1080p.mkv").HasSeason())
+}
+
+func TestParseAirDate(t *testing.T) {
+	testCases := []struct {
+		input   string
+		title   string
+		airDate string
+	}{
+		{"The.Daily.Show.2024.05.17.1080p.WEB.h264-EDITH.mkv", "The Daily Show", "2024-05-17"},
+		{"向往的生活 2024-5-3 1080p.mp4", "向往的生活", "2024-05-03"},
+		{"奔跑吧 20231027 4K.mp4", "奔跑吧", "2023-10-27"},
+		{"Show.2024.13.40.1080p.mkv", "Show", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			m := meta.ParseVideoMeta(tc.input)
+			require.Equal(t, tc.title, m.GetTitle(), "标题不匹配")
+			require.Equal(t, tc.airDate, m.AirDate, "播出日期不匹配")
+			if tc.airDate != "" {
+				require.Equal(t, meta.MediaTypeTV, m.MediaType, "媒体类型不匹配")
+				require.Equal(t, -1, m.Episode, "集数不匹配")
+				require.Equal(t, -1, m.Season, "季数不匹配") // 季数在识别后按播出日期查找
+			}
+		})
+	}
+}
+
+func TestParseExtra(t *testing.T) {
+	testCases := []struct {
+		input     string
+		title     string
+		extraType meta.ExtraType
+		season    int
+		episode   int
+	}{
+		{"Inception.2010.Trailer.mkv", "Inception", meta.ExtraTypeTrailer, -1, -1},
+		{"The.Dark.Knight.2008.Behind.the.Scenes.mkv", "The Dark Knight", meta.ExtraTypeBehindTheScenes, -1, -1},
+		{"Movie.2019.Deleted.Scenes.mkv", "Movie", meta.ExtraTypeDeletedScene, -1, -1},
+		{"[Group] Show - NCED2 [1080p].mkv", "Show", meta.ExtraTypeNonCredit, -1, -1},
+		{"Show.S01.SP01.1080p.mkv", "Show", meta.ExtraTypeSpecial, 0, 1},
+		{"Show 特别篇 02 1080p.mp4", "Show", meta.ExtraTypeSpecial, 0, 2},
+		{"[Group] Show - OVA [1080p].mkv", "Show", meta.ExtraTypeSpecial, 0, -1},
+		{"Trailer Park Boys S01E01 1080p.mkv", "Trailer Park Boys", meta.ExtraTypeNone, 1, 1},
+		{"Movie (2010)-featurette.mkv", "Movie", meta.ExtraTypeFeaturette, -1, -1},
+		{"Movie.2010-interview.mkv", "Movie", meta.ExtraTypeInterview, -1, -1},
+		{"The.Interview.2014.1080p.mkv", "The Interview", meta.ExtraTypeNone, -1, -1},
+		{"The.Special.2020.mkv", "The Special", meta.ExtraTypeNone, -1, -1},
+		{"Ova.2020.mkv", "Ova", meta.ExtraTypeNone, -1, -1},
+		{"Show.S01E01.Special.Edition.mkv", "Show", meta.ExtraTypeNone, 1, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			m := meta.ParseVideoMeta(tc.input)
+			require.Equal(t, tc.title, m.GetTitle(), "标题不匹配")
+			require.Equal(t, tc.extraType, m.ExtraType, "附加内容类型不匹配")
+			require.Equal(t, tc.season, m.Season, "季数不匹配")
+			require.Equal(t, tc.episode, m.Episode, "集数不匹配")
+		})
+	}
+}
+
+func TestParseEdition(t *testing.T) {
+	testCases := []struct {
+		input   string
+		title   string
+		edition string
+	}{
+		{"The.Lord.of.the.Rings.2001.EXTENDED.1080p.BluRay.x264.mkv", "The Lord of the Rings", "Extended"},
+		{"Kingdom.of.Heaven.2005.Directors.Cut.1080p.mkv", "Kingdom of Heaven", "Director's Cut"},
+		{"Dune.2021.IMAX.2160p.WEB-DL.mkv", "Dune", "IMAX"},
+		{"Aliens.1986.Special.Edition.1080p.mkv", "Aliens", "Special Edition"},
+		{"Avatar.2009.Extended.Remastered.1080p.mkv", "Avatar", "Extended Remastered"},
+		{"Movie (2020) {edition-Collector's Cut}.mkv", "Movie", "Collector's Cut"},
+		{"天国王朝 导演剪辑版 2005 1080p.mkv", "天国王朝", "Director's Cut"},
+		{"The.Final.Cut.2004.1080p.mkv", "The Final Cut", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			m := meta.ParseVideoMeta(tc.input)
+			require.Equal(t, tc.title, m.GetTitle(), "标题不匹配")
+			require.Equal(t, tc.edition, m.Edition, "版本不匹配")
+			require.Equal(t, meta.ExtraTypeNone, m.ExtraType, "附加内容类型不匹配")
+		})
+	}
+}
+
 func TestParseVideoMetaByPath(t *testing.T) {
 	testCases := []struct {
 		path     string
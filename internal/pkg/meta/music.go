@@ -0,0 +1,109 @@
+package meta
+
+import (
+	pathlib "path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MusicMeta 音乐文件元数据
+type MusicMeta struct {
+	OrginalTitle string // 原始文件名
+	Title        string // 曲目标题
+	Artist       string // 艺术家
+	AlbumArtist  string // 专辑艺术家
+	Album        string // 专辑
+	Track        int    // 音轨号，0 表示未知
+	TrackTotal   int    // 音轨总数
+	Disc         int    // 碟号，0 表示未知
+	DiscTotal    int    // 碟片总数
+	Year         int    // 年份
+	Genre        string // 流派
+}
+
+var (
+	// 1-01 Title、1.01 - Title
+	musicDiscTrackRe = regexp.MustCompile(`^(\d{1,2})[-.](\d{1,3})(?:\s*[-._]\s*|\s+)(.+)$`)
+	// 01 Title、01. Title、01 - Title、01_Title
+	musicTrackRe = regexp.MustCompile(`^(\d{1,3})(?:\s*[-._]\s*|\s+)(.+)$`)
+	// CD1、CD 2、Disc 1、Disk2、碟1
+	musicDiscFolderRe = regexp.MustCompile(`(?i)^(?:cd|dis[ck]|碟)\s*(\d{1,2})$`)
+	// 专辑目录中的年份：(2005)、[2005]、2005 -
+	musicYearRe = regexp.MustCompile(`[(\[]((?:19|20)\d{2})[)\]]|^((?:19|20)\d{2})\s*-\s+`)
+	// 专辑目录中的格式、音质等标记：[FLAC]、(24bit-96kHz)、{CUE}
+	musicTagRe = regexp.MustCompile(`(?i)[\[({][^\])}]*(?:flac|ape|wav|mp3|aac|alac|dsd|dsf|cue|log|bit|khz|kbps|hi-?res|web|cd|vinyl|lossless|无损)[^\])}]*[\])}]`)
+)
+
+// ParseMusicMeta 根据文件路径解析音乐元数据，用于音频标签缺失时补全
+// 文件名解析碟号、音轨号、标题（「艺术家 - 标题」时同时解析艺术家），
+// 父目录解析专辑、年份（「艺术家 - 专辑」时同时解析专辑艺术家），CD1、Disc 2 等碟片目录解析碟号并继续向上查找专辑目录，
+// 专辑目录的上级目录作为专辑艺术家
+// stopDir 为媒体库源目录（可为空），向上查找目录时不会越过该目录
+func ParseMusicMeta(p string, stopDir string) *MusicMeta {
+	name := pathlib.Base(p)
+	m := &MusicMeta{OrginalTitle: name}
+	base := strings.TrimSpace(strings.TrimSuffix(name, pathlib.Ext(name)))
+
+	if match := musicDiscTrackRe.FindStringSubmatch(base); match != nil {
+		m.Disc, _ = strconv.Atoi(match[1])
+		m.Track, _ = strconv.Atoi(match[2])
+		base = match[3]
+	} else if match := musicTrackRe.FindStringSubmatch(base); match != nil {
+		m.Track, _ = strconv.Atoi(match[1])
+		base = match[2]
+	}
+	if artist, title, ok := strings.Cut(base, " - "); ok {
+		m.Artist, m.Title = strings.TrimSpace(artist), strings.TrimSpace(title)
+	} else {
+		m.Title = strings.TrimSpace(base)
+	}
+
+	stopDir = strings.TrimSuffix(stopDir, "/")
+	var folders []string
+	for dir := pathlib.Dir(p); len(folders) < 3; dir = pathlib.Dir(dir) {
+		if dir == "/" || dir == "." || dir == stopDir ||
+			(stopDir != "" && !strings.HasPrefix(dir, stopDir+"/")) {
+			break
+		}
+		folders = append(folders, pathlib.Base(dir))
+	}
+	if len(folders) > 0 {
+		if match := musicDiscFolderRe.FindStringSubmatch(strings.TrimSpace(folders[0])); match != nil {
+			if m.Disc == 0 {
+				m.Disc, _ = strconv.Atoi(match[1])
+			}
+			folders = folders[1:]
+		}
+	}
+	if len(folders) > 0 {
+		album := folders[0]
+		if match := musicYearRe.FindStringSubmatch(album); match != nil {
+			m.Year, _ = strconv.Atoi(match[1] + match[2])
+			album = strings.Replace(album, match[0], " ", 1)
+		}
+		album = musicTagRe.ReplaceAllString(album, " ")
+		album = strings.Join(strings.Fields(album), " ")
+		if artist, title, ok := strings.Cut(album, " - "); ok {
+			m.AlbumArtist, album = strings.TrimSpace(artist), strings.TrimSpace(title)
+		}
+		m.Album = strings.Trim(album, " -")
+	}
+	if m.AlbumArtist == "" && len(folders) > 1 {
+		m.AlbumArtist = strings.TrimSpace(folders[1])
+	}
+	return m
+}
+
+// IsDiscFolder 是否为多碟专辑中的碟片目录（CD1、Disc 2 等）
+func IsDiscFolder(name string) bool {
+	return musicDiscFolderRe.MatchString(strings.TrimSpace(name))
+}
+
+// GetAlbumArtist 获取专辑艺术家，未知时使用艺术家
+func (m *MusicMeta) GetAlbumArtist() string {
+	if m.AlbumArtist != "" {
+		return m.AlbumArtist
+	}
+	return m.Artist
+}
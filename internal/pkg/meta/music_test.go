@@ -0,0 +1,43 @@
+package meta_test
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMusicMeta(t *testing.T) {
+	testCases := []struct {
+		path    string
+		stopDir string
+		want    meta.MusicMeta
+	}{
+		{
+			path: "/music/Pink Floyd/The Dark Side of the Moon (1973) [FLAC]/04 - Time.flac",
+			want: meta.MusicMeta{Title: "Time", AlbumArtist: "Pink Floyd", Album: "The Dark Side of the Moon", Track: 4, Year: 1973},
+		},
+		{
+			path:    "/downloads/周杰伦 - 十一月的萧邦 [2005] [24bit-96kHz]/CD2/1-03 周杰伦 - 夜曲.flac",
+			stopDir: "/downloads",
+			want:    meta.MusicMeta{Title: "夜曲", Artist: "周杰伦", AlbumArtist: "周杰伦", Album: "十一月的萧邦", Track: 3, Disc: 1, Year: 2005},
+		},
+		{
+			path:    "/downloads/Discovery/CD 2/05. One More Time.m4a",
+			stopDir: "/downloads",
+			want:    meta.MusicMeta{Title: "One More Time", Album: "Discovery", Track: 5, Disc: 2},
+		},
+		{
+			path:    "/downloads/2001 - Discovery/Daft Punk - Discovery.flac",
+			stopDir: "/downloads/",
+			want:    meta.MusicMeta{Title: "Discovery", Artist: "Daft Punk", Album: "Discovery", Year: 2001},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			m := meta.ParseMusicMeta(tc.path, tc.stopDir)
+			m.OrginalTitle = ""
+			require.Equal(t, tc.want, *m)
+		})
+	}
+}
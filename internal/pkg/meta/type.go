@@ -18,6 +18,7 @@ const (
 	MediaTypeUnknown MediaType = iota // 未知
 	MediaTypeMovie                    //电影
 	MediaTypeTV                       //电视剧
+	MediaTypeMusic                    // 音乐
 )
 
 func (mtype MediaType) String() string {
@@ -26,6 +27,8 @@ func (mtype MediaType) String() string {
 		return "Movie"
 	case MediaTypeTV:
 		return "TV"
+	case MediaTypeMusic:
+		return "Music"
 	default:
 		return "UnknownMediaType"
 	}
@@ -37,6 +40,8 @@ func ParseMediaType(s string) MediaType {
 		return MediaTypeMovie
 	case "tv":
 		return MediaTypeTV
+	case "music":
+		return MediaTypeMusic
 	default:
 		return MediaTypeUnknown
 	}
@@ -55,12 +60,35 @@ func (mtype *MediaType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// 剧集编号方式
+const (
+	OrderingAired    = "aired"    // 按 TMDB 播出顺序（默认）
+	OrderingAbsolute = "absolute" // 绝对集数，需要按各季集数换算
+)
+
+var episodeGroupIDRe = regexp.MustCompile(`^[0-9a-f]{24}$`) // TMDB 剧集组 ID
+
+// ParseOrdering 解析剧集编号方式，支持 aired、absolute 和 TMDB 剧集组 ID
+func ParseOrdering(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case s == OrderingAired, s == OrderingAbsolute, episodeGroupIDRe.MatchString(s):
+		return s, true
+	default:
+		return "", false
+	}
+}
+
 var (
 	// 基础预处理正则
 	nameNoBeginRe = regexp.MustCompile(`^[\[【].+?[\]】]`)
 	yearRangeRe   = regexp.MustCompile(`([\s.]+)(\d{4})-(\d{4})`)
 	fileSizeRe    = regexp.MustCompile(`(?i)[0-9.]+\s*[MGT]i?B\b`)
-	dateFmtRe     = regexp.MustCompile(`\d{4}[\s._-]\d{1,2}[\s._-]\d{1,2}`)
+	dateFmtRe     = regexp.MustCompile(`(\d{4})[\s._-](\d{1,2})[\s._-](\d{1,2})`)
+	dateCompactRe = regexp.MustCompile(`\b((?:19|20)\d{2})(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])\b`) // 20240517 格式的日期
+	// 字幕组发布的长篇动画常用绝对集数，如「[SubsPlease] One Piece - 1089 (1080p)」
+	// 结尾的分隔符只用于判断边界，替换时保留（RE2 不支持前瞻）
+	absoluteEpisodeRe = regexp.MustCompile(`\s-\s(\d{3,4})((?:[vV]\d+)?)(?:[\s\[(]|$)`)
 
 	// 季集识别正则
 	seasonRe      = regexp.MustCompile(`(?i)S(\d{3})|^S(\d{1,3})$|S(\d{1,3})E|S(\d{1,3})$`)
@@ -95,7 +123,7 @@ var (
 		`|欧美|西德|日韩` +
 		`|超高清|高清|无水印|下载|蓝光|翡翠台|梦幻天堂·龙网` +
 		`|最终季|合集|[多中国英葡法俄日韩德意西印泰台港粤双文语简繁体特效内封官译外挂]+字幕|版本|出品|台版|港版|\w+字幕组|\w+字幕社` +
-		`|未删减版|UNCUT$|UNRATE$|WITH EXTRAS$|RERIP$|SUBBED$|PROPER$|REPACK$|SEASON$|EPISODE$|Complete$|Extended$|Extended Version$` +
+		`|未删减版|UNCUT$|UNRATE$|WITH EXTRAS$|RERIP$|SUBBED$|PROPER$|REPACK$|SEASON$|EPISODE$|Complete$` +
 		`|S\d{2}\s*-\s*S\d{2}|S\d{2}|\s+S\d{1,2}|EP?\d{2,4}\s*-\s*EP?\d{2,4}|EP?\d{2,4}|\s+EP?\d{1,4}` +
 		`|CD[\s.]*[1-9]|DVD[\s.]*[1-9]|DISK[\s.]*[1-9]|DISC[\s.]*[1-9]` +
 		`|[248]K|\d{3,4}[PIX]+` +
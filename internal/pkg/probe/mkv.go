@@ -0,0 +1,393 @@
+package probe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+// Matroska 元素 ID
+const (
+	mkvEBML                 = 0x1A45DFA3
+	mkvDocType              = 0x4282
+	mkvSegment              = 0x18538067
+	mkvCluster              = 0x1F43B675
+	mkvInfo                 = 0x1549A966
+	mkvTimecodeScale        = 0x2AD7B1
+	mkvDuration             = 0x4489
+	mkvTracks               = 0x1654AE6B
+	mkvTrackEntry           = 0xAE
+	mkvTrackType            = 0x83
+	mkvCodecID              = 0x86
+	mkvCodecPrivate         = 0x63A2
+	mkvLanguage             = 0x22B59C
+	mkvLanguageIETF         = 0x22B59D
+	mkvFlagForced           = 0x55AA
+	mkvVideo                = 0xE0
+	mkvPixelWidth           = 0xB0
+	mkvPixelHeight          = 0xBA
+	mkvColour               = 0x55B0
+	mkvBitsPerChannel       = 0x55B2
+	mkvTransferChar         = 0x55BA
+	mkvAudio                = 0xE1
+	mkvChannels             = 0x9F
+	mkvBlockAdditionMapping = 0x41E4
+	mkvBlockAddIDType       = 0x41E7
+)
+
+// Matroska 轨道类型
+const (
+	mkvTrackVideo    = 1
+	mkvTrackAudio    = 2
+	mkvTrackSubtitle = 0x11
+)
+
+const (
+	maxEBMLHeader      = 4 << 10  // EBML 头的最大读取长度
+	maxMatroskaElement = 16 << 20 // Info、Tracks 元素的最大读取长度
+)
+
+// 传输特性（ISO/IEC 23091-2），用于识别 HDR
+const (
+	transferPQ  = 16
+	transferHLG = 18
+)
+
+func probeMatroska(r *mediaio.Reader) (*Info, error) {
+	id, size, unknown, err := readElementHeader(r)
+	if err != nil || id != mkvEBML || unknown {
+		return nil, fmt.Errorf("读取 EBML 头失败: %v", err)
+	}
+	if size > maxEBMLHeader {
+		return nil, fmt.Errorf("EBML 头过大: %d", size)
+	}
+	header, err := r.ReadFull(size)
+	if err != nil {
+		return nil, fmt.Errorf("读取 EBML 头失败: %w", err)
+	}
+	info := &Info{Container: "matroska"}
+	forEachElement(header, func(id uint32, data []byte) {
+		if id == mkvDocType {
+			info.Container = strings.TrimRight(string(data), "\x00")
+		}
+	})
+
+	id, _, _, err = readElementHeader(r)
+	if err != nil || id != mkvSegment {
+		return nil, fmt.Errorf("读取 Segment 失败: %v", err)
+	}
+
+	var foundInfo, foundTracks bool
+	for !foundInfo || !foundTracks {
+		id, size, unknown, err := readElementHeader(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return nil, err
+		}
+		if id == mkvCluster { // 媒体数据开始，轨道信息一般在此之前
+			break
+		}
+		if unknown {
+			return nil, fmt.Errorf("元素 0x%X 长度未知", id)
+		}
+		switch id {
+		case mkvInfo, mkvTracks:
+			if size > maxMatroskaElement {
+				return nil, fmt.Errorf("元素 0x%X 过大: %d", id, size)
+			}
+			data, err := r.ReadFull(size)
+			if err != nil {
+				return nil, err
+			}
+			if id == mkvInfo {
+				parseMatroskaInfo(info, data)
+				foundInfo = true
+			} else {
+				parseMatroskaTracks(info, data)
+				foundTracks = true
+			}
+		default:
+			if err := r.Skip(size); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !foundTracks {
+		return nil, fmt.Errorf("未找到轨道信息")
+	}
+	return info, nil
+}
+
+func parseMatroskaInfo(info *Info, data []byte) {
+	scale := uint64(1000000)
+	var duration float64
+	forEachElement(data, func(id uint32, data []byte) {
+		switch id {
+		case mkvTimecodeScale:
+			scale = readUint(data)
+		case mkvDuration:
+			duration = readFloat(data)
+		}
+	})
+	info.Duration = time.Duration(duration * float64(scale))
+}
+
+func parseMatroskaTracks(info *Info, data []byte) {
+	forEachElement(data, func(id uint32, entry []byte) {
+		if id != mkvTrackEntry {
+			return
+		}
+		var (
+			trackType   uint64
+			codecID     string
+			language    = "eng" // Matroska 默认语言
+			forced      bool
+			video       VideoStream
+			channels    int
+			codecPriv   []byte
+			dolbyVision bool
+		)
+		forEachElement(entry, func(id uint32, data []byte) {
+			switch id {
+			case mkvTrackType:
+				trackType = readUint(data)
+			case mkvCodecID:
+				codecID = string(data)
+			case mkvCodecPrivate:
+				codecPriv = data
+			case mkvLanguage:
+				language = string(data)
+			case mkvLanguageIETF:
+				if lang, _, _ := strings.Cut(string(data), "-"); lang != "" && language == "eng" {
+					language = lang
+				}
+			case mkvFlagForced:
+				forced = readUint(data) == 1
+			case mkvVideo:
+				parseMatroskaVideo(&video, data)
+			case mkvAudio:
+				forEachElement(data, func(id uint32, data []byte) {
+					if id == mkvChannels {
+						channels = int(readUint(data))
+					}
+				})
+			case mkvBlockAdditionMapping:
+				forEachElement(data, func(id uint32, data []byte) {
+					if id == mkvBlockAddIDType {
+						switch string(binary.BigEndian.AppendUint32(nil, uint32(readUint(data)))) {
+						case "dvcC", "dvvC", "dvwC":
+							dolbyVision = true
+						}
+					}
+				})
+			}
+		})
+
+		switch trackType {
+		case mkvTrackVideo:
+			video.Codec = matroskaCodec(codecID)
+			if video.BitDepth == 0 && video.Codec == "hevc" {
+				video.BitDepth = hvcCBitDepth(codecPriv)
+			}
+			if dolbyVision {
+				video.HDR = DolbyVision
+			}
+			info.Videos = append(info.Videos, video)
+		case mkvTrackAudio:
+			info.Audios = append(info.Audios, AudioStream{Codec: matroskaCodec(codecID), Channels: channels, Language: language})
+		case mkvTrackSubtitle:
+			info.Subtitles = append(info.Subtitles, SubtitleStream{Codec: matroskaCodec(codecID), Language: language, Forced: forced})
+		}
+	})
+}
+
+func parseMatroskaVideo(video *VideoStream, data []byte) {
+	forEachElement(data, func(id uint32, data []byte) {
+		switch id {
+		case mkvPixelWidth:
+			video.Width = int(readUint(data))
+		case mkvPixelHeight:
+			video.Height = int(readUint(data))
+		case mkvColour:
+			forEachElement(data, func(id uint32, data []byte) {
+				switch id {
+				case mkvBitsPerChannel:
+					video.BitDepth = int(readUint(data))
+				case mkvTransferChar:
+					video.HDR = transferHDR(readUint(data))
+				}
+			})
+		}
+	})
+}
+
+func transferHDR(transfer uint64) string {
+	switch transfer {
+	case transferPQ:
+		return HDR10
+	case transferHLG:
+		return HLG
+	default:
+		return ""
+	}
+}
+
+// hvcCBitDepth 从 HEVCDecoderConfigurationRecord 中读取亮度位深
+func hvcCBitDepth(hvcC []byte) int {
+	if len(hvcC) < 18 {
+		return 0
+	}
+	return int(hvcC[17]&0x07) + 8
+}
+
+// Matroska CodecID 前缀到编码名称的映射
+var matroskaCodecs = []struct {
+	prefix string
+	codec  string
+}{
+	{"V_MPEG4/ISO/AVC", "h264"},
+	{"V_MPEGH/ISO/HEVC", "hevc"},
+	{"V_AV1", "av1"},
+	{"V_VP9", "vp9"},
+	{"V_VP8", "vp8"},
+	{"V_MPEG2", "mpeg2"},
+	{"V_MPEG4/", "mpeg4"},
+	{"V_MS/VFW/FOURCC", "vfw"},
+	{"A_AAC", "aac"},
+	{"A_AC3", "ac3"},
+	{"A_EAC3", "eac3"},
+	{"A_DTS", "dts"},
+	{"A_TRUEHD", "truehd"},
+	{"A_FLAC", "flac"},
+	{"A_OPUS", "opus"},
+	{"A_VORBIS", "vorbis"},
+	{"A_MPEG/L3", "mp3"},
+	{"A_MPEG/L2", "mp2"},
+	{"A_PCM", "pcm"},
+	{"S_TEXT/UTF8", "srt"},
+	{"S_TEXT/ASS", "ass"},
+	{"S_TEXT/SSA", "ass"},
+	{"S_ASS", "ass"},
+	{"S_SSA", "ass"},
+	{"S_TEXT/WEBVTT", "webvtt"},
+	{"S_HDMV/PGS", "pgs"},
+	{"S_HDMV/TEXTST", "textst"},
+	{"S_VOBSUB", "vobsub"},
+}
+
+func matroskaCodec(codecID string) string {
+	for _, item := range matroskaCodecs {
+		if strings.HasPrefix(codecID, item.prefix) {
+			return item.codec
+		}
+	}
+	return strings.ToLower(codecID)
+}
+
+// readElementHeader 读取 EBML 元素 ID 和长度，unknown 表示长度未知（直到父元素结束）
+func readElementHeader(r io.Reader) (id uint32, size int64, unknown bool, err error) {
+	var b [8]byte
+	if _, err = io.ReadFull(r, b[:1]); err != nil {
+		return
+	}
+	idLen := vintLength(b[0])
+	if idLen == 0 || idLen > 4 {
+		return 0, 0, false, fmt.Errorf("无效的 EBML 元素 ID")
+	}
+	if _, err = io.ReadFull(r, b[1:idLen]); err != nil {
+		return
+	}
+	for _, c := range b[:idLen] {
+		id = id<<8 | uint32(c)
+	}
+
+	if _, err = io.ReadFull(r, b[:1]); err != nil {
+		return
+	}
+	sizeLen := vintLength(b[0])
+	if sizeLen == 0 {
+		return 0, 0, false, fmt.Errorf("无效的 EBML 元素长度")
+	}
+	if _, err = io.ReadFull(r, b[1:sizeLen]); err != nil {
+		return
+	}
+	value, unknown := vintValue(b[:sizeLen])
+	if value > math.MaxInt64 {
+		return 0, 0, false, fmt.Errorf("无效的 EBML 元素长度")
+	}
+	return id, int64(value), unknown, nil
+}
+
+// vintLength 根据首字节前导零个数计算变长整数的字节数，无效时返回 0
+func vintLength(first byte) int {
+	for i := 0; i < 8; i++ {
+		if first&(0x80>>i) != 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// vintValue 去掉长度标记位后的变长整数值，所有数值位为 1 时表示长度未知
+func vintValue(b []byte) (uint64, bool) {
+	value := uint64(b[0] & (0xFF >> len(b)))
+	allOnes := value == uint64(0xFF>>len(b))
+	for _, c := range b[1:] {
+		value = value<<8 | uint64(c)
+		allOnes = allOnes && c == 0xFF
+	}
+	return value, allOnes
+}
+
+// forEachElement 遍历缓冲区中的 EBML 子元素，遇到格式错误时停止
+func forEachElement(buf []byte, fn func(id uint32, data []byte)) {
+	for len(buf) > 0 {
+		idLen := vintLength(buf[0])
+		if idLen == 0 || idLen > 4 || len(buf) < idLen+1 {
+			return
+		}
+		var id uint32
+		for _, c := range buf[:idLen] {
+			id = id<<8 | uint32(c)
+		}
+		buf = buf[idLen:]
+		sizeLen := vintLength(buf[0])
+		if sizeLen == 0 || len(buf) < sizeLen {
+			return
+		}
+		size, unknown := vintValue(buf[:sizeLen])
+		buf = buf[sizeLen:]
+		if unknown || size > uint64(len(buf)) {
+			size = uint64(len(buf))
+		}
+		fn(id, buf[:size])
+		buf = buf[size:]
+	}
+}
+
+func readUint(data []byte) uint64 {
+	var v uint64
+	for _, c := range data {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func readFloat(data []byte) float64 {
+	switch len(data) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,196 @@
+package probe
+
+import (
+	"encoding/binary"
+	"time"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+func probeMP4(r *mediaio.Reader) (*Info, error) {
+	data, err := mediaio.ReadMoov(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseMoov(data), nil
+}
+
+func parseMoov(data []byte) *Info {
+	info := &Info{Container: "mp4"}
+	mediaio.ForEachBox(data, func(boxType string, data []byte) {
+		switch boxType {
+		case "mvhd":
+			info.Duration = parseMvhd(data)
+		case "trak":
+			parseTrak(info, data)
+		}
+	})
+	return info
+}
+
+// parseMvhd 解析影片时长
+func parseMvhd(data []byte) time.Duration {
+	var timescale, duration uint64
+	switch {
+	case len(data) >= 32 && data[0] == 1:
+		timescale = uint64(binary.BigEndian.Uint32(data[20:24]))
+		duration = binary.BigEndian.Uint64(data[24:32])
+	case len(data) >= 20:
+		timescale = uint64(binary.BigEndian.Uint32(data[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(data[16:20]))
+	}
+	if timescale == 0 {
+		return 0
+	}
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+}
+
+func parseTrak(info *Info, data []byte) {
+	var (
+		width, height int
+		handler       string
+		language      string
+		entryType     string
+		entry         []byte
+	)
+	mediaio.ForEachBox(data, func(boxType string, data []byte) {
+		switch boxType {
+		case "tkhd":
+			if len(data) >= 8 { // 宽高为末尾的两个 16.16 定点数
+				width = int(binary.BigEndian.Uint32(data[len(data)-8:]) >> 16)
+				height = int(binary.BigEndian.Uint32(data[len(data)-4:]) >> 16)
+			}
+		case "mdia":
+			mediaio.ForEachBox(data, func(boxType string, data []byte) {
+				switch boxType {
+				case "mdhd":
+					language = parseMdhdLanguage(data)
+				case "hdlr":
+					if len(data) >= 12 {
+						handler = string(data[8:12])
+					}
+				case "minf":
+					entryType, entry = findSampleEntry(data)
+				}
+			})
+		}
+	})
+
+	switch handler {
+	case "vide":
+		video := VideoStream{Codec: mp4Codec(entryType), Width: width, Height: height}
+		parseVisualSampleEntry(&video, entryType, entry)
+		info.Videos = append(info.Videos, video)
+	case "soun":
+		audio := AudioStream{Codec: mp4Codec(entryType), Language: language}
+		if len(entry) >= 18 {
+			audio.Channels = int(binary.BigEndian.Uint16(entry[16:18]))
+		}
+		info.Audios = append(info.Audios, audio)
+	case "sbtl", "subt", "text":
+		info.Subtitles = append(info.Subtitles, SubtitleStream{Codec: mp4Codec(entryType), Language: language})
+	}
+}
+
+// parseMdhdLanguage 解析 mdhd 中打包的 ISO 639-2 语言代码
+func parseMdhdLanguage(data []byte) string {
+	offset := 20
+	if len(data) > 0 && data[0] == 1 {
+		offset = 32
+	}
+	if len(data) < offset+2 {
+		return ""
+	}
+	packed := binary.BigEndian.Uint16(data[offset : offset+2])
+	if packed == 0 || packed == 0x7FFF {
+		return ""
+	}
+	lang := []byte{
+		byte(packed>>10&0x1F) + 0x60,
+		byte(packed>>5&0x1F) + 0x60,
+		byte(packed&0x1F) + 0x60,
+	}
+	return string(lang)
+}
+
+// findSampleEntry 在 minf/stbl/stsd 中查找第一个样本描述
+func findSampleEntry(minf []byte) (string, []byte) {
+	var entryType string
+	var entry []byte
+	mediaio.ForEachBox(minf, func(boxType string, data []byte) {
+		if boxType != "stbl" {
+			return
+		}
+		mediaio.ForEachBox(data, func(boxType string, data []byte) {
+			if boxType != "stsd" || len(data) < 8 || entryType != "" {
+				return
+			}
+			mediaio.ForEachBox(data[8:], func(boxType string, data []byte) {
+				if entryType == "" {
+					entryType, entry = boxType, data
+				}
+			})
+		})
+	})
+	return entryType, entry
+}
+
+// visualSampleEntrySize VisualSampleEntry 固定字段长度，其后为子 box
+const visualSampleEntrySize = 78
+
+func parseVisualSampleEntry(video *VideoStream, entryType string, entry []byte) {
+	if len(entry) < visualSampleEntrySize {
+		return
+	}
+	if video.Width == 0 || video.Height == 0 {
+		video.Width = int(binary.BigEndian.Uint16(entry[24:26]))
+		video.Height = int(binary.BigEndian.Uint16(entry[26:28]))
+	}
+	switch entryType {
+	case "dvh1", "dvhe", "dva1", "dvav", "dav1":
+		video.HDR = DolbyVision
+	}
+	mediaio.ForEachBox(entry[visualSampleEntrySize:], func(boxType string, data []byte) {
+		switch boxType {
+		case "hvcC":
+			video.BitDepth = hvcCBitDepth(data)
+		case "av1C":
+			if len(data) >= 3 {
+				switch {
+				case data[2]&0x40 == 0:
+					video.BitDepth = 8
+				case data[2]&0x20 == 0:
+					video.BitDepth = 10
+				default:
+					video.BitDepth = 12
+				}
+			}
+		case "colr":
+			if len(data) >= 10 && string(data[:4]) == "nclx" && video.HDR == "" {
+				video.HDR = transferHDR(uint64(binary.BigEndian.Uint16(data[6:8])))
+			}
+		case "dvcC", "dvvC", "dvwC":
+			video.HDR = DolbyVision
+		}
+	})
+}
+
+// MP4 样本描述类型到编码名称的映射
+var mp4Codecs = map[string]string{
+	"avc1": "h264", "avc3": "h264", "dva1": "h264", "dvav": "h264",
+	"hvc1": "hevc", "hev1": "hevc", "dvh1": "hevc", "dvhe": "hevc",
+	"av01": "av1", "dav1": "av1",
+	"vp09": "vp9", "vp08": "vp8",
+	"mp4v": "mpeg4",
+	"mp4a": "aac", "ac-3": "ac3", "ec-3": "eac3", "Opus": "opus", "fLaC": "flac", "alac": "alac",
+	"dtsc": "dts", "dtsh": "dts", "dtsl": "dts", "dtse": "dts", "mlpa": "truehd", ".mp3": "mp3",
+	"lpcm": "pcm", "sowt": "pcm", "twos": "pcm", "ipcm": "pcm",
+	"tx3g": "tx3g", "wvtt": "webvtt", "stpp": "ttml", "c608": "eia608",
+}
+
+func mp4Codec(entryType string) string {
+	if codec, ok := mp4Codecs[entryType]; ok {
+		return codec
+	}
+	return entryType
+}
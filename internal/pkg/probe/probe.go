@@ -0,0 +1,91 @@
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"MediaTools/internal/pkg/mediaio"
+)
+
+const maxDiscard = 256 << 20 // 不可 Seek 时最多丢弃的字节数
+
+var (
+	ErrUnsupported = errors.New("不支持的容器格式")
+	ErrTooFar      = mediaio.ErrTooFar
+)
+
+// HDR 格式
+const (
+	HDR10       = "HDR10"
+	HLG         = "HLG"
+	DolbyVision = "DolbyVision"
+)
+
+// VideoStream 视频流信息
+type VideoStream struct {
+	Codec    string // 编码（h264、hevc、av1、vp9、mpeg2、mpeg4 等）
+	Width    int    // 宽度
+	Height   int    // 高度
+	BitDepth int    // 位深，未知时为 0
+	HDR      string // HDR 格式（HDR10、HLG、DolbyVision），SDR 或未知时为空
+}
+
+// AudioStream 音频流信息
+type AudioStream struct {
+	Codec    string // 编码（aac、ac3、eac3、dts、truehd、flac、opus 等）
+	Channels int    // 声道数
+	Language string // 语言（ISO 639-2，如 chi、eng）
+}
+
+// SubtitleStream 内嵌字幕流信息
+type SubtitleStream struct {
+	Codec    string // 编码（srt、ass、pgs、vobsub、tx3g 等）
+	Language string // 语言（ISO 639-2）
+	Forced   bool   // 是否为强制字幕
+}
+
+// Info 容器探测结果
+type Info struct {
+	Container string           // 容器格式（matroska、webm、mp4）
+	Duration  time.Duration    // 时长
+	Videos    []VideoStream    // 视频流
+	Audios    []AudioStream    // 音频流
+	Subtitles []SubtitleStream // 字幕流
+}
+
+// Video 返回第一条视频流，没有视频流时返回 nil
+func (info *Info) Video() *VideoStream {
+	if len(info.Videos) == 0 {
+		return nil
+	}
+	return &info.Videos[0]
+}
+
+// Audio 返回第一条音频流，没有音频流时返回 nil
+func (info *Info) Audio() *AudioStream {
+	if len(info.Audios) == 0 {
+		return nil
+	}
+	return &info.Audios[0]
+}
+
+// Probe 解析 Matroska/WebM 或 MP4/MOV 容器头部，获取时长和音视频、字幕流信息
+// r 实现 io.Seeker 时直接跳过媒体数据，否则顺序读取（最多跳过 maxDiscard 字节）
+func Probe(r io.Reader) (*Info, error) {
+	pr := mediaio.NewReader(r, maxDiscard)
+
+	head, err := pr.Peek(8)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件头失败: %w", err)
+	}
+	switch {
+	case head[0] == 0x1A && head[1] == 0x45 && head[2] == 0xDF && head[3] == 0xA3:
+		return probeMatroska(pr)
+	case mediaio.IsMP4Box(string(head[4:8])):
+		return probeMP4(pr)
+	default:
+		return nil, ErrUnsupported
+	}
+}
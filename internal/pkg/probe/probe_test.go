@@ -0,0 +1,168 @@
+package probe_test
+
+import (
+	"MediaTools/internal/pkg/probe"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ebml 编码一个 EBML 元素（长度统一使用 8 字节变长整数）
+func ebml(id uint32, children ...[]byte) []byte {
+	body := bytes.Join(children, nil)
+	var buf []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if b := byte(id >> shift); b != 0 || len(buf) > 0 {
+			buf = append(buf, b)
+		}
+	}
+	size := make([]byte, 8)
+	binary.BigEndian.PutUint64(size, uint64(len(body)))
+	size[0] = 0x01
+	return append(append(buf, size...), body...)
+}
+
+func ebmlUint(id uint32, v uint64) []byte {
+	return ebml(id, binary.BigEndian.AppendUint64(nil, v))
+}
+
+func ebmlString(id uint32, s string) []byte {
+	return ebml(id, []byte(s))
+}
+
+func box(boxType string, children ...[]byte) []byte {
+	body := bytes.Join(children, nil)
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(body)+8))
+	return append(append(buf, boxType...), body...)
+}
+
+func TestProbeMatroska(t *testing.T) {
+	duration := binary.BigEndian.AppendUint64(nil, math.Float64bits(5400000))
+	data := bytes.Join([][]byte{
+		ebml(0x1A45DFA3, ebmlString(0x4282, "matroska")),
+		ebml(0x18538067,
+			ebml(0x114D9B74, []byte{0x00, 0x00}), // SeekHead
+			ebml(0x1549A966, ebmlUint(0x2AD7B1, 1000000), ebml(0x4489, duration)),
+			ebml(0x1654AE6B,
+				ebml(0xAE,
+					ebmlUint(0x83, 1),
+					ebmlString(0x86, "V_MPEGH/ISO/HEVC"),
+					ebml(0xE0,
+						ebmlUint(0xB0, 3840),
+						ebmlUint(0xBA, 2160),
+						ebml(0x55B0, ebmlUint(0x55B2, 10), ebmlUint(0x55BA, 16)),
+					),
+				),
+				ebml(0xAE,
+					ebmlUint(0x83, 2),
+					ebmlString(0x86, "A_TRUEHD"),
+					ebmlString(0x22B59C, "jpn"),
+					ebml(0xE1, ebmlUint(0x9F, 8)),
+				),
+				ebml(0xAE,
+					ebmlUint(0x83, 0x11),
+					ebmlString(0x86, "S_HDMV/PGS"),
+					ebmlString(0x22B59C, "chi"),
+					ebmlUint(0x55AA, 1),
+				),
+			),
+			ebml(0x1F43B675, make([]byte, 64)), // Cluster
+		),
+	}, nil)
+
+	info, err := probe.Probe(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "matroska", info.Container)
+	require.Equal(t, 90*time.Minute, info.Duration)
+	require.Equal(t, []probe.VideoStream{{Codec: "hevc", Width: 3840, Height: 2160, BitDepth: 10, HDR: probe.HDR10}}, info.Videos)
+	require.Equal(t, []probe.AudioStream{{Codec: "truehd", Channels: 8, Language: "jpn"}}, info.Audios)
+	require.Equal(t, []probe.SubtitleStream{{Codec: "pgs", Language: "chi", Forced: true}}, info.Subtitles)
+
+	// 不可 Seek 的读取器
+	info, err = probe.Probe(io.MultiReader(bytes.NewReader(data)))
+	require.NoError(t, err)
+	require.Len(t, info.Videos, 1)
+}
+
+func TestProbeMatroskaInvalidSize(t *testing.T) {
+	// EBML 头声明的长度为 64 GiB，不应按声明的长度分配内存
+	_, err := probe.Probe(bytes.NewReader([]byte{0x1A, 0x45, 0xDF, 0xA3, 0x01, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00}))
+	require.Error(t, err)
+
+	// Tracks 声明的长度超过实际数据
+	data := bytes.Join([][]byte{
+		ebml(0x1A45DFA3, ebmlString(0x4282, "matroska")),
+		{0x18, 0x53, 0x80, 0x67, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, // Segment（长度未知）
+		{0x16, 0x54, 0xAE, 0x6B, 0x01, 0x00, 0x00, 0x00, 0x00, 0x80, 0x00, 0x00}, // Tracks（8 MiB）
+	}, nil)
+	_, err = probe.Probe(io.MultiReader(bytes.NewReader(data)))
+	require.Error(t, err)
+}
+
+func mp4Trak(handler string, language string, tkhdSize [2]uint32, entry []byte) []byte {
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:], tkhdSize[0]<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], tkhdSize[1]<<16)
+
+	mdhd := make([]byte, 24)
+	var packed uint16
+	for _, c := range []byte(language) {
+		packed = packed<<5 | uint16(c-0x60)
+	}
+	binary.BigEndian.PutUint16(mdhd[20:], packed)
+
+	hdlr := append(make([]byte, 8), handler...)
+	hdlr = append(hdlr, make([]byte, 13)...)
+
+	stsd := append(make([]byte, 4), 0, 0, 0, 1)
+	return box("trak",
+		box("tkhd", tkhd),
+		box("mdia",
+			box("mdhd", mdhd),
+			box("hdlr", hdlr),
+			box("minf", box("stbl", box("stsd", stsd, entry))),
+		),
+	)
+}
+
+func TestProbeMP4(t *testing.T) {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:], 125500)
+
+	hvcC := make([]byte, 23)
+	hvcC[17] = 0xF8 | 2 // bitDepthLumaMinus8 = 2
+	colr := append([]byte("nclx"), 0, 9, 0, 18, 0, 9, 0x80)
+	video := box("dvh1", make([]byte, 78), box("hvcC", hvcC), box("colr", colr))
+
+	audioEntry := make([]byte, 28)
+	binary.BigEndian.PutUint16(audioEntry[16:], 6)
+	audio := box("ec-3", audioEntry)
+
+	data := bytes.Join([][]byte{
+		box("ftyp", []byte("isom"), make([]byte, 4)),
+		box("mdat", make([]byte, 1024)),
+		box("moov",
+			box("mvhd", mvhd),
+			mp4Trak("vide", "und", [2]uint32{1920, 1080}, video),
+			mp4Trak("soun", "eng", [2]uint32{}, audio),
+			mp4Trak("sbtl", "chi", [2]uint32{}, box("tx3g", make([]byte, 40))),
+		),
+	}, nil)
+
+	info, err := probe.Probe(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "mp4", info.Container)
+	require.Equal(t, 125500*time.Millisecond, info.Duration)
+	require.Equal(t, []probe.VideoStream{{Codec: "hevc", Width: 1920, Height: 1080, BitDepth: 10, HDR: probe.DolbyVision}}, info.Videos)
+	require.Equal(t, []probe.AudioStream{{Codec: "eac3", Channels: 6, Language: "eng"}}, info.Audios)
+	require.Equal(t, []probe.SubtitleStream{{Codec: "tx3g", Language: "chi"}}, info.Subtitles)
+
+	_, err = probe.Probe(bytes.NewReader([]byte("not a media file")))
+	require.ErrorIs(t, err, probe.ErrUnsupported)
+}
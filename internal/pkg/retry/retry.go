@@ -0,0 +1,108 @@
+package retry
+
+import (
+	"MediaTools/internal/pkg/limiter"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Policy 请求重试策略
+// 网络错误、429 和 5xx 响应会按指数退避（带随机抖动）重试，响应带有 Retry-After 时优先使用其指定的等待时间
+type Policy struct {
+	MaxAttempts int           // 最大尝试次数（含首次请求）
+	BaseDelay   time.Duration // 首次重试的退避时间
+	MaxDelay    time.Duration // 单次等待时间上限，Retry-After 超过该值时不再重试
+}
+
+var DefaultPolicy = Policy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// Do 发送请求，每次尝试前都会从限流器获取令牌
+// newRequest 用于在每次尝试时构造新的请求
+func (p Policy) Do(
+	ctx context.Context,
+	client *http.Client,
+	l *limiter.Limiter,
+	newRequest func(ctx context.Context) (*http.Request, error),
+) (*http.Response, error) {
+	attempts := max(p.MaxAttempts, 1)
+	for attempt := 0; ; attempt++ {
+		if l != nil {
+			if err := l.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		req, err := newRequest(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := client.Do(req)
+		if attempt+1 >= attempts || !retryable(ctx, res, err) {
+			return res, err
+		}
+
+		delay := p.backoff(attempt)
+		if res != nil {
+			if d, ok := retryAfter(res); ok {
+				if d > p.MaxDelay {
+					return res, nil
+				}
+				delay = d
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// 第 attempt 次重试前的退避时间，在 [d/2, d) 区间内随机抖动
+func (p Policy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// 解析 Retry-After 响应头，支持秒数和 HTTP 日期两种格式
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,73 @@
+package subtitle
+
+import "strings"
+
+// 简繁对照表，按位置一一对应
+// 仅包含常用的一对一字符，「发/發/髮」「着/著」「几/幾」「干/乾/幹」这类一简对多繁或繁简双向都常用、容易误转的字未收录，不做词组级转换
+const (
+	simplifiedChars = "" +
+		"个义乌乐乔习书买乱争亏亚产亲亿仅从仓仪们众优会伞伟传伤伦伪体佣侠侣侦侧俭债倾偿儿兑兰" +
+		"关兴养兽内冈册写军农冯决况冻净凉减凑凤凭凯击刘则刚创删刹剂剑剧劝办务动励劲劳势勋区医" +
+		"华协单卖卢卫却厂厅压厌厕县参双变叙号叹吓吕吗启吴员听呜咏响哑唤啰园围国图圆圣场坏块坚" +
+		"坝坟坠垒垦堕墙壮声壳处备头夹夺奋奖妇妈娱婴孙学宁宝实宠审宪宫宽宾对寻导寿将尔尘尝尧层" +
+		"属岁岂岛岭帅师帐带帮广庄庆库应庙废开异弃张弹强归录彻径忆忧怀态怜总恋恶恼悦惊惧惨惯愤" +
+		"懒战戏户执扩扫扬扰抚抢护报担拟拥择挂挡挤挥损换据掷揽摄摇敌数断无旧时昼显晋晓晕暂术机" +
+		"杀杂权条来杨极构枪柜标栏树样桥检楼欢欧残毁毕气汉汤沟没沪泪泽洁浅测济浏浑浓润涨渐温湾" +
+		"湿满滚滞灭灯灵灾炉点炼烂烟烦烧热焕爱爷牵犹狮独狱猎猫献环现玛电画畅疗疯盖盘监睁矫码础" +
+		"礼祸离种积称稳穷窃竞笔笼筑简类粮紧纠红约级纪纯纲纳纵纷纸线练组细织终经结绕绘给络绝统" +
+		"继绩续绳维综绿缘编缩网罗罚罢职联聪肃肠肤胁胜胆脉脑脚腾艺节芦苹荐荡荣药萝营蓝虑虚虫虽" +
+		"蚂蛮补装见观规视览觉触誉计认讨让训议讯记讲许论设访证评识诉诊词译试诗诚话询该详语误说" +
+		"请诸读课谁调谈谋谎谢谱贝负贡财责败货质贩贪贫购贯贵费贴贸资赏赔赖赚赛赠赵赶趋跃践踪车" +
+		"轨转轮软轻载较辅辆辈辉输辞边达迁过运还这进远违连迟选递逻遗邓邮邻郑酱释鉴针钓钢钥钱铁" +
+		"铃银铺链销锁错锋锦键镇镜长门闪闭问闯闲间闷闹闻阅阔队阳阴阵阶际陆陈险随隐难雾静韩页顶" +
+		"项顺顽顾顿预领频题颜额风飞饭饮饰饱饿馆马驱驶驾验骂骑骗鱼鲜鸟鸡鸣鸭麦黄齐齿龙龟为与东" +
+		"丝两严丧丰临举乡亩侨俩储匀厦叠吨咙哗哟喷嘱垄妆娄娇寝岗峡币帜并庐忏恳悬惩拨拣挣捞捡携" +
+		"敛斋旷晒杰枣栋桩梦档氢浊涌涩渊渔滥潜灿炖牺状狭猪琐疮痒瘾皱盐矿砖硕确碍祷秃稣窝竖笋筛" +
+		"篮粪翘耸聂肿胀胶脸腻舰舱艰苍茧莲莱蕴蚀袭袄裤谜贼赌躯轰辖迹逊酝酿钉钩铅铜铭锅锐锡闸阀" +
+		"阁隶雳韵颈颗颠飘馈驴骄骚鲁鸿鹅鹰龄赢专业丽"
+	traditionalChars = "" +
+		"個義烏樂喬習書買亂爭虧亞產親億僅從倉儀們眾優會傘偉傳傷倫偽體傭俠侶偵側儉債傾償兒兌蘭" +
+		"關興養獸內岡冊寫軍農馮決況凍淨涼減湊鳳憑凱擊劉則剛創刪剎劑劍劇勸辦務動勵勁勞勢勳區醫" +
+		"華協單賣盧衛卻廠廳壓厭廁縣參雙變敘號嘆嚇呂嗎啟吳員聽嗚詠響啞喚囉園圍國圖圓聖場壞塊堅" +
+		"壩墳墜壘墾墮牆壯聲殼處備頭夾奪奮獎婦媽娛嬰孫學寧寶實寵審憲宮寬賓對尋導壽將爾塵嘗堯層" +
+		"屬歲豈島嶺帥師帳帶幫廣莊慶庫應廟廢開異棄張彈強歸錄徹徑憶憂懷態憐總戀惡惱悅驚懼慘慣憤" +
+		"懶戰戲戶執擴掃揚擾撫搶護報擔擬擁擇掛擋擠揮損換據擲攬攝搖敵數斷無舊時晝顯晉曉暈暫術機" +
+		"殺雜權條來楊極構槍櫃標欄樹樣橋檢樓歡歐殘毀畢氣漢湯溝沒滬淚澤潔淺測濟瀏渾濃潤漲漸溫灣" +
+		"濕滿滾滯滅燈靈災爐點煉爛煙煩燒熱煥愛爺牽猶獅獨獄獵貓獻環現瑪電畫暢療瘋蓋盤監睜矯碼礎" +
+		"禮禍離種積稱穩窮竊競筆籠築簡類糧緊糾紅約級紀純綱納縱紛紙線練組細織終經結繞繪給絡絕統" +
+		"繼績續繩維綜綠緣編縮網羅罰罷職聯聰肅腸膚脅勝膽脈腦腳騰藝節蘆蘋薦蕩榮藥蘿營藍慮虛蟲雖" +
+		"螞蠻補裝見觀規視覽覺觸譽計認討讓訓議訊記講許論設訪證評識訴診詞譯試詩誠話詢該詳語誤說" +
+		"請諸讀課誰調談謀謊謝譜貝負貢財責敗貨質販貪貧購貫貴費貼貿資賞賠賴賺賽贈趙趕趨躍踐蹤車" +
+		"軌轉輪軟輕載較輔輛輩輝輸辭邊達遷過運還這進遠違連遲選遞邏遺鄧郵鄰鄭醬釋鑑針釣鋼鑰錢鐵" +
+		"鈴銀鋪鏈銷鎖錯鋒錦鍵鎮鏡長門閃閉問闖閒間悶鬧聞閱闊隊陽陰陣階際陸陳險隨隱難霧靜韓頁頂" +
+		"項順頑顧頓預領頻題顏額風飛飯飲飾飽餓館馬驅駛駕驗罵騎騙魚鮮鳥雞鳴鴨麥黃齊齒龍龜為與東" +
+		"絲兩嚴喪豐臨舉鄉畝僑倆儲勻廈疊噸嚨嘩喲噴囑壟妝婁嬌寢崗峽幣幟並廬懺懇懸懲撥揀掙撈撿攜" +
+		"斂齋曠曬傑棗棟樁夢檔氫濁湧澀淵漁濫潛燦燉犧狀狹豬瑣瘡癢癮皺鹽礦磚碩確礙禱禿穌窩豎筍篩" +
+		"籃糞翹聳聶腫脹膠臉膩艦艙艱蒼繭蓮萊蘊蝕襲襖褲謎賊賭軀轟轄跡遜醞釀釘鉤鉛銅銘鍋銳錫閘閥" +
+		"閣隸靂韻頸顆顛飄饋驢驕騷魯鴻鵝鷹齡贏專業麗"
+)
+
+var s2t, t2s *strings.Replacer
+
+func init() {
+	simplified := []rune(simplifiedChars)
+	traditional := []rune(traditionalChars)
+	s2tPairs := make([]string, 0, len(simplified)*2)
+	t2sPairs := make([]string, 0, len(simplified)*2)
+	for i := range simplified {
+		s2tPairs = append(s2tPairs, string(simplified[i]), string(traditional[i]))
+		t2sPairs = append(t2sPairs, string(traditional[i]), string(simplified[i]))
+	}
+	s2t = strings.NewReplacer(s2tPairs...)
+	t2s = strings.NewReplacer(t2sPairs...)
+}
+
+// ToTraditional 简体转繁体
+func ToTraditional(s string) string {
+	return s2t.Replace(s)
+}
+
+// ToSimplified 繁体转简体
+func ToSimplified(s string) string {
+	return t2s.Replace(s)
+}
@@ -0,0 +1,42 @@
+package subtitle_test
+
+import (
+	"MediaTools/internal/pkg/subtitle"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTraditional(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"这是一个测试", "這是一個測試"},
+		{"头发", "頭发"},   // 发 可能是 發 或 髮，不转换
+		{"茶几", "茶几"},   // 几 可能是 幾 或 几，不转换
+		{"看着他", "看着他"}, // 着 在繁体中也常用，不转换
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, subtitle.ToTraditional(tc.input))
+		})
+	}
+}
+
+func TestToSimplified(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"這是一個測試", "这是一个测试"},
+		{"著名", "著名"}, // 著 在简体中也常用，不转换为 着
+		{"幾個", "幾个"},
+		{"頭髮", "头髮"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, subtitle.ToSimplified(tc.input))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package subtitle
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Encoding 字幕文本编码
+type Encoding string
+
+const (
+	EncodingUTF8    Encoding = "UTF-8"
+	EncodingUTF16LE Encoding = "UTF-16LE"
+	EncodingUTF16BE Encoding = "UTF-16BE"
+	EncodingGB18030 Encoding = "GB18030" // 兼容 GBK、GB2312
+	EncodingBig5    Encoding = "Big5"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// 非 UTF-8 且没有 BOM 时依次尝试的中文编码
+var chineseEncodings = []struct {
+	name    Encoding
+	decoder encoding.Encoding
+}{
+	{EncodingGB18030, simplifiedchinese.GB18030},
+	{EncodingBig5, traditionalchinese.Big5},
+}
+
+// ToUTF8 检测字幕文本编码并转换为不带 BOM 的 UTF-8
+// 依次根据 BOM、UTF-8 合法性判断，否则分别按 GB18030 和 Big5 解码，选择常用汉字占比更高的结果
+// 返回转换后的文本和检测到的原始编码
+func ToUTF8(data []byte) ([]byte, Encoding, error) {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return data[len(bomUTF8):], EncodingUTF8, nil
+	case bytes.HasPrefix(data, bomUTF16LE):
+		out, err := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+		return out, EncodingUTF16LE, err
+	case bytes.HasPrefix(data, bomUTF16BE):
+		out, err := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+		return out, EncodingUTF16BE, err
+	case utf8.Valid(data):
+		return data, EncodingUTF8, nil
+	}
+
+	var (
+		best      []byte
+		bestName  Encoding
+		bestScore = -1
+	)
+	for _, enc := range chineseEncodings {
+		out, err := enc.decoder.NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+		if score := commonHanScore(out); score > bestScore {
+			best, bestName, bestScore = out, enc.name, score
+		}
+	}
+	if best == nil {
+		return nil, "", fmt.Errorf("无法识别字幕文本编码")
+	}
+	return best, bestName, nil
+}
+
+// 常用汉字（简繁通用），用于判断解码结果是否合理
+const commonHanChars = "的一是不了人我在有他这中大来上个国到说们为子和你地出道也时年得就那要下以生会自着去之过家学对可她里后小么心多天而能好都然没日于起还发成事只作当想看文无开手十用主行方又如前所本见经头面公同三已老从动两长知民样现分将外但身些与高意进把法此实回二理美点月明其种声全工己话儿者向情部正名定女问力机给等几很业最间新什打便位因重被走电四第门相次东政海口使教西再平真听世气信北少关并内加化由却代军产入先山五太水万市眼体别处总才场师书比住员九笑性通目华报立马命张活难神数件安表原车白应路期叫死常提感金何更反合放做系计或司利受光王果亲界及今京务制解任至清物台象记边共风战干接它许八特觉望直服毛林题建南度统色字请交爱让认算论百吃义科怎元社术结六功指思非流每青管夫连远资队跟带花快条院变联言权往展该领传近留红治决周保达办运武半候七必城父强步完革深区即求品士转量空甚众技轻程告江语英基派满式李息写呢识极令黄德收脸钱党倒未持取设始版双历越史商千片容研像找友孩站广改议形委早房音火际则首单据导影失拿网香似斯专石若兵弟谁校读志飞观争究包组造落视济喜离虽坐集编宝谈府拉黑且随格尽剑讲布杀微怕母调局根曾准团段终乐切级克精哪官示冷域读"
+
+var commonHanSet = func() map[rune]struct{} {
+	set := make(map[rune]struct{})
+	for _, r := range commonHanChars + simplifiedChars + traditionalChars {
+		set[r] = struct{}{}
+	}
+	return set
+}()
+
+// commonHanScore 统计文本中常用汉字的数量，解码错误产生的替换字符扣分
+func commonHanScore(data []byte) int {
+	score := 0
+	for _, r := range string(data) {
+		if r == utf8.RuneError {
+			score -= 10
+			continue
+		}
+		if _, ok := commonHanSet[r]; ok {
+			score++
+		}
+	}
+	return score
+}
@@ -0,0 +1,288 @@
+package subtitle
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format 字幕格式
+type Format string
+
+const (
+	FormatSRT Format = "srt"
+	FormatASS Format = "ass"
+)
+
+// ParseFormat 根据扩展名或名称解析字幕格式（.ssa 按 ASS 处理），不支持的格式返回空字符串
+func ParseFormat(s string) Format {
+	switch strings.TrimPrefix(strings.ToLower(s), ".") {
+	case "srt":
+		return FormatSRT
+	case "ass", "ssa":
+		return FormatASS
+	default:
+		return ""
+	}
+}
+
+// Ext 字幕格式对应的扩展名
+func (f Format) Ext() string {
+	return "." + string(f)
+}
+
+// Cue 一条字幕
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string // 多行文本以 \n 分隔
+
+	assFields []string // 来自 ASS 时的原始字段（样式、图层等），输出 ASS 时保留
+}
+
+// Subtitle 字幕文件内容
+type Subtitle struct {
+	Header string // ASS 的 [Events] 之前的内容（脚本信息、样式），转换为 ASS 时保留
+	Cues   []Cue
+
+	assFormat []string // 来自 ASS 时 [Events] 的字段格式
+}
+
+// Shift 将所有字幕时间整体偏移，偏移后小于 0 的时间按 0 处理
+func (s *Subtitle) Shift(offset time.Duration) {
+	for i := range s.Cues {
+		s.Cues[i].Start = max(s.Cues[i].Start+offset, 0)
+		s.Cues[i].End = max(s.Cues[i].End+offset, 0)
+	}
+}
+
+// Unmarshal 按格式解析字幕文本（UTF-8）
+func Unmarshal(data string, format Format) (*Subtitle, error) {
+	switch format {
+	case FormatSRT:
+		return parseSRT(data)
+	case FormatASS:
+		return parseASS(data)
+	default:
+		return nil, fmt.Errorf("不支持的字幕格式: %s", format)
+	}
+}
+
+// Marshal 按格式生成字幕文本
+func (s *Subtitle) Marshal(format Format) (string, error) {
+	switch format {
+	case FormatSRT:
+		return s.marshalSRT(), nil
+	case FormatASS:
+		return s.marshalASS(), nil
+	default:
+		return "", fmt.Errorf("不支持的字幕格式: %s", format)
+	}
+}
+
+var srtTimeRe = regexp.MustCompile(`(\d{1,2}):(\d{2}):(\d{2})[,.](\d{1,3})\s*-->\s*(\d{1,2}):(\d{2}):(\d{2})[,.](\d{1,3})`)
+
+func parseSRT(data string) (*Subtitle, error) {
+	var sub Subtitle
+	var cue *Cue
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if m := srtTimeRe.FindStringSubmatch(line); m != nil {
+			if cue != nil {
+				sub.Cues = append(sub.Cues, *cue)
+			}
+			cue = &Cue{
+				Start: srtDuration(m[1], m[2], m[3], m[4]),
+				End:   srtDuration(m[5], m[6], m[7], m[8]),
+			}
+			continue
+		}
+		if cue == nil {
+			continue // 序号或文件开头的空行
+		}
+		if strings.TrimSpace(line) == "" {
+			sub.Cues = append(sub.Cues, *cue)
+			cue = nil
+			continue
+		}
+		if cue.Text != "" {
+			cue.Text += "\n"
+		}
+		cue.Text += line
+	}
+	if cue != nil {
+		sub.Cues = append(sub.Cues, *cue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取 SRT 字幕失败: %w", err)
+	}
+	return &sub, nil
+}
+
+func srtDuration(h, m, s, ms string) time.Duration {
+	hour, _ := strconv.Atoi(h)
+	minute, _ := strconv.Atoi(m)
+	second, _ := strconv.Atoi(s)
+	for len(ms) < 3 {
+		ms += "0"
+	}
+	milli, _ := strconv.Atoi(ms)
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(milli)*time.Millisecond
+}
+
+func (s *Subtitle) marshalSRT() string {
+	var sb strings.Builder
+	for i, cue := range s.Cues {
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n", i+1, formatSRTTime(cue.Start), formatSRTTime(cue.End), assToPlain(cue.Text))
+	}
+	return sb.String()
+}
+
+func formatSRTTime(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+var assTagRe = regexp.MustCompile(`\{\\[^}]*\}`)
+
+// assToPlain 去掉 ASS 特效标签，SRT 中不支持
+func assToPlain(text string) string {
+	return assTagRe.ReplaceAllString(text, "")
+}
+
+const defaultASSHeader = `[Script Info]
+ScriptType: v4.00+
+WrapStyle: 0
+ScaledBorderAndShadow: yes
+PlayResX: 1920
+PlayResY: 1080
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,64,&H00FFFFFF,&H000000FF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,2,1,2,20,20,40,1
+`
+
+func parseASS(data string) (*Subtitle, error) {
+	var sub Subtitle
+	var header strings.Builder
+	inEvents := false
+	fields := []string{"Layer", "Start", "End", "Style", "Name", "MarginL", "MarginR", "MarginV", "Effect", "Text"}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			inEvents = strings.EqualFold(trimmed, "[Events]")
+		}
+		if !inEvents {
+			header.WriteString(line + "\n")
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Format":
+			fields = strings.Split(value, ",")
+			for i := range fields {
+				fields[i] = strings.TrimSpace(fields[i])
+			}
+		case "Dialogue":
+			values := strings.SplitN(strings.TrimSpace(value), ",", len(fields))
+			if len(values) != len(fields) {
+				continue
+			}
+			cue := Cue{assFields: values}
+			for i, field := range fields {
+				switch field {
+				case "Start":
+					cue.Start = assDuration(values[i])
+				case "End":
+					cue.End = assDuration(values[i])
+				case "Text":
+					cue.Text = strings.NewReplacer(`\N`, "\n", `\n`, "\n").Replace(values[i])
+				}
+			}
+			sub.Cues = append(sub.Cues, cue)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取 ASS 字幕失败: %w", err)
+	}
+	sub.Header = strings.TrimRight(header.String(), "\n") + "\n"
+	sub.assFormat = fields
+	return &sub, nil
+}
+
+func assDuration(s string) time.Duration {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	sec, cs, _ := strings.Cut(parts[2], ".")
+	return srtDuration(parts[0], parts[1], sec, "0") + time.Duration(centiseconds(cs))*10*time.Millisecond
+}
+
+func centiseconds(s string) int {
+	for len(s) < 2 {
+		s += "0"
+	}
+	n, _ := strconv.Atoi(s[:2])
+	return n
+}
+
+func (s *Subtitle) marshalASS() string {
+	var sb strings.Builder
+	header := s.Header
+	if strings.TrimSpace(header) == "" {
+		header = defaultASSHeader
+	}
+	sb.WriteString(header)
+	format := s.assFormat
+	if format == nil {
+		format = []string{"Layer", "Start", "End", "Style", "Name", "MarginL", "MarginR", "MarginV", "Effect", "Text"}
+	}
+	sb.WriteString("\n[Events]\nFormat: " + strings.Join(format, ", ") + "\n")
+	for _, cue := range s.Cues {
+		values := slices.Clone(cue.assFields)
+		if len(values) != len(format) { // 来自 SRT 的字幕使用默认样式
+			values = make([]string, len(format))
+		}
+		for i, field := range format {
+			switch field {
+			case "Layer", "MarginL", "MarginR", "MarginV":
+				if values[i] == "" {
+					values[i] = "0"
+				}
+			case "Style":
+				if values[i] == "" {
+					values[i] = "Default"
+				}
+			case "Start":
+				values[i] = formatASSTime(cue.Start)
+			case "End":
+				values[i] = formatASSTime(cue.End)
+			case "Text":
+				values[i] = strings.ReplaceAll(cue.Text, "\n", `\N`)
+			}
+		}
+		sb.WriteString("Dialogue: " + strings.Join(values, ",") + "\n")
+	}
+	return sb.String()
+}
+
+func formatASSTime(d time.Duration) string {
+	cs := d.Milliseconds() / 10
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
@@ -0,0 +1,96 @@
+package subtitle
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Info 从字幕文件名中解析到的信息
+type Info struct {
+	Language string // 语言代码（如 zh-CN、zh-TW、en、ja），未识别时为空
+	Forced   bool   // 强制字幕
+	SDH      bool   // 听障字幕（SDH/CC）
+}
+
+// Suffix 生成 Jellyfin/Plex 约定的文件名后缀，如 .zh-CN.forced
+func (info Info) Suffix() string {
+	var sb strings.Builder
+	if info.Language != "" {
+		sb.WriteString("." + info.Language)
+	}
+	if info.Forced {
+		sb.WriteString(".forced")
+	}
+	if info.SDH {
+		sb.WriteString(".sdh")
+	}
+	return sb.String()
+}
+
+// 语言关键字（小写）到语言代码的映射
+var languageWords = map[string]string{
+	"chs": "zh-CN", "sc": "zh-CN", "gb": "zh-CN", "zh-hans": "zh-CN", "zh-cn": "zh-CN",
+	"简": "zh-CN", "简体": "zh-CN", "简中": "zh-CN", "简体中文": "zh-CN", "简日": "zh-CN", "简英": "zh-CN",
+	"cht": "zh-TW", "tc": "zh-TW", "big5": "zh-TW", "zh-hant": "zh-TW", "zh-tw": "zh-TW", "zh-hk": "zh-TW",
+	"繁": "zh-TW", "繁体": "zh-TW", "繁體": "zh-TW", "繁中": "zh-TW", "繁体中文": "zh-TW", "繁體中文": "zh-TW", "繁日": "zh-TW", "繁英": "zh-TW",
+	"chi": "zh", "zho": "zh", "zh": "zh", "chinese": "zh", "中文": "zh", "中字": "zh", "双语": "zh", "中英": "zh",
+	"eng": "en", "en": "en", "english": "en", "英文": "en", "英语": "en",
+	"jpn": "ja", "ja": "ja", "jp": "ja", "japanese": "ja", "日文": "ja", "日语": "ja",
+	"kor": "ko", "ko": "ko", "korean": "ko", "韩文": "ko", "韩语": "ko",
+	"fre": "fr", "fra": "fr", "fr": "fr", "french": "fr",
+	"ger": "de", "deu": "de", "de": "de", "german": "de",
+	"spa": "es", "es": "es", "spanish": "es",
+	"ita": "it", "italian": "it",
+	"rus": "ru", "ru": "ru", "russian": "ru",
+	"por": "pt", "pt": "pt", "portuguese": "pt",
+}
+
+var (
+	forcedWords = []string{"forced", "强制"}
+	sdhWords    = []string{"sdh", "cc", "hi"}
+)
+
+var tokenSplitRe = regexp.MustCompile(`[.\s_\[\]()【】&+,]+`)
+
+// Parse 从字幕/音轨文件名（不含扩展名）中解析语言和强制/听障标记
+// 先从文件名末尾连续的关键字中识别（如 Movie.2010.chs.forced、Show.S01E01.[简体]），
+// 末尾没有语言时再在整个文件名中查找较长的关键字（如 [简体] Show S01E01、2_English），
+// 两个字母的关键字（如 sc、en、hi）容易与标题中的单词混淆，仅在末尾识别
+func Parse(name string) Info {
+	var info Info
+	tokens := slices.DeleteFunc(tokenSplitRe.Split(strings.ToLower(name), -1), func(s string) bool { return s == "" })
+
+	for i := len(tokens) - 1; i >= 0; i-- {
+		token := tokens[i]
+		if slices.Contains(forcedWords, token) {
+			info.Forced = true
+			continue
+		}
+		if slices.Contains(sdhWords, token) {
+			info.SDH = true
+			continue
+		}
+		lang, ok := languageWords[token]
+		if !ok {
+			break
+		}
+		info.Language = lang // 双语字幕（如 chs&eng）以第一个语言为准
+	}
+
+	for _, token := range tokens {
+		if len(token) <= 2 {
+			continue
+		}
+		if token == "forced" || token == "强制" {
+			info.Forced = true
+		}
+		if token == "sdh" {
+			info.SDH = true
+		}
+		if lang, ok := languageWords[token]; ok && info.Language == "" {
+			info.Language = lang
+		}
+	}
+	return info
+}
@@ -0,0 +1,35 @@
+package subtitle_test
+
+import (
+	"MediaTools/internal/pkg/subtitle"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected subtitle.Info
+		suffix   string
+	}{
+		{"Movie.2010.1080p.chs", subtitle.Info{Language: "zh-CN"}, ".zh-CN"},
+		{"Movie.2010.1080p.cht", subtitle.Info{Language: "zh-TW"}, ".zh-TW"},
+		{"Show.S01E01.sc", subtitle.Info{Language: "zh-CN"}, ".zh-CN"},
+		{"Show.S01E01.tc", subtitle.Info{Language: "zh-TW"}, ".zh-TW"},
+		{"Show.S01E01.[简体]", subtitle.Info{Language: "zh-CN"}, ".zh-CN"},
+		{"[繁體] Show S01E01", subtitle.Info{Language: "zh-TW"}, ".zh-TW"},
+		{"Movie.2010.chs&eng", subtitle.Info{Language: "zh-CN"}, ".zh-CN"},
+		{"Movie.2010.eng.forced", subtitle.Info{Language: "en", Forced: true}, ".en.forced"},
+		{"Movie.2010.en.sdh", subtitle.Info{Language: "en", SDH: true}, ".en.sdh"},
+		{"2_English", subtitle.Info{Language: "en"}, ".en"},
+		{"It.2017.1080p", subtitle.Info{}, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := subtitle.Parse(tc.name)
+			require.Equal(t, tc.expected, info)
+			require.Equal(t, tc.suffix, info.Suffix())
+		})
+	}
+}
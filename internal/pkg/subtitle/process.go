@@ -0,0 +1,69 @@
+package subtitle
+
+import (
+	"fmt"
+	"time"
+)
+
+// ChineseConvert 简繁转换方式
+type ChineseConvert string
+
+const (
+	ChineseConvertNone ChineseConvert = ""    // 不转换
+	ChineseConvertS2T  ChineseConvert = "s2t" // 简体转繁体
+	ChineseConvertT2S  ChineseConvert = "t2s" // 繁体转简体
+)
+
+// Options 字幕处理选项
+type Options struct {
+	Chinese ChineseConvert // 简繁转换方式
+	Format  Format         // 目标格式，为空时保持原格式
+	Offset  time.Duration  // 时间偏移，正数为延后
+}
+
+// Result 字幕处理结果
+type Result struct {
+	Data     []byte   // 处理后的 UTF-8 字幕内容
+	Format   Format   // 处理后的字幕格式
+	Encoding Encoding // 原始文本编码
+}
+
+// Process 处理文本字幕：转换为 UTF-8，按选项进行简繁转换、格式转换和时间偏移
+// format 为原字幕格式，图形字幕（如 .sup）不支持处理
+func Process(data []byte, format Format, opts Options) (*Result, error) {
+	if format == "" {
+		return nil, fmt.Errorf("不支持处理该字幕格式")
+	}
+	text, enc, err := ToUTF8(data)
+	if err != nil {
+		return nil, fmt.Errorf("转换字幕编码失败: %w", err)
+	}
+
+	content := string(text)
+	switch opts.Chinese {
+	case ChineseConvertNone:
+	case ChineseConvertS2T:
+		content = ToTraditional(content)
+	case ChineseConvertT2S:
+		content = ToSimplified(content)
+	default:
+		return nil, fmt.Errorf("不支持的简繁转换方式: %s", opts.Chinese)
+	}
+
+	target := format
+	if opts.Format != "" {
+		target = opts.Format
+	}
+	if target != format || opts.Offset != 0 {
+		sub, err := Unmarshal(content, format)
+		if err != nil {
+			return nil, err
+		}
+		sub.Shift(opts.Offset)
+		content, err = sub.Marshal(target)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Result{Data: []byte(content), Format: target, Encoding: enc}, nil
+}
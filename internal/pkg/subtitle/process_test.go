@@ -0,0 +1,71 @@
+package subtitle_test
+
+import (
+	"MediaTools/internal/pkg/subtitle"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+const srtSample = "1\r\n00:00:01,500 --> 00:00:03,000\r\n这是第一句字幕\r\n\r\n2\r\n00:00:04,000 --> 00:00:06,250\r\n第二行\r\n还有一行\r\n"
+
+func TestToUTF8(t *testing.T) {
+	gbk, err := simplifiedchinese.GBK.NewEncoder().String(srtSample)
+	require.NoError(t, err)
+	big5, err := traditionalchinese.Big5.NewEncoder().String("1\n00:00:01,500 --> 00:00:03,000\n這是第一句字幕，我們說話\n")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		encoding subtitle.Encoding
+	}{
+		{"UTF-8", []byte(srtSample), subtitle.EncodingUTF8},
+		{"UTF-8 BOM", append([]byte{0xEF, 0xBB, 0xBF}, srtSample...), subtitle.EncodingUTF8},
+		{"GBK", []byte(gbk), subtitle.EncodingGB18030},
+		{"Big5", []byte(big5), subtitle.EncodingBig5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, enc, err := subtitle.ToUTF8(tc.data)
+			require.NoError(t, err)
+			require.Equal(t, tc.encoding, enc)
+			require.NotEqual(t, []byte{0xEF, 0xBB, 0xBF}, out[:3])
+			require.Contains(t, string(out), "第一句字幕")
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	result, err := subtitle.Process([]byte(srtSample), subtitle.FormatSRT, subtitle.Options{
+		Chinese: subtitle.ChineseConvertS2T,
+		Offset:  time.Second,
+	})
+	require.NoError(t, err)
+	require.Equal(t, subtitle.FormatSRT, result.Format)
+	require.Equal(t, "1\n00:00:02,500 --> 00:00:04,000\n這是第一句字幕\n\n2\n00:00:05,000 --> 00:00:07,250\n第二行\n還有一行\n\n", string(result.Data))
+
+	result, err = subtitle.Process([]byte(srtSample), subtitle.FormatSRT, subtitle.Options{Format: subtitle.FormatASS})
+	require.NoError(t, err)
+	require.Equal(t, subtitle.FormatASS, result.Format)
+	require.Contains(t, string(result.Data), "Dialogue: 0,0:00:04.00,0:00:06.25,Default,,0,0,0,,第二行\\N还有一行\n")
+
+	// ASS 转回 SRT 时保持时间和文本
+	back, err := subtitle.Process(result.Data, subtitle.FormatASS, subtitle.Options{Format: subtitle.FormatSRT, Chinese: subtitle.ChineseConvertT2S})
+	require.NoError(t, err)
+	require.Equal(t, "1\n00:00:01,500 --> 00:00:03,000\n这是第一句字幕\n\n2\n00:00:04,000 --> 00:00:06,250\n第二行\n还有一行\n\n", string(back.Data))
+
+	_, err = subtitle.Process([]byte{0x00}, subtitle.ParseFormat(".sup"), subtitle.Options{})
+	require.Error(t, err)
+}
+
+func TestShiftASSKeepStyle(t *testing.T) {
+	ass := "[Script Info]\nTitle: test\n\n[V4+ Styles]\nStyle: Top,Arial,20\n\n[Events]\nFormat: Layer, Start, End, Style, Text\nDialogue: 1,0:00:01.00,0:00:02.00,Top,{\\an8}你好, 世界\n"
+	result, err := subtitle.Process([]byte(ass), subtitle.FormatASS, subtitle.Options{Offset: -500 * time.Millisecond})
+	require.NoError(t, err)
+	require.Contains(t, string(result.Data), "Title: test\n")
+	require.Contains(t, string(result.Data), "Format: Layer, Start, End, Style, Text\nDialogue: 1,0:00:00.50,0:00:01.50,Top,{\\an8}你好, 世界\n")
+}
@@ -71,7 +71,7 @@ func (tq *TaskQueue) SubmitTask(name string, fn TaskFunc) *Task {
 	)
 
 	for ok {
-		id := uuid.New().String()
+		id = uuid.New().String()
 		_, ok = tq.taskMap.Load(id)
 	}
 
@@ -1,8 +1,11 @@
 package themoviedb
 
 import (
+	"MediaTools/internal/pkg/cache"
 	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"image"
@@ -11,6 +14,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/allegro/bigcache"
@@ -24,7 +28,17 @@ type Client struct {
 	imageLanguage string             // Default language for images
 	client        *http.Client       // HTTP client for making requests
 	limiter       *limiter.Limiter   // Rate limiter to control request frequency
-	cache         *bigcache.BigCache // Cache for storing responses
+	retry         retry.Policy       // Retry policy for failed requests
+	loader        *cache.Loader      // Cache for storing API responses
+	ttl           CacheTTL           // Cache TTL for each kind of endpoint
+	imageCache    *bigcache.BigCache // Cache for storing downloaded images
+}
+
+// 各类接口的缓存时间
+type CacheTTL struct {
+	Detail time.Duration // 详情类接口
+	Search time.Duration // 搜索接口
+	Image  time.Duration // 图片列表接口
 }
 
 type clientConfig struct {
@@ -34,6 +48,10 @@ type clientConfig struct {
 	imageLanguage string
 	client        *http.Client
 	limiter       *limiter.Limiter
+	retry         retry.Policy
+	cache         cache.Cache
+	ttl           CacheTTL
+	stale         bool
 }
 type ClientOptions func(c *clientConfig)
 
@@ -73,6 +91,32 @@ func CustomLimiter(d time.Duration, maxCount uint64) ClientOptions {
 	}
 }
 
+func CustomRetryPolicy(policy retry.Policy) ClientOptions {
+	return func(c *clientConfig) {
+		c.retry = policy
+	}
+}
+
+// 自定义缓存后端，默认使用内存缓存
+func CustomCache(c cache.Cache) ClientOptions {
+	return func(cfg *clientConfig) {
+		cfg.cache = c
+	}
+}
+
+func CustomCacheTTL(ttl CacheTTL) ClientOptions {
+	return func(c *clientConfig) {
+		c.ttl = ttl
+	}
+}
+
+// 缓存过期后先返回旧数据，并在后台刷新缓存
+func CustomStaleWhileRevalidate(enable bool) ClientOptions {
+	return func(c *clientConfig) {
+		c.stale = enable
+	}
+}
+
 func NewClient(apiKey string, opts ...ClientOptions) (*Client, error) {
 	c := &clientConfig{
 		apiURL:        "https://api.themoviedb.org",
@@ -81,15 +125,28 @@ func NewClient(apiKey string, opts ...ClientOptions) (*Client, error) {
 		imageLanguage: "zh",
 		client:        &http.Client{},
 		limiter:       limiter.NewLimiter(time.Second, 20), // 默认每秒20次请求
-	}
-
-	cache, err := bigcache.NewBigCache(bigcache.DefaultConfig(10 * time.Minute))
-	if err != nil {
-		return nil, fmt.Errorf("创建 TMDB 缓存失败: %w", err)
+		retry:         retry.DefaultPolicy,
+		ttl: CacheTTL{
+			Detail: 7 * 24 * time.Hour,
+			Search: 24 * time.Hour,
+			Image:  30 * 24 * time.Hour,
+		},
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+
+	if c.cache == nil {
+		memCache, err := cache.NewMemoryCache(10 * time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("创建 TMDB 缓存失败: %w", err)
+		}
+		c.cache = memCache
+	}
+	imageCache, err := bigcache.NewBigCache(bigcache.DefaultConfig(10 * time.Minute))
+	if err != nil {
+		return nil, fmt.Errorf("创建 TMDB 图片缓存失败: %w", err)
+	}
 	client := Client{
 		apiKey:        apiKey,
 		apiURL:        c.apiURL,
@@ -98,99 +155,161 @@ func NewClient(apiKey string, opts ...ClientOptions) (*Client, error) {
 		imageLanguage: c.imageLanguage,
 		client:        c.client,
 		limiter:       c.limiter,
-		cache:         cache,
+		retry:         c.retry,
+		loader:        cache.NewLoader(c.cache, c.stale),
+		ttl:           c.ttl,
+		imageCache:    imageCache,
 	}
 	return &client, nil
 }
 
-func (c *Client) DoRequest(method string, path string, query url.Values, body io.Reader, resp any) error {
+func (c *Client) DoRequest(ctx context.Context, method string, path string, query url.Values, body io.Reader, resp any) error {
 	var (
 		data []byte
 		err  error
 	)
-	cacheKey := method + "|" + path + "|" + query.Encode()
 	if method == http.MethodGet && body == nil {
-		if data, err = c.cache.Get(cacheKey); err == nil {
-			return json.Unmarshal(data, resp)
-		}
+		cacheKey := method + "|" + path + "|" + query.Encode()
+		data, err = c.loader.Load(ctx, cacheKey, c.cacheTTL(path), func(ctx context.Context) ([]byte, error) {
+			return c.fetch(ctx, method, path, query, body)
+		})
+	} else {
+		data, err = c.fetch(ctx, method, path, query, body)
+	}
+	if err != nil {
+		return err
 	}
 
-	c.limiter.Acquire()
-	query.Set("api_key", c.apiKey)
-	url := c.apiURL + "/3" + path + "?" + query.Encode()
-	req, err := http.NewRequest(method, url, body)
+	err = json.Unmarshal(data, resp)
 	if err != nil {
-		return fmt.Errorf("create request failed: %w", err)
+		return fmt.Errorf("unmarshal response failed: %w", err)
+	}
+	return nil
+}
+
+// 发送请求并返回原始响应体
+func (c *Client) fetch(ctx context.Context, method string, path string, query url.Values, body io.Reader) ([]byte, error) {
+	params := url.Values{}
+	for k, v := range query {
+		params[k] = v
+	}
+	params.Set("api_key", c.apiKey)
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read request body failed: %w", err)
+		}
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	res, err := c.client.Do(req)
+
+	url := c.apiURL + "/3" + path + "?" + params.Encode()
+	res, err := c.retry.Do(ctx, c.client, c.limiter, func(ctx context.Context) (*http.Request, error) {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("create request failed: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		return fmt.Errorf("do request failed: %w", err)
+		return nil, fmt.Errorf("do request failed: %w", err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
 		var errResp ErrorResponse
 		if err := json.NewDecoder(res.Body).Decode(&errResp); err != nil {
-			return fmt.Errorf("request failed, status code: %d, message: %s", res.StatusCode, errResp.StatusMessage)
+			return nil, fmt.Errorf("decode error response failed: %w", err)
 		}
-		return fmt.Errorf("decode error response failed: %w", err)
+		return nil, fmt.Errorf("request failed, status code: %d, message: %s", res.StatusCode, errResp.StatusMessage)
 	}
-	data, err = io.ReadAll(res.Body)
+	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return fmt.Errorf("read response body failed: %w", err)
+		return nil, fmt.Errorf("read response body failed: %w", err)
 	}
-	err = json.Unmarshal(data, resp)
-	if err != nil {
-		return fmt.Errorf("unmarshal response failed: %w", err)
+	return data, nil
+}
+
+// 根据接口路径选择缓存时间
+func (c *Client) cacheTTL(path string) time.Duration {
+	switch {
+	case strings.HasPrefix(path, "/search/"):
+		return c.ttl.Search
+	case strings.HasSuffix(path, "/images"):
+		return c.ttl.Image
+	default:
+		return c.ttl.Detail
 	}
+}
 
-	// 写入缓存
-	if method == http.MethodGet && body == nil {
-		if data, err = json.Marshal(resp); err == nil {
-			_ = c.cache.Set(cacheKey, data)
-		}
+// CacheStats 获取缓存统计信息
+func (c *Client) CacheStats() (*cache.Stats, error) {
+	return c.loader.Cache().Stats()
+}
+
+// PurgeCache 清理缓存，expiredOnly 为 true 时只清理已过期的条目
+func (c *Client) PurgeCache(expiredOnly bool) (int64, error) {
+	count, err := c.loader.Cache().Purge(expiredOnly)
+	if err != nil {
+		return 0, err
 	}
-	return nil
+	if !expiredOnly {
+		_ = c.imageCache.Reset()
+	}
+	return count, nil
 }
 
 func (c *Client) GetImageURL(path string) string {
 	return c.imgURL + "/t/p/original" + path
 }
 
-func (c *Client) DownloadImage(path string) (image.Image, error) {
+// DownloadImageData 下载图片原始数据
+func (c *Client) DownloadImageData(ctx context.Context, path string) ([]byte, error) {
 	url := c.GetImageURL(path)
 
 	cacheKey := "IMAGE" + "|" + url
+	data, err := c.imageCache.Get(cacheKey)
+	if err == nil {
+		return data, nil
+	}
 
-	var (
-		data []byte
-		err  error
-	)
-	data, err = c.cache.Get(cacheKey)
+	resp, err := c.retry.Do(ctx, c.client, nil, func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	})
 	if err != nil {
-		resp, err := c.client.Get(url)
-		if err != nil {
-			return nil, NewTMDBError(err, fmt.Sprintf("下载图片「%s」失败", url))
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode != http.StatusOK {
-			return nil, NewTMDBError(nil, fmt.Sprintf("下载图片「%s」失败，HTTP code: %d", url, resp.StatusCode))
-		}
-		data, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, NewTMDBError(err, fmt.Sprintf("读取图片「%s」失败", url))
-		}
+		return nil, NewTMDBError(err, fmt.Sprintf("下载图片「%s」失败", url))
 	}
-
-	img, _, err := image.Decode(bytes.NewReader(data))
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewTMDBError(nil, fmt.Sprintf("下载图片「%s」失败，HTTP code: %d", url, resp.StatusCode))
+	}
+	data, err = io.ReadAll(resp.Body)
 	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("读取图片「%s」失败", url))
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("解码图片「%s」失败", url))
 	}
 
 	go func() {
-		c.cache.Set(cacheKey, data)
+		c.imageCache.Set(cacheKey, data)
 	}()
+	return data, nil
+}
 
+func (c *Client) DownloadImage(ctx context.Context, path string) (image.Image, error) {
+	data, err := c.DownloadImageData(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("解码图片「%s」失败", c.GetImageURL(path)))
+	}
 	return img, nil
 }
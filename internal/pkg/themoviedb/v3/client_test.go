@@ -0,0 +1,143 @@
+package themoviedb_test
+
+import (
+	"MediaTools/internal/pkg/retry"
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockClient(t *testing.T, handler http.HandlerFunc) *themoviedb.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := themoviedb.NewClient("test",
+		themoviedb.CustomAPIURL(server.URL),
+		themoviedb.CustomHTTPClient(server.Client()),
+		themoviedb.CustomRetryPolicy(retry.Policy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    time.Second,
+		}),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClientThrottled(t *testing.T) {
+	var count atomic.Int32
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/3/movie/603", r.URL.Path)
+		if count.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"status_code":25,"status_message":"rate limit"}`))
+			return
+		}
+		w.Write([]byte(`{"id":603,"title":"The Matrix"}`))
+	})
+
+	detail, err := c.GetMovieDetail(context.Background(), 603, nil)
+	require.NoError(t, err)
+	require.Equal(t, "The Matrix", detail.Title)
+	require.Equal(t, int32(2), count.Load())
+}
+
+func TestClientErrorResponse(t *testing.T) {
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status_code":7,"status_message":"Invalid API key"}`))
+	})
+
+	_, err := c.GetMovieDetail(context.Background(), 603, nil)
+	require.ErrorContains(t, err, "Invalid API key")
+}
+
+func TestClientCanceled(t *testing.T) {
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetMovieDetail(ctx, 603, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientDeduplicate(t *testing.T) {
+	var count atomic.Int32
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id":1399,"name":"Game of Thrones"}`))
+	})
+
+	const n = 24
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			detail, err := c.GetTVSerieDetail(context.Background(), 1399, nil)
+			if err == nil && detail.Name != "Game of Thrones" {
+				err = fmt.Errorf("unexpected name: %s", detail.Name)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), count.Load())
+}
+
+func TestClientDeduplicateCanceledWaiter(t *testing.T) {
+	var count atomic.Int32
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"id":1399,"name":"Game of Thrones"}`))
+	})
+
+	// 先发起的请求被取消时，不影响其他等待相同结果的请求
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := c.GetTVSerieDetail(ctx, 1399, nil)
+		canceled <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	detail, err := c.GetTVSerieDetail(context.Background(), 1399, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Game of Thrones", detail.Name)
+	require.ErrorIs(t, <-canceled, context.DeadlineExceeded)
+	require.Equal(t, int32(1), count.Load())
+}
+
+func TestFindByID(t *testing.T) {
+	c := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/3/find/tt0133093", r.URL.Path)
+		assert.Equal(t, "imdb_id", r.URL.Query().Get("external_source"))
+		w.Write([]byte(`{"movie_results":[{"id":603,"title":"The Matrix","release_date":"1999-03-31"}],"tv_results":[],"tv_episode_results":[],"tv_season_results":[]}`))
+	})
+
+	resp, err := c.FindByID(context.Background(), "tt0133093", themoviedb.ExternalSourceIMDB, nil)
+	require.NoError(t, err)
+	require.Len(t, resp.MovieResults, 1)
+	require.Equal(t, 603, resp.MovieResults[0].ID)
+	require.Empty(t, resp.TVResults)
+}
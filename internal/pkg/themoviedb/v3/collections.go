@@ -0,0 +1,79 @@
+package themoviedb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type CollectionDetail struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Overview     string `json:"overview"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+	Parts        []struct {
+		ID            int     `json:"id"`
+		Title         string  `json:"title"`
+		OriginalTitle string  `json:"original_title"`
+		Overview      string  `json:"overview"`
+		PosterPath    string  `json:"poster_path"`
+		BackdropPath  string  `json:"backdrop_path"`
+		ReleaseDate   string  `json:"release_date"`
+		VoteAverage   float64 `json:"vote_average"`
+	} `json:"parts"`
+}
+
+// 通过ID获取合集（系列电影）的详情。
+// Get collection details by ID.
+// https://api.themoviedb.org/3/collection/{collection_id}
+// https://developer.themoviedb.org/reference/collection-details
+func (c *Client) GetCollectionDetail(ctx context.Context, collectionID int, language *string) (*CollectionDetail, error) {
+	params := url.Values{}
+	if language != nil {
+		params.Set("language", *language)
+	} else {
+		params.Set("language", c.language)
+	}
+
+	detail := CollectionDetail{}
+	err := c.DoRequest(ctx, http.MethodGet, "/collection/"+strconv.Itoa(collectionID), params, nil, &detail)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取合集「%d」详情失败：%v", collectionID, err))
+	}
+	return &detail, nil
+}
+
+type CollectionImage struct {
+	ID        int         `json:"id"`
+	Backdrops []ImageInfo `json:"backdrops"`
+	Posters   []ImageInfo `json:"posters"`
+}
+
+// 获取属于某个合集的图片。
+// Get the images that belong to a collection.
+// https://api.themoviedb.org/3/collection/{collection_id}/images
+// https://developer.themoviedb.org/reference/collection-images
+func (c *Client) GetCollectionImage(ctx context.Context, collectionID int, language *string, IncludeImageLanguage *string) (*CollectionImage, error) {
+	params := url.Values{}
+	if language != nil {
+		params.Set("language", *language)
+	} else {
+		params.Set("language", c.language)
+	}
+
+	if IncludeImageLanguage != nil {
+		params.Set("include_image_language", *IncludeImageLanguage)
+	} else {
+		params.Set("include_image_language", c.imageLanguage)
+	}
+
+	img := CollectionImage{}
+	err := c.DoRequest(ctx, http.MethodGet, "/collection/"+strconv.Itoa(collectionID)+"/images", params, nil, &img)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取合集「%d」图片失败：%v", collectionID, err))
+	}
+	return &img, nil
+}
@@ -14,6 +14,10 @@ func (e *TMDBError) Error() string {
 	return fmt.Sprintf("TMDB 错误: %s - %s", e.msg, e.err.Error())
 }
 
+func (e *TMDBError) Unwrap() error {
+	return e.err
+}
+
 func NewTMDBError(err error, msg string) *TMDBError {
 	return &TMDBError{
 		err: err,
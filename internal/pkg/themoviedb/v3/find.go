@@ -0,0 +1,69 @@
+package themoviedb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// 外部 ID 来源
+type ExternalSource string
+
+const (
+	ExternalSourceIMDB ExternalSource = "imdb_id"
+	ExternalSourceTVDB ExternalSource = "tvdb_id"
+)
+
+type FindTVEpisodeResult struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Overview      string `json:"overview"`
+	AirDate       string `json:"air_date"`
+	EpisodeNumber int    `json:"episode_number"`
+	SeasonNumber  int    `json:"season_number"`
+	ShowID        int    `json:"show_id"`
+}
+
+type FindTVSeasonResult struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	AirDate      string `json:"air_date"`
+	SeasonNumber int    `json:"season_number"`
+	ShowID       int    `json:"show_id"`
+}
+
+type FindResponse struct {
+	MovieResults     []SearchMovieResponse `json:"movie_results"`
+	TVResults        []SearchTVResponse    `json:"tv_results"`
+	TVEpisodeResults []FindTVEpisodeResult `json:"tv_episode_results"`
+	TVSeasonResults  []FindTVSeasonResult  `json:"tv_season_results"`
+}
+
+// 通过外部 ID（IMDb、TVDB 等）查找电影、电视剧、季或集。
+// Find data by one of the external sources.
+// https://api.themoviedb.org/3/find/{external_id}
+// https://developer.themoviedb.org/reference/find-by-id
+func (c *Client) FindByID(ctx context.Context, externalID string, source ExternalSource, language *string) (*FindResponse, error) {
+	params := url.Values{}
+	params.Set("external_source", string(source))
+	if language != nil {
+		params.Set("language", *language)
+	} else {
+		params.Set("language", c.language)
+	}
+
+	var resp FindResponse
+	err := c.DoRequest(
+		ctx,
+		http.MethodGet,
+		"/find/"+url.PathEscape(externalID),
+		params,
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("通过外部 ID「%s」（%s）查找失败：%v", externalID, source, err))
+	}
+	return &resp, nil
+}
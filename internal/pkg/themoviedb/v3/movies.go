@@ -1,6 +1,7 @@
 package themoviedb
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -59,7 +60,7 @@ type MovieDetail struct {
 // Get the top level details of a movie by ID.
 // https://api.themoviedb.org/3/movie/{movie_id}
 // https://developer.themoviedb.org/reference/movie-details
-func (c *Client) GetMovieDetail(movieID int, language *string) (*MovieDetail, error) {
+func (c *Client) GetMovieDetail(ctx context.Context, movieID int, language *string) (*MovieDetail, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -68,7 +69,7 @@ func (c *Client) GetMovieDetail(movieID int, language *string) (*MovieDetail, er
 	}
 
 	detail := MovieDetail{}
-	err := c.DoRequest(http.MethodGet, "/movie/"+strconv.Itoa(movieID), params, nil, &detail)
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID), params, nil, &detail)
 	if err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("获取电影详情失败：%v", err))
 	}
@@ -90,7 +91,7 @@ type MovieAlternativeTitle struct {
 // https://developer.themoviedb.org/reference/movie-alternative-titles
 //
 // country 可选，指定国家(指定一个 ISO-3166-1 值来筛选结果)
-func (c *Client) GetMovieAlternativeTitle(movieID int, country *string) (*MovieAlternativeTitle, error) {
+func (c *Client) GetMovieAlternativeTitle(ctx context.Context, movieID int, country *string) (*MovieAlternativeTitle, error) {
 	params := url.Values{}
 	if country != nil {
 		params.Set("country", *country)
@@ -98,7 +99,7 @@ func (c *Client) GetMovieAlternativeTitle(movieID int, country *string) (*MovieA
 
 	var resp MovieAlternativeTitle
 
-	err := c.DoRequest(http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/alternative_titles", params, nil, &resp)
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/alternative_titles", params, nil, &resp)
 	if err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」别名失败：%v", movieID, err))
 	}
@@ -140,7 +141,7 @@ type MovieCredit struct {
 // Get the cast and crew for a movie by its ID.
 // https://api.themoviedb.org/3/movie/{movie_id}/credits
 // https://developer.themoviedb.org/reference/movie-credits
-func (c *Client) GetMovieCredit(movieID int, language *string) (*MovieCredit, error) {
+func (c *Client) GetMovieCredit(ctx context.Context, movieID int, language *string) (*MovieCredit, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -149,7 +150,7 @@ func (c *Client) GetMovieCredit(movieID int, language *string) (*MovieCredit, er
 	}
 
 	var response MovieCredit
-	err := c.DoRequest(http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/credits", params, nil, &response)
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/credits", params, nil, &response)
 	if err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」演员列表失败：%v", movieID, err))
 	}
@@ -168,9 +169,10 @@ type MovieExternalID struct {
 // Get the external IDs for a movie by its ID.
 // https://api.themoviedb.org/3/movie/{movie_id}/external_ids
 // https://developer.themoviedb.org/reference/movie-external-ids
-func (c *Client) GetMovieExternalID(movieID int) (*MovieExternalID, error) {
+func (c *Client) GetMovieExternalID(ctx context.Context, movieID int) (*MovieExternalID, error) {
 	var resp MovieExternalID
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/movie/"+strconv.Itoa(movieID)+"/external_ids",
 		url.Values{},
@@ -183,42 +185,29 @@ func (c *Client) GetMovieExternalID(movieID int) (*MovieExternalID, error) {
 	return &resp, nil
 }
 
+// ImageInfo 图片信息
+type ImageInfo struct {
+	AspectRatio float64 `json:"aspect_ratio"`
+	Height      int     `json:"height"`
+	Iso6391     string  `json:"iso_639_1"`
+	FilePath    string  `json:"file_path"`
+	VoteAverage float64 `json:"vote_average"`
+	VoteCount   int     `json:"vote_count"`
+	Width       int     `json:"width"`
+}
+
 type MovieImage struct {
-	Backdrops []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"backdrops"`
-	ID    int `json:"id"`
-	Logos []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"logos"`
-	Posters []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"posters"`
+	Backdrops []ImageInfo `json:"backdrops"`
+	ID        int         `json:"id"`
+	Logos     []ImageInfo `json:"logos"`
+	Posters   []ImageInfo `json:"posters"`
 }
 
 // 获取属于某部电影的图片。
 // Get the images that belong to a movie.
 // https://api.themoviedb.org/3/movie/{movie_id}/images
 // https://developer.themoviedb.org/reference/movie-images
-func (c *Client) GetMovieImage(movieID int, language *string, IncludeImageLanguage *string) (*MovieImage, error) {
+func (c *Client) GetMovieImage(ctx context.Context, movieID int, language *string, IncludeImageLanguage *string) (*MovieImage, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -233,7 +222,7 @@ func (c *Client) GetMovieImage(movieID int, language *string, IncludeImageLangua
 	}
 
 	img := MovieImage{}
-	err := c.DoRequest(http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/images", params, nil, &img)
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/images", params, nil, &img)
 	if err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」图片失败：%v", movieID, err))
 	}
@@ -252,9 +241,9 @@ type MovieKeyword struct {
 // Get the keywords for a movie by its ID.
 // https://api.themoviedb.org/3/movie/{movie_id}/keywords
 // https://developer.themoviedb.org/reference/movie-keywords
-func (c *Client) GetMovieKeyword(movieID int) (*MovieKeyword, error) {
+func (c *Client) GetMovieKeyword(ctx context.Context, movieID int) (*MovieKeyword, error) {
 	keyword := MovieKeyword{}
-	err := c.DoRequest(http.MethodGet, "/movie/"+strconv.Itoa(int(movieID))+"/keywords", url.Values{}, nil, &keyword)
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(int(movieID))+"/keywords", url.Values{}, nil, &keyword)
 	if err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」关键词失败：%v", movieID, err))
 	}
@@ -285,12 +274,78 @@ type MovieTranslation struct {
 // Get the translations for a movie.
 // https://api.themoviedb.org/3/movie/{movie_id}/translations
 // https://developer.themoviedb.org/reference/movie-translations
-func (c *Client) GetMovieTranslation(movieID int) (*MovieTranslation, error) {
+func (c *Client) GetMovieTranslation(ctx context.Context, movieID int) (*MovieTranslation, error) {
 	params := url.Values{}
 	var resp MovieTranslation
-	err := c.DoRequest(http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/translations", params, nil, &resp)
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/translations", params, nil, &resp)
 	if err != nil {
 		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」翻译失败：%v", movieID, err))
 	}
 	return &resp, nil
 }
+
+type MovieReleaseDate struct {
+	ID      int `json:"id"`
+	Results []struct {
+		Iso31661     string `json:"iso_3166_1"`
+		ReleaseDates []struct {
+			Certification string   `json:"certification"`
+			Descriptors   []string `json:"descriptors"`
+			Iso6391       string   `json:"iso_639_1"`
+			Note          string   `json:"note"`
+			ReleaseDate   string   `json:"release_date"`
+			Type          int      `json:"type"`
+		} `json:"release_dates"`
+	} `json:"results"`
+}
+
+// 获取一部电影在各个国家/地区的上映日期和分级。
+// Get the release dates and certifications for a movie.
+// https://api.themoviedb.org/3/movie/{movie_id}/release_dates
+// https://developer.themoviedb.org/reference/movie-release-dates
+func (c *Client) GetMovieReleaseDate(ctx context.Context, movieID int) (*MovieReleaseDate, error) {
+	var resp MovieReleaseDate
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/release_dates", url.Values{}, nil, &resp)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」上映日期失败：%v", movieID, err))
+	}
+	return &resp, nil
+}
+
+type VideoInfo struct {
+	Iso6391     string `json:"iso_639_1"`
+	Iso31661    string `json:"iso_3166_1"`
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	Site        string `json:"site"`
+	Size        int    `json:"size"`
+	Type        string `json:"type"`
+	Official    bool   `json:"official"`
+	PublishedAt string `json:"published_at"`
+	ID          string `json:"id"`
+}
+
+type MovieVideo struct {
+	ID      int         `json:"id"`
+	Results []VideoInfo `json:"results"`
+}
+
+// 获取已添加到电影中的视频（预告片、花絮等）。
+// Get the videos that have been added to a movie.
+// https://api.themoviedb.org/3/movie/{movie_id}/videos
+// https://developer.themoviedb.org/reference/movie-videos
+func (c *Client) GetMovieVideo(ctx context.Context, movieID int, language *string) (*MovieVideo, error) {
+	params := url.Values{}
+	if language != nil {
+		params.Set("language", *language)
+	} else {
+		params.Set("language", c.language)
+	}
+
+	var resp MovieVideo
+	err := c.DoRequest(ctx, http.MethodGet, "/movie/"+strconv.Itoa(movieID)+"/videos", params, nil, &resp)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取电影「%d」视频失败：%v", movieID, err))
+	}
+	return &resp, nil
+}
@@ -2,6 +2,7 @@ package themoviedb_test
 
 import (
 	"MediaTools/internal/pkg/themoviedb/v3"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,26 +20,26 @@ func init() {
 }
 
 func TestGetMovieDetail(t *testing.T) {
-	movieDetail, err := client.GetMovieDetail(MovieID, nil)
+	movieDetail, err := client.GetMovieDetail(context.Background(), MovieID, nil)
 	require.NoError(t, err)
 	require.NotNil(t, movieDetail)
 	require.Equal(t, MovieID, movieDetail.ID)
 }
 
 func TestGetMovieAlternativeTitle(t *testing.T) {
-	titles, err := client.GetMovieAlternativeTitle(MovieID, nil)
+	titles, err := client.GetMovieAlternativeTitle(context.Background(), MovieID, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, titles.Titles)
 }
 
 func TestGetMovieTranslation(t *testing.T) {
-	translation, err := client.GetMovieTranslation(MovieID)
+	translation, err := client.GetMovieTranslation(context.Background(), MovieID)
 	require.NoError(t, err)
 	require.NotEmpty(t, translation.Translations)
 }
 
 func TestGetMovieCredit(t *testing.T) {
-	credit, err := client.GetMovieCredit(MovieID, nil)
+	credit, err := client.GetMovieCredit(context.Background(), MovieID, nil)
 	require.NoError(t, err)
 	require.NotNil(t, credit)
 	require.NotEmpty(t, credit.Cast)
@@ -46,12 +47,12 @@ func TestGetMovieCredit(t *testing.T) {
 }
 
 func TestGetMovieImage(t *testing.T) {
-	_, err := client.GetMovieImage(MovieID, nil, nil)
+	_, err := client.GetMovieImage(context.Background(), MovieID, nil, nil)
 	require.NoError(t, err)
 }
 
 func TestGetMovieKeyword(t *testing.T) {
-	keywords, err := client.GetMovieKeyword(MovieID)
+	keywords, err := client.GetMovieKeyword(context.Background(), MovieID)
 	require.NoError(t, err)
 	require.NotNil(t, keywords)
 	require.NotEmpty(t, keywords.Keywords)
@@ -2,6 +2,7 @@ package themoviedb
 
 import (
 	"MediaTools/utils"
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -178,12 +179,13 @@ type SearchPersonResponse struct {
 // 按收藏的原名、译名及别名进行搜索。
 // Search for collections by their original, translated and alternative names.
 // https://developer.themoviedb.org/reference/search-collection
-func (c *Client) SearchCollection(params SearchCollectionParams) (*SearchResponse[SearchCollectionResponse], error) {
+func (c *Client) SearchCollection(ctx context.Context, params SearchCollectionParams) (*SearchResponse[SearchCollectionResponse], error) {
 	var resp SearchResponse[SearchCollectionResponse]
 	if params.Language == nil {
 		params.Language = &c.language
 	}
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/collection",
 		utils.StructToQuery(params),
@@ -199,9 +201,10 @@ func (c *Client) SearchCollection(params SearchCollectionParams) (*SearchRespons
 // 按公司的原名和别名搜索。
 // Search for companies by their original and alternative names.
 // https://developer.themoviedb.org/reference/search-company
-func (c *Client) SearchCompany(params SearchCompanyParams) ([]SearchCompanyResponse, error) {
+func (c *Client) SearchCompany(ctx context.Context, params SearchCompanyParams) ([]SearchCompanyResponse, error) {
 	var resp SearchResponse[SearchCompanyResponse]
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/company",
 		utils.StructToQuery(params),
@@ -217,9 +220,10 @@ func (c *Client) SearchCompany(params SearchCompanyParams) ([]SearchCompanyRespo
 // 按名称搜索关键词。
 // Search for keywords by their name.
 // https://developer.themoviedb.org/reference/search-keyword
-func (c *Client) SearchKeyword(params SearchKeywordParams) (*SearchResponse[SearchKeywordResponse], error) {
+func (c *Client) SearchKeyword(ctx context.Context, params SearchKeywordParams) (*SearchResponse[SearchKeywordResponse], error) {
 	var resp SearchResponse[SearchKeywordResponse]
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/keyword",
 		utils.StructToQuery(params),
@@ -235,12 +239,13 @@ func (c *Client) SearchKeyword(params SearchKeywordParams) (*SearchResponse[Sear
 // 按电影的原名、译名和别名搜索。
 // Search for movies by their original, translated and alternative titles.
 // https://developer.themoviedb.org/reference/search-movie
-func (c *Client) SearchMovie(params SearchMovieParams) (*SearchResponse[SearchMovieResponse], error) {
+func (c *Client) SearchMovie(ctx context.Context, params SearchMovieParams) (*SearchResponse[SearchMovieResponse], error) {
 	var resp SearchResponse[SearchMovieResponse]
 	if params.Language == nil {
 		params.Language = &c.language
 	}
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/movie", utils.StructToQuery(params),
 		nil,
@@ -255,12 +260,13 @@ func (c *Client) SearchMovie(params SearchMovieParams) (*SearchResponse[SearchMo
 // 当你想在一次请求中搜索电影、电视节目和人物时，请使用多重搜索。
 // Use multi search when you want to search for movies, TV shows and people in a single request.
 // https://developer.themoviedb.org/reference/search-multi
-func (c *Client) SearchMulti(params SearchMultiParams) (*SearchResponse[SearchMultiResponse], error) {
+func (c *Client) SearchMulti(ctx context.Context, params SearchMultiParams) (*SearchResponse[SearchMultiResponse], error) {
 	var resp SearchResponse[SearchMultiResponse]
 	if params.Language == nil {
 		params.Language = &c.language
 	}
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/multi",
 		utils.StructToQuery(params),
@@ -276,12 +282,13 @@ func (c *Client) SearchMulti(params SearchMultiParams) (*SearchResponse[SearchMu
 // 按人名及其曾用名搜索人物。
 // Search for people by their name and also known as names.
 // https://developer.themoviedb.org/reference/search-person
-func (c *Client) SearchPerson(params SearchPersonParams) (*SearchResponse[SearchPersonResponse], error) {
+func (c *Client) SearchPerson(ctx context.Context, params SearchPersonParams) (*SearchResponse[SearchPersonResponse], error) {
 	var resp SearchResponse[SearchPersonResponse]
 	if params.Language == nil {
 		params.Language = &c.language
 	}
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/person",
 		utils.StructToQuery(params),
@@ -297,12 +304,13 @@ func (c *Client) SearchPerson(params SearchPersonParams) (*SearchResponse[Search
 // 按电视节目的原名、译名及别名搜索。
 // Search for TV shows by their original, translated and also known as names.
 // https://developer.themoviedb.org/reference/search-tv
-func (c *Client) SearchTV(params SearchTVSParams) (*SearchResponse[SearchTVResponse], error) {
+func (c *Client) SearchTV(ctx context.Context, params SearchTVSParams) (*SearchResponse[SearchTVResponse], error) {
 	var resp SearchResponse[SearchTVResponse]
 	if params.Language == nil {
 		params.Language = &c.language
 	}
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/search/tv",
 		utils.StructToQuery(params),
@@ -0,0 +1,93 @@
+package themoviedb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+type TVEpisodeGroupDetail struct {
+	Description  string           `json:"description"`
+	EpisodeCount int              `json:"episode_count"`
+	GroupCount   int              `json:"group_count"`
+	Groups       []TVEpisodeGroup `json:"groups"`
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Network      struct {
+		ID            int    `json:"id"`
+		LogoPath      string `json:"logo_path"`
+		Name          string `json:"name"`
+		OriginCountry string `json:"origin_country"`
+	} `json:"network"`
+	Type int `json:"type"`
+}
+
+type TVEpisodeGroup struct {
+	ID       string                  `json:"id"`
+	Name     string                  `json:"name"`
+	Order    int                     `json:"order"`
+	Episodes []TVEpisodeGroupEpisode `json:"episodes"`
+	Locked   bool                    `json:"locked"`
+}
+
+type TVEpisodeGroupEpisode struct {
+	AirDate        string  `json:"air_date"`
+	EpisodeNumber  int     `json:"episode_number"`
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	Overview       string  `json:"overview"`
+	ProductionCode string  `json:"production_code"`
+	Runtime        int     `json:"runtime"`
+	SeasonNumber   int     `json:"season_number"`
+	ShowID         int     `json:"show_id"`
+	StillPath      string  `json:"still_path"`
+	VoteAverage    float64 `json:"vote_average"`
+	VoteCount      int     `json:"vote_count"`
+	Order          int     `json:"order"` // 在分组中的序号，从 0 开始
+}
+
+// 获取剧集组的详细信息。
+// Get the details of a TV episode group.
+// https://api.themoviedb.org/3/tv/episode_group/{tv_episode_group_id}
+// https://developer.themoviedb.org/reference/tv-episode-group-details
+func (c *Client) GetTVEpisodeGroupDetail(ctx context.Context, groupID string) (*TVEpisodeGroupDetail, error) {
+	var resp TVEpisodeGroupDetail
+	err := c.DoRequest(
+		ctx,
+		http.MethodGet,
+		"/tv/episode_group/"+url.PathEscape(groupID),
+		url.Values{},
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取剧集组「%s」详情失败：%v", groupID, err))
+	}
+	return &resp, nil
+}
+
+// Locate 在剧集组中查找对应的播出季和集
+// season 为剧集组中的分组序号（-1 表示把所有分组视为连续编号），episode 为分组内的集序号（从 1 开始）
+func (g *TVEpisodeGroupDetail) Locate(season int, episode int) (seasonNumber int, episodeNumber int, ok bool) {
+	groups := slices.Clone(g.Groups)
+	slices.SortStableFunc(groups, func(a, b TVEpisodeGroup) int { return a.Order - b.Order })
+
+	index := episode - 1
+	for _, group := range groups {
+		if season != -1 && group.Order != season {
+			continue
+		}
+		episodes := slices.Clone(group.Episodes)
+		slices.SortStableFunc(episodes, func(a, b TVEpisodeGroupEpisode) int { return a.Order - b.Order })
+		if index >= 0 && index < len(episodes) {
+			return episodes[index].SeasonNumber, episodes[index].EpisodeNumber, true
+		}
+		if season != -1 {
+			break
+		}
+		index -= len(episodes)
+	}
+	return 0, 0, false
+}
@@ -1,6 +1,7 @@
 package themoviedb
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -52,7 +53,7 @@ type TVEpisodeDetail struct {
 // Query the details of a TV episode.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/episode/{episode_number}
 // https://developer.themoviedb.org/reference/tv-episode-details
-func (c *Client) GetTVEpisodeDetail(seriesID int, seasonNumber int, episodeNumber int, language *string) (*TVEpisodeDetail, error) {
+func (c *Client) GetTVEpisodeDetail(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int, language *string) (*TVEpisodeDetail, error) {
 	var resp TVEpisodeDetail
 
 	params := url.Values{}
@@ -63,6 +64,7 @@ func (c *Client) GetTVEpisodeDetail(seriesID int, seasonNumber int, episodeNumbe
 	}
 
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/season/"+strconv.Itoa(int(seasonNumber))+"/episode/"+strconv.Itoa(int(episodeNumber)),
 		params,
@@ -122,7 +124,7 @@ type TVEpisodeCredit struct {
 // Get the cast and crew for a TV episode by its ID.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/episode/{episode_number}/credits
 // https://developer.themoviedb.org/reference/tv-episode-credits
-func (c *Client) GetTVEpisodeCredit(seriesID int, seasonNumber int, episodeNumber int, language *string) (*TVEpisodeCredit, error) {
+func (c *Client) GetTVEpisodeCredit(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int, language *string) (*TVEpisodeCredit, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -132,6 +134,7 @@ func (c *Client) GetTVEpisodeCredit(seriesID int, seasonNumber int, episodeNumbe
 
 	var resp TVEpisodeCredit
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(seriesID)+"/season/"+strconv.Itoa(seasonNumber)+"/episode/"+strconv.Itoa(episodeNumber)+"/credits",
 		params,
@@ -158,10 +161,11 @@ type TVEpisodeExternalID struct {
 // Get a list of external IDs that have been added to a TV episode.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/episode/{episode_number}/external_ids
 // https://developer.themoviedb.org/reference/tv-episode-external-ids
-func (c *Client) GetTVEpisodeExternalID(seriesID int, seasonNumber int, episodeNumber int) (*TVEpisodeExternalID, error) {
+func (c *Client) GetTVEpisodeExternalID(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int) (*TVEpisodeExternalID, error) {
 	var resp TVEpisodeExternalID
 
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(seriesID)+"/season/"+strconv.Itoa(seasonNumber)+"/episode/"+strconv.Itoa(episodeNumber)+"/external_ids",
 		url.Values{},
@@ -175,23 +179,15 @@ func (c *Client) GetTVEpisodeExternalID(seriesID int, seasonNumber int, episodeN
 }
 
 type TVEpisodeImage struct {
-	ID     int `json:"id"`
-	Stills []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"stills"`
+	ID     int         `json:"id"`
+	Stills []ImageInfo `json:"stills"`
 }
 
 // 获取属于电视剧单集的图片。
 // Get the images that belong to a TV episode.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/episode/{episode_number}/images
 // https://developer.themoviedb.org/reference/tv-episode-images
-func (c *Client) GetTVEpisodeImage(seriesID int, seasonNumber int, episodeNumber int, language *string) (*TVEpisodeImage, error) {
+func (c *Client) GetTVEpisodeImage(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int, language *string) (*TVEpisodeImage, error) {
 	var img TVEpisodeImage
 
 	params := url.Values{}
@@ -202,6 +198,7 @@ func (c *Client) GetTVEpisodeImage(seriesID int, seasonNumber int, episodeNumber
 	}
 
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/season/"+strconv.Itoa(int(seasonNumber))+"/episode/"+strconv.Itoa(int(episodeNumber))+"/images",
 		params,
@@ -213,3 +210,37 @@ func (c *Client) GetTVEpisodeImage(seriesID int, seasonNumber int, episodeNumber
 	}
 	return &img, nil
 }
+
+type TVEpisodeTranslation struct {
+	ID           int `json:"id"`
+	Translations []struct {
+		Iso31661    string `json:"iso_3166_1"`
+		Iso6391     string `json:"iso_639_1"`
+		Name        string `json:"name"`
+		EnglishName string `json:"english_name"`
+		Data        struct {
+			Name     string `json:"name"`
+			Overview string `json:"overview"`
+		} `json:"data"`
+	} `json:"translations"`
+}
+
+// 获取已添加到电视剧单集中的翻译内容。
+// Get the translations that have been added to a TV episode.
+// https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/episode/{episode_number}/translations
+// https://developer.themoviedb.org/reference/tv-episode-translations
+func (c *Client) GetTVEpisodeTranslation(ctx context.Context, seriesID int, seasonNumber int, episodeNumber int) (*TVEpisodeTranslation, error) {
+	var resp TVEpisodeTranslation
+	err := c.DoRequest(
+		ctx,
+		http.MethodGet,
+		"/tv/"+strconv.Itoa(seriesID)+"/season/"+strconv.Itoa(seasonNumber)+"/episode/"+strconv.Itoa(episodeNumber)+"/translations",
+		url.Values{},
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取电视剧「%d 第 %d 季 第 %d 集」翻译失败：%v", seriesID, seasonNumber, episodeNumber, err))
+	}
+	return &resp, nil
+}
@@ -1,6 +1,7 @@
 package themoviedb
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -62,7 +63,7 @@ type TVSeasonDetail struct {
 // Query the details of a TV season.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}
 // https://developer.themoviedb.org/reference/tv-season-details
-func (c *Client) GetTVSeasonDetail(seriesID int, seasonNumber int, language *string) (*TVSeasonDetail, error) {
+func (c *Client) GetTVSeasonDetail(ctx context.Context, seriesID int, seasonNumber int, language *string) (*TVSeasonDetail, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -72,6 +73,7 @@ func (c *Client) GetTVSeasonDetail(seriesID int, seasonNumber int, language *str
 
 	var resp TVSeasonDetail
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/season/"+strconv.Itoa(int(seasonNumber)),
 		params,
@@ -126,7 +128,7 @@ type TVSeasonCredit struct {
 // Get the cast and crew for a TV season by its ID.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/credits
 // https://developer.themoviedb.org/reference/tv-season-credits
-func (c *Client) GetTVSeasonCredit(seriesID int, seasonNumber int, language *string) (*TVSeasonCredit, error) {
+func (c *Client) GetTVSeasonCredit(ctx context.Context, seriesID int, seasonNumber int, language *string) (*TVSeasonCredit, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -136,6 +138,7 @@ func (c *Client) GetTVSeasonCredit(seriesID int, seasonNumber int, language *str
 
 	var resp TVSeasonCredit
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(seriesID)+"/season/"+strconv.Itoa(seasonNumber)+"/credits",
 		params,
@@ -161,9 +164,10 @@ type TVSeasonExternalID struct {
 // Get a list of external IDs that have been added to a TV season.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/external_ids
 // https://developer.themoviedb.org/reference/tv-season-external-ids
-func (c *Client) GetTVSeasonExternalID(seriesID int, seasonNumber int) (*TVSeasonExternalID, error) {
+func (c *Client) GetTVSeasonExternalID(ctx context.Context, seriesID int, seasonNumber int) (*TVSeasonExternalID, error) {
 	var resp TVSeasonExternalID
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(seriesID)+"/season/"+strconv.Itoa(seasonNumber)+"/external_ids",
 		url.Values{},
@@ -177,41 +181,17 @@ func (c *Client) GetTVSeasonExternalID(seriesID int, seasonNumber int) (*TVSeaso
 }
 
 type TVSeasonImage struct {
-	Backdrops []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"backdrops"`
-	ID    int `json:"id"`
-	Logos []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"logos"`
-	Posters []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"posters"`
+	Backdrops []ImageInfo `json:"backdrops"`
+	ID        int         `json:"id"`
+	Logos     []ImageInfo `json:"logos"`
+	Posters   []ImageInfo `json:"posters"`
 }
 
 // 获取属于某一电视剧季的图片。
 // Get the images that belong to a TV season.
 // https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/images
 // https://developer.themoviedb.org/reference/tv-season-images
-func (c *Client) GetTVSeasonImage(series_id int, season_number int, language *string) (*TVSeasonImage, error) {
+func (c *Client) GetTVSeasonImage(ctx context.Context, series_id int, season_number int, language *string, IncludeImageLanguage *string) (*TVSeasonImage, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -219,8 +199,15 @@ func (c *Client) GetTVSeasonImage(series_id int, season_number int, language *st
 		params.Set("language", c.language)
 	}
 
+	if IncludeImageLanguage != nil {
+		params.Set("include_image_language", *IncludeImageLanguage)
+	} else {
+		params.Set("include_image_language", c.imageLanguage)
+	}
+
 	var resp TVSeasonImage
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(series_id))+"/season/"+strconv.Itoa(int(season_number))+"/images",
 		params,
@@ -232,3 +219,37 @@ func (c *Client) GetTVSeasonImage(series_id int, season_number int, language *st
 	}
 	return &resp, nil
 }
+
+type TVSeasonTranslation struct {
+	ID           int `json:"id"`
+	Translations []struct {
+		Iso31661    string `json:"iso_3166_1"`
+		Iso6391     string `json:"iso_639_1"`
+		Name        string `json:"name"`
+		EnglishName string `json:"english_name"`
+		Data        struct {
+			Name     string `json:"name"`
+			Overview string `json:"overview"`
+		} `json:"data"`
+	} `json:"translations"`
+}
+
+// 获取已添加到电视剧季中的翻译内容。
+// Get the translations for a TV season.
+// https://api.themoviedb.org/3/tv/{series_id}/season/{season_number}/translations
+// https://developer.themoviedb.org/reference/tv-season-translations
+func (c *Client) GetTVSeasonTranslation(ctx context.Context, seriesID int, seasonNumber int) (*TVSeasonTranslation, error) {
+	var resp TVSeasonTranslation
+	err := c.DoRequest(
+		ctx,
+		http.MethodGet,
+		"/tv/"+strconv.Itoa(seriesID)+"/season/"+strconv.Itoa(seasonNumber)+"/translations",
+		url.Values{},
+		nil,
+		&resp,
+	)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取电视剧「%d 第 %d 季」翻译失败：%v", seriesID, seasonNumber, err))
+	}
+	return &resp, nil
+}
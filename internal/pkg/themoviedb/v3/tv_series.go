@@ -1,9 +1,11 @@
 package themoviedb
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 )
 
@@ -81,16 +83,7 @@ type TVSerieDetail struct {
 		Iso31661 string `json:"iso_3166_1"`
 		Name     string `json:"name"`
 	} `json:"production_countries"`
-	Seasons []struct {
-		AirDate      string  `json:"air_date"`
-		EpisodeCount int     `json:"episode_count"`
-		ID           int     `json:"id"`
-		Name         string  `json:"name"`
-		Overview     string  `json:"overview"`
-		PosterPath   string  `json:"poster_path"`
-		SeasonNumber int     `json:"season_number"`
-		VoteAverage  float64 `json:"vote_average"`
-	} `json:"seasons"`
+	Seasons         []TVSerieSeason `json:"seasons"`
 	SpokenLanguages []struct {
 		EnglishName string `json:"english_name"`
 		Iso6391     string `json:"iso_639_1"`
@@ -103,11 +96,22 @@ type TVSerieDetail struct {
 	VoteCount   int     `json:"vote_count"`
 }
 
+type TVSerieSeason struct {
+	AirDate      string  `json:"air_date"`
+	EpisodeCount int     `json:"episode_count"`
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Overview     string  `json:"overview"`
+	PosterPath   string  `json:"poster_path"`
+	SeasonNumber int     `json:"season_number"`
+	VoteAverage  float64 `json:"vote_average"`
+}
+
 // 获取一部电视剧的详细信息。
 // Get the details of a TV show.
 // https://api.themoviedb.org/3/tv/{series_id}
 // https://developer.themoviedb.org/reference/tv-series-details
-func (c *Client) GetTVSerieDetail(seriesID int, language *string) (*TVSerieDetail, error) {
+func (c *Client) GetTVSerieDetail(ctx context.Context, seriesID int, language *string) (*TVSerieDetail, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -117,6 +121,7 @@ func (c *Client) GetTVSerieDetail(seriesID int, language *string) (*TVSerieDetai
 
 	var resp TVSerieDetail
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID)),
 		params,
@@ -129,6 +134,25 @@ func (c *Client) GetTVSerieDetail(seriesID int, language *string) (*TVSerieDetai
 	return &resp, nil
 }
 
+// AbsoluteToAired 按各季集数把绝对集数换算为播出季和集，跳过第 0 季（特别篇）
+func (d *TVSerieDetail) AbsoluteToAired(absolute int) (seasonNumber int, episodeNumber int, ok bool) {
+	if absolute < 1 {
+		return 0, 0, false
+	}
+	seasons := slices.Clone(d.Seasons)
+	slices.SortStableFunc(seasons, func(a, b TVSerieSeason) int { return a.SeasonNumber - b.SeasonNumber })
+	for _, season := range seasons {
+		if season.SeasonNumber == 0 {
+			continue
+		}
+		if absolute <= season.EpisodeCount {
+			return season.SeasonNumber, absolute, true
+		}
+		absolute -= season.EpisodeCount
+	}
+	return 0, 0, false
+}
+
 type TVSerieCredit struct {
 	Cast []struct {
 		Adult              bool    `json:"adult"`
@@ -171,7 +195,7 @@ type TVSerieCredit struct {
 // Get the latest season credits of a TV show.
 // https://api.themoviedb.org/3/tv/{series_id}/credits
 // https://developer.themoviedb.org/reference/tv-series-credits
-func (c *Client) GetTVSerieCredit(seriesID int, language *string) (*TVSerieCredit, error) {
+func (c *Client) GetTVSerieCredit(ctx context.Context, seriesID int, language *string) (*TVSerieCredit, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -180,6 +204,7 @@ func (c *Client) GetTVSerieCredit(seriesID int, language *string) (*TVSerieCredi
 	}
 	var resp TVSerieCredit
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(seriesID),
 		params,
@@ -207,7 +232,7 @@ type TVSerieAlternativeTitle struct {
 // https://developer.themoviedb.org/reference/tv-series-alternative-titles
 //
 // country 可选，指定国家(指定一个 ISO-3166-1 值来筛选结果)
-func (c *Client) GetTVSerieAlternativeTitle(seriesID int, country *string) (*TVSerieAlternativeTitle, error) {
+func (c *Client) GetTVSerieAlternativeTitle(ctx context.Context, seriesID int, country *string) (*TVSerieAlternativeTitle, error) {
 	params := url.Values{}
 	if country != nil {
 		params.Set("country", *country)
@@ -215,6 +240,7 @@ func (c *Client) GetTVSerieAlternativeTitle(seriesID int, country *string) (*TVS
 
 	var resp TVSerieAlternativeTitle
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/alternative_titles",
 		params,
@@ -249,10 +275,11 @@ type TVSerieEpisodeGroup struct {
 // Get the episode groups that have been added to a TV show.
 // https://api.themoviedb.org/3/tv/{series_id}/episode_groups
 // https://developer.themoviedb.org/reference/tv-series-episode-groups
-func (c *Client) GetTVSerieEpisodeGroup(seriesID int) (*TVSerieEpisodeGroup, error) {
+func (c *Client) GetTVSerieEpisodeGroup(ctx context.Context, seriesID int) (*TVSerieEpisodeGroup, error) {
 	params := url.Values{}
 	var resp TVSerieEpisodeGroup
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/episode_groups",
 		params,
@@ -282,9 +309,10 @@ type TVSerieExternalID struct {
 // Get a list of external IDs that have been added to a TV show.
 // https://api.themoviedb.org/3/tv/{series_id}/external_ids
 // https://developer.themoviedb.org/reference/tv-series-external-ids
-func (c *Client) GetTVSerieExternalID(seriesID int) (*TVSerieExternalID, error) {
+func (c *Client) GetTVSerieExternalID(ctx context.Context, seriesID int) (*TVSerieExternalID, error) {
 	var resp TVSerieExternalID
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(seriesID)+"/external_ids",
 		url.Values{},
@@ -298,41 +326,17 @@ func (c *Client) GetTVSerieExternalID(seriesID int) (*TVSerieExternalID, error)
 }
 
 type TVSerieImage struct {
-	Backdrops []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"backdrops"`
-	ID    int `json:"id"`
-	Logos []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"logos"`
-	Posters []struct {
-		AspectRatio float64 `json:"aspect_ratio"`
-		Height      int     `json:"height"`
-		Iso6391     string  `json:"iso_639_1"`
-		FilePath    string  `json:"file_path"`
-		VoteAverage float64 `json:"vote_average"`
-		VoteCount   int     `json:"vote_count"`
-		Width       int     `json:"width"`
-	} `json:"posters"`
+	Backdrops []ImageInfo `json:"backdrops"`
+	ID        int         `json:"id"`
+	Logos     []ImageInfo `json:"logos"`
+	Posters   []ImageInfo `json:"posters"`
 }
 
 // 获取属于某部电视剧的图片。
 // Get the images that belong to a TV series.
 // https://api.themoviedb.org/3/tv/{series_id}/images
 // https://developer.themoviedb.org/reference/tv-series-images
-func (c *Client) GetTVSerieImage(seriesID int, IncludeImageLanguage *string, language *string) (*TVSerieImage, error) {
+func (c *Client) GetTVSerieImage(ctx context.Context, seriesID int, IncludeImageLanguage *string, language *string) (*TVSerieImage, error) {
 	params := url.Values{}
 	if language != nil {
 		params.Set("language", *language)
@@ -348,6 +352,7 @@ func (c *Client) GetTVSerieImage(seriesID int, IncludeImageLanguage *string, lan
 
 	var resp TVSerieImage
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/images",
 		params,
@@ -372,9 +377,10 @@ type TVSerieKeyword struct {
 // Get the keywords for a movie by its ID.
 // https://api.themoviedb.org/3/tv/{series_id}/keywords
 // https://developer.themoviedb.org/reference/movie-keywords
-func (c *Client) GetTVSerieKeyword(seriesID int) (*TVSerieKeyword, error) {
+func (c *Client) GetTVSerieKeyword(ctx context.Context, seriesID int) (*TVSerieKeyword, error) {
 	var resp TVSerieKeyword
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/keywords",
 		url.Values{},
@@ -407,9 +413,10 @@ type TVSerieTranslation struct {
 // Get the translations that have been added to a TV show.
 // https://api.themoviedb.org/3/tv/{series_id}/translations
 // https://developer.themoviedb.org/reference/tv-series-translations
-func (c *Client) GetTVSerieTranslation(seriesID int) (*TVSerieTranslation, error) {
+func (c *Client) GetTVSerieTranslation(ctx context.Context, seriesID int) (*TVSerieTranslation, error) {
 	var resp TVSerieTranslation
 	err := c.DoRequest(
+		ctx,
 		http.MethodGet,
 		"/tv/"+strconv.Itoa(int(seriesID))+"/translations",
 		url.Values{},
@@ -421,3 +428,50 @@ func (c *Client) GetTVSerieTranslation(seriesID int) (*TVSerieTranslation, error
 	}
 	return &resp, nil
 }
+
+type TVSerieContentRating struct {
+	ID      int `json:"id"`
+	Results []struct {
+		Descriptors []string `json:"descriptors"`
+		Iso31661    string   `json:"iso_3166_1"`
+		Rating      string   `json:"rating"`
+	} `json:"results"`
+}
+
+// 获取已添加到电视剧中的内容分级。
+// Get the content ratings that have been added to a TV show.
+// https://api.themoviedb.org/3/tv/{series_id}/content_ratings
+// https://developer.themoviedb.org/reference/tv-series-content-ratings
+func (c *Client) GetTVSerieContentRating(ctx context.Context, seriesID int) (*TVSerieContentRating, error) {
+	var resp TVSerieContentRating
+	err := c.DoRequest(ctx, http.MethodGet, "/tv/"+strconv.Itoa(seriesID)+"/content_ratings", url.Values{}, nil, &resp)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取电视剧「%d」内容分级失败：%v", seriesID, err))
+	}
+	return &resp, nil
+}
+
+type TVSerieVideo struct {
+	ID      int         `json:"id"`
+	Results []VideoInfo `json:"results"`
+}
+
+// 获取已添加到电视剧中的视频（预告片、花絮等）。
+// Get the videos that belong to a TV show.
+// https://api.themoviedb.org/3/tv/{series_id}/videos
+// https://developer.themoviedb.org/reference/tv-series-videos
+func (c *Client) GetTVSerieVideo(ctx context.Context, seriesID int, language *string) (*TVSerieVideo, error) {
+	params := url.Values{}
+	if language != nil {
+		params.Set("language", *language)
+	} else {
+		params.Set("language", c.language)
+	}
+
+	var resp TVSerieVideo
+	err := c.DoRequest(ctx, http.MethodGet, "/tv/"+strconv.Itoa(seriesID)+"/videos", params, nil, &resp)
+	if err != nil {
+		return nil, NewTMDBError(err, fmt.Sprintf("获取电视剧「%d」视频失败：%v", seriesID, err))
+	}
+	return &resp, nil
+}
@@ -0,0 +1,68 @@
+package themoviedb_test
+
+import (
+	"MediaTools/internal/pkg/themoviedb/v3"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTVSerieAbsoluteToAired(t *testing.T) {
+	var detail themoviedb.TVSerieDetail
+	require.NoError(t, json.Unmarshal([]byte(`{"seasons":[
+		{"season_number":0,"episode_count":5},
+		{"season_number":2,"episode_count":13},
+		{"season_number":1,"episode_count":12}
+	]}`), &detail))
+
+	cases := []struct {
+		absolute, season, episode int
+		ok                        bool
+	}{
+		{1, 1, 1, true},
+		{12, 1, 12, true},
+		{13, 2, 1, true},
+		{25, 2, 13, true},
+		{26, 0, 0, false},
+		{0, 0, 0, false},
+	}
+	for _, c := range cases {
+		season, episode, ok := detail.AbsoluteToAired(c.absolute)
+		require.Equal(t, c.ok, ok, "absolute %d", c.absolute)
+		require.Equal(t, c.season, season, "absolute %d", c.absolute)
+		require.Equal(t, c.episode, episode, "absolute %d", c.absolute)
+	}
+}
+
+func TestTVEpisodeGroupLocate(t *testing.T) {
+	var group themoviedb.TVEpisodeGroupDetail
+	require.NoError(t, json.Unmarshal([]byte(`{"groups":[
+		{"order":2,"episodes":[
+			{"order":1,"season_number":1,"episode_number":5},
+			{"order":0,"season_number":0,"episode_number":1}
+		]},
+		{"order":1,"episodes":[
+			{"order":0,"season_number":1,"episode_number":1},
+			{"order":1,"season_number":1,"episode_number":2},
+			{"order":2,"season_number":1,"episode_number":3}
+		]}
+	]}`), &group))
+
+	season, episode, ok := group.Locate(2, 1)
+	require.True(t, ok)
+	require.Equal(t, []int{0, 1}, []int{season, episode})
+
+	season, episode, ok = group.Locate(1, 3)
+	require.True(t, ok)
+	require.Equal(t, []int{1, 3}, []int{season, episode})
+
+	season, episode, ok = group.Locate(-1, 5)
+	require.True(t, ok)
+	require.Equal(t, []int{1, 5}, []int{season, episode})
+
+	_, _, ok = group.Locate(1, 4)
+	require.False(t, ok)
+	_, _, ok = group.Locate(-1, 6)
+	require.False(t, ok)
+}
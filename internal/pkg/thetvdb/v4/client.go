@@ -0,0 +1,189 @@
+package thetvdb
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type TVDBClient struct {
+	api      string
+	apiKey   string
+	pin      string
+	language string
+	client   *http.Client
+	limiter  *limiter.Limiter
+	retry    retry.Policy
+	loader   *cache.Loader
+	cacheTTL time.Duration
+
+	tokenLock sync.Mutex
+	token     string // 登录后获取的 Bearer Token，有效期一个月
+}
+
+func NewClient(apiKey string, opts ...Options) (*TVDBClient, error) {
+	opt := &options{
+		apiURL:   "https://api4.thetvdb.com",
+		client:   &http.Client{},
+		limiter:  limiter.NewLimiter(time.Second, 20), // 每秒最多20次请求
+		retry:    retry.DefaultPolicy,
+		cacheTTL: 7 * 24 * time.Hour,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if opt.cache == nil {
+		memCache, err := cache.NewMemoryCache(10 * time.Minute)
+		if err != nil {
+			return nil, fmt.Errorf("create cache for TVDBClient failed: %w", err)
+		}
+		opt.cache = memCache
+	}
+
+	client := TVDBClient{
+		api:      opt.apiURL,
+		apiKey:   apiKey,
+		pin:      opt.pin,
+		language: opt.language,
+		client:   opt.client,
+		limiter:  opt.limiter,
+		retry:    opt.retry,
+		loader:   cache.NewLoader(opt.cache, opt.stale),
+		cacheTTL: opt.cacheTTL,
+	}
+	return &client, nil
+}
+
+// 登录获取 Token
+// https://thetvdb.github.io/v4-api/#/Login/post_login
+func (client *TVDBClient) Login(ctx context.Context) error {
+	body, err := json.Marshal(map[string]string{"apikey": client.apiKey, "pin": client.pin})
+	if err != nil {
+		return err
+	}
+	var resp Response[struct {
+		Token string `json:"token"`
+	}]
+	data, err := client.fetch(ctx, http.MethodPost, "/login", url.Values{}, body, "")
+	if err != nil {
+		return NewTVDBError("登录失败", err)
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return NewTVDBError("解析登录响应失败", err)
+	}
+	if resp.Data.Token == "" {
+		return NewTVDBError("登录失败，未返回 Token", nil)
+	}
+	client.token = resp.Data.Token
+	return nil
+}
+
+// 获取 Token，未登录时先登录
+func (client *TVDBClient) getToken(ctx context.Context, refresh bool) (string, error) {
+	client.tokenLock.Lock()
+	defer client.tokenLock.Unlock()
+	if client.token == "" || refresh {
+		if err := client.Login(ctx); err != nil {
+			return "", err
+		}
+	}
+	return client.token, nil
+}
+
+func (client *TVDBClient) DoRequest(ctx context.Context, method string, path string, query url.Values, resp any) error {
+	var (
+		data []byte
+		err  error
+	)
+	if method == http.MethodGet {
+		cacheKey := method + "|" + path + "|" + query.Encode()
+		data, err = client.loader.Load(ctx, cacheKey, client.cacheTTL, func(ctx context.Context) ([]byte, error) {
+			return client.authFetch(ctx, method, path, query)
+		})
+	} else {
+		data, err = client.authFetch(ctx, method, path, query)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, resp)
+	if err != nil {
+		return fmt.Errorf("unmarshal response failed: %w", err)
+	}
+	return nil
+}
+
+// 携带 Token 发送请求，Token 过期时重新登录后重试一次
+func (client *TVDBClient) authFetch(ctx context.Context, method string, path string, query url.Values) ([]byte, error) {
+	token, err := client.getToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := client.fetch(ctx, method, path, query, nil, token)
+	if isStatus(err, http.StatusUnauthorized) {
+		if token, err = client.getToken(ctx, true); err != nil {
+			return nil, err
+		}
+		data, err = client.fetch(ctx, method, path, query, nil, token)
+	}
+	return data, err
+}
+
+// 发送请求并返回原始响应体
+func (client *TVDBClient) fetch(ctx context.Context, method string, path string, query url.Values, payload []byte, token string) ([]byte, error) {
+	u := client.api + "/v4" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	res, err := client.retry.Do(ctx, client.client, client.limiter, func(ctx context.Context) (*http.Request, error) {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("create request failed: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("do request failed: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		var errResp Response[any]
+		_ = json.NewDecoder(res.Body).Decode(&errResp)
+		return nil, &StatusError{StatusCode: res.StatusCode, Message: errResp.Message}
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+	return data, nil
+}
+
+// CacheStats 获取缓存统计信息
+func (client *TVDBClient) CacheStats() (*cache.Stats, error) {
+	return client.loader.Cache().Stats()
+}
+
+// PurgeCache 清理缓存，expiredOnly 为 true 时只清理已过期的条目
+func (client *TVDBClient) PurgeCache(expiredOnly bool) (int64, error) {
+	return client.loader.Cache().Purge(expiredOnly)
+}
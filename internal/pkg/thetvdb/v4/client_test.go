@@ -0,0 +1,75 @@
+package thetvdb_test
+
+import (
+	"MediaTools/internal/pkg/fixture"
+	"MediaTools/internal/pkg/retry"
+	"MediaTools/internal/pkg/thetvdb/v4"
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureClient 创建请求录制数据的客户端，模拟登录并检查令牌，返回登录次数
+func newFixtureClient(t *testing.T, routes map[string]string, opts ...thetvdb.Options) (*thetvdb.TVDBClient, *atomic.Int32) {
+	var logins atomic.Int32
+	server := fixture.NewServer(t, routes, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/v4/login" {
+			assert.Equal(t, http.MethodPost, r.Method)
+			logins.Add(1)
+			fixture.Write(t, w, "login.json")
+			return true
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return true
+		}
+		return false
+	})
+
+	opts = append([]thetvdb.Options{
+		thetvdb.CustomAPIURL(server.URL),
+		thetvdb.CustomHTTPClient(server.Client()),
+		thetvdb.CustomRetryPolicy(retry.Policy{MaxAttempts: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second}),
+	}, opts...)
+	c, err := thetvdb.NewClient("test", opts...)
+	require.NoError(t, err)
+	return c, &logins
+}
+
+func TestSearch(t *testing.T) {
+	c, logins := newFixtureClient(t, map[string]string{"/v4/search": "search.json"})
+	results, err := c.Search(context.Background(), "Breaking Bad", "series", 2008)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "81189", results[0].TVDBID)
+	require.Equal(t, "绝命毒师", results[0].Translations["zho"])
+	require.Equal(t, int32(1), logins.Load())
+}
+
+func TestGetSeriesExtendedTranslated(t *testing.T) {
+	c, _ := newFixtureClient(t, map[string]string{
+		"/v4/series/81189/extended":         "series_extended.json",
+		"/v4/series/81189/translations/zho": "series_translation.json",
+	}, thetvdb.CustomLanguage("zho"))
+	series, err := c.GetSeriesExtended(context.Background(), 81189)
+	require.NoError(t, err)
+	require.Equal(t, "绝命毒师", series.Name)
+	require.Equal(t, "高中化学老师沃尔特·怀特被诊断出肺癌晚期。", series.Overview)
+	require.Len(t, series.Artworks, 2)
+	require.Equal(t, thetvdb.ArtworkSeriesPoster, series.Artworks[0].Type)
+}
+
+func TestGetSeriesEpisode(t *testing.T) {
+	c, _ := newFixtureClient(t, map[string]string{"/v4/series/81189/episodes/default/zho": "episodes.json"}, thetvdb.CustomLanguage("zho"))
+	episode, err := c.GetSeriesEpisode(context.Background(), 81189, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, "灰色物质", episode.Name)
+
+	_, err = c.GetSeriesEpisode(context.Background(), 81189, 1, 6)
+	require.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package thetvdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type MovieExtended struct {
+	ID               int        `json:"id"`
+	Name             string     `json:"name"`
+	Slug             string     `json:"slug"`
+	Image            string     `json:"image"`
+	Year             string     `json:"year"`
+	Overview         string     `json:"overview"`
+	Score            float64    `json:"score"`
+	Runtime          int        `json:"runtime"`
+	OriginalCountry  string     `json:"originalCountry"`
+	OriginalLanguage string     `json:"originalLanguage"`
+	Genres           []Genre    `json:"genres"`
+	Artworks         []Artwork  `json:"artworks"`
+	RemoteIDs        []RemoteID `json:"remoteIds"`
+}
+
+// 获取电影详细信息（包含图片和外部 ID）
+// https://thetvdb.github.io/v4-api/#/Movies/getMovieExtended
+func (client *TVDBClient) GetMovieExtended(ctx context.Context, movieID int) (*MovieExtended, error) {
+	var resp Response[MovieExtended]
+	if err := client.DoRequest(ctx, http.MethodGet, "/movies/"+strconv.Itoa(movieID)+"/extended", url.Values{}, &resp); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("获取电影「%d」详情失败", movieID), err)
+	}
+	if err := client.translate(ctx, "/movies/"+strconv.Itoa(movieID), &resp.Data.Name, &resp.Data.Overview); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("获取电影「%d」翻译失败", movieID), err)
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,80 @@
+package thetvdb
+
+import (
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/pkg/limiter"
+	"MediaTools/internal/pkg/retry"
+	"net/http"
+	"time"
+)
+
+type options struct {
+	apiURL   string // TheTVDB API URL
+	pin      string // 订阅者 PIN
+	language string // 翻译语言（ISO 639-2）
+	client   *http.Client
+	limiter  *limiter.Limiter
+	retry    retry.Policy
+	cache    cache.Cache   // 缓存后端
+	cacheTTL time.Duration // 缓存时间
+	stale    bool          // 是否开启 stale-while-revalidate
+}
+type Options func(opt *options)
+
+func CustomAPIURL(apiURL string) Options {
+	return func(opt *options) {
+		opt.apiURL = apiURL
+	}
+}
+
+// 订阅者 PIN，使用用户订阅的 API Key 时需要
+func CustomPin(pin string) Options {
+	return func(opt *options) {
+		opt.pin = pin
+	}
+}
+
+// 翻译语言，使用 ISO 639-2 三字母代码（如 zho、eng），为空时使用原始语言
+func CustomLanguage(language string) Options {
+	return func(opt *options) {
+		opt.language = language
+	}
+}
+
+func CustomHTTPClient(client *http.Client) Options {
+	return func(opt *options) {
+		opt.client = client
+	}
+}
+
+func CustomLimiter(d time.Duration, maxCount uint64) Options {
+	return func(opt *options) {
+		opt.limiter = limiter.NewLimiter(d, maxCount)
+	}
+}
+
+func CustomRetryPolicy(policy retry.Policy) Options {
+	return func(opt *options) {
+		opt.retry = policy
+	}
+}
+
+// 自定义缓存后端，默认使用内存缓存
+func CustomCache(c cache.Cache) Options {
+	return func(opt *options) {
+		opt.cache = c
+	}
+}
+
+func CustomCacheTTL(ttl time.Duration) Options {
+	return func(opt *options) {
+		opt.cacheTTL = ttl
+	}
+}
+
+// 缓存过期后先返回旧数据，并在后台刷新缓存
+func CustomStaleWhileRevalidate(enable bool) Options {
+	return func(opt *options) {
+		opt.stale = enable
+	}
+}
@@ -0,0 +1,67 @@
+package thetvdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type SearchResult struct {
+	ObjectID     string            `json:"objectID"`
+	TVDBID       string            `json:"tvdb_id"`
+	Name         string            `json:"name"`
+	Type         string            `json:"type"` // series、movie、person、company
+	Year         string            `json:"year"`
+	FirstAirTime string            `json:"first_air_time"`
+	Overview     string            `json:"overview"`
+	ImageURL     string            `json:"image_url"`
+	Country      string            `json:"country"`
+	Language     string            `json:"primary_language"`
+	Aliases      []string          `json:"aliases"`
+	Translations map[string]string `json:"translations"`
+	Overviews    map[string]string `json:"overviews"`
+	RemoteIDs    []RemoteID        `json:"remote_ids"`
+}
+
+// 搜索剧集或电影
+// searchType: series、movie，为空时不限制
+// year: 年份，0 不限制
+// https://thetvdb.github.io/v4-api/#/Search/getSearchResults
+func (client *TVDBClient) Search(ctx context.Context, query string, searchType string, year int) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	if searchType != "" {
+		params.Set("type", searchType)
+	}
+	if year > 0 {
+		params.Set("year", strconv.Itoa(year))
+	}
+	var resp Response[[]SearchResult]
+	if err := client.DoRequest(ctx, http.MethodGet, "/search", params, &resp); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("搜索「%s」失败", query), err)
+	}
+	return resp.Data, nil
+}
+
+type RemoteIDResult struct {
+	Series *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"series"`
+	Movie *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"movie"`
+}
+
+// 通过外部 ID（IMDb ID 等）查找剧集或电影
+// https://thetvdb.github.io/v4-api/#/Search/getSearchResultsByRemoteId
+func (client *TVDBClient) SearchByRemoteID(ctx context.Context, remoteID string) ([]RemoteIDResult, error) {
+	var resp Response[[]RemoteIDResult]
+	if err := client.DoRequest(ctx, http.MethodGet, "/search/remoteid/"+url.PathEscape(remoteID), url.Values{}, &resp); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("通过外部 ID「%s」查找失败", remoteID), err)
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,100 @@
+package thetvdb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type SeriesExtended struct {
+	ID               int        `json:"id"`
+	Name             string     `json:"name"`
+	Slug             string     `json:"slug"`
+	Image            string     `json:"image"`
+	FirstAired       string     `json:"firstAired"`
+	LastAired        string     `json:"lastAired"`
+	Year             string     `json:"year"`
+	Overview         string     `json:"overview"`
+	Score            float64    `json:"score"`
+	OriginalCountry  string     `json:"originalCountry"`
+	OriginalLanguage string     `json:"originalLanguage"`
+	Genres           []Genre    `json:"genres"`
+	Artworks         []Artwork  `json:"artworks"`
+	RemoteIDs        []RemoteID `json:"remoteIds"`
+}
+
+// 获取剧集详细信息（包含图片和外部 ID）
+// https://thetvdb.github.io/v4-api/#/Series/getSeriesExtended
+func (client *TVDBClient) GetSeriesExtended(ctx context.Context, seriesID int) (*SeriesExtended, error) {
+	var resp Response[SeriesExtended]
+	if err := client.DoRequest(ctx, http.MethodGet, "/series/"+strconv.Itoa(seriesID)+"/extended", url.Values{}, &resp); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("获取剧集「%d」详情失败", seriesID), err)
+	}
+	if err := client.translate(ctx, "/series/"+strconv.Itoa(seriesID), &resp.Data.Name, &resp.Data.Overview); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("获取剧集「%d」翻译失败", seriesID), err)
+	}
+	return &resp.Data, nil
+}
+
+type Episode struct {
+	ID           int    `json:"id"`
+	SeriesID     int    `json:"seriesId"`
+	Name         string `json:"name"`
+	Overview     string `json:"overview"`
+	Aired        string `json:"aired"`
+	Runtime      int    `json:"runtime"`
+	Image        string `json:"image"`
+	SeasonNumber int    `json:"seasonNumber"`
+	Number       int    `json:"number"`
+}
+
+// 获取剧集中指定季、集的信息（默认排序）
+// https://thetvdb.github.io/v4-api/#/Series/getSeriesEpisodes
+// https://thetvdb.github.io/v4-api/#/Series/getSeriesSeasonEpisodesTranslated
+func (client *TVDBClient) GetSeriesEpisode(ctx context.Context, seriesID int, season int, episode int) (*Episode, error) {
+	path := "/series/" + strconv.Itoa(seriesID) + "/episodes/default"
+	if client.language != "" {
+		path += "/" + client.language
+	}
+	params := url.Values{}
+	params.Set("page", "0")
+	params.Set("season", strconv.Itoa(season))
+	params.Set("episodeNumber", strconv.Itoa(episode))
+
+	var resp Response[struct {
+		Episodes []Episode `json:"episodes"`
+	}]
+	if err := client.DoRequest(ctx, http.MethodGet, path, params, &resp); err != nil {
+		return nil, NewTVDBError(fmt.Sprintf("获取剧集「%d」S%02dE%02d 失败", seriesID, season, episode), err)
+	}
+	for _, ep := range resp.Data.Episodes {
+		if ep.SeasonNumber == season && ep.Number == episode {
+			return &ep, nil
+		}
+	}
+	return nil, NewTVDBError(fmt.Sprintf("剧集「%d」中没有 S%02dE%02d", seriesID, season, episode), nil)
+}
+
+// translate 使用配置的语言翻译名称和简介，没有翻译时保持原样
+func (client *TVDBClient) translate(ctx context.Context, path string, name *string, overview *string) error {
+	if client.language == "" {
+		return nil
+	}
+	var resp Response[Translation]
+	err := client.DoRequest(ctx, http.MethodGet, path+"/translations/"+client.language, url.Values{}, &resp)
+	if isStatus(err, http.StatusNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.Data.Name != "" {
+		*name = resp.Data.Name
+	}
+	if resp.Data.Overview != "" {
+		*overview = resp.Data.Overview
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package thetvdb
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Response TheTVDB 接口统一响应
+type Response[T any] struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Data    T      `json:"data"`
+}
+
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed, status code: %d, message: %s", e.StatusCode, e.Message)
+}
+
+// isStatus 判断错误是否为指定的 HTTP 状态码
+func isStatus(err error, code int) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == code
+}
+
+type TVDBError struct {
+	err error
+	msg string
+}
+
+func (e *TVDBError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("TheTVDB 错误: %s", e.msg)
+	}
+	return fmt.Sprintf("TheTVDB 错误: %s - %s", e.msg, e.err.Error())
+}
+
+func (e *TVDBError) Unwrap() error {
+	return e.err
+}
+
+func NewTVDBError(msg string, err error) *TVDBError {
+	return &TVDBError{
+		err: err,
+		msg: msg,
+	}
+}
+
+// 图片类型
+// https://api4.thetvdb.com/v4/artwork/types
+const (
+	ArtworkSeriesBanner     = 1
+	ArtworkSeriesPoster     = 2
+	ArtworkSeriesBackground = 3
+	ArtworkSeasonPoster     = 7
+	ArtworkMoviePoster      = 14
+	ArtworkMovieBackground  = 15
+	ArtworkClearArt         = 22
+	ArtworkClearLogo        = 23
+)
+
+type Artwork struct {
+	ID       int     `json:"id"`
+	Image    string  `json:"image"`
+	Language string  `json:"language"`
+	Type     int     `json:"type"`
+	Score    float64 `json:"score"`
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+}
+
+type RemoteID struct {
+	ID         string `json:"id"`
+	Type       int    `json:"type"`
+	SourceName string `json:"sourceName"`
+}
+
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type Translation struct {
+	Name     string `json:"name"`
+	Overview string `json:"overview"`
+	Language string `json:"language"`
+}
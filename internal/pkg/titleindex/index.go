@@ -0,0 +1,102 @@
+package titleindex
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Entry 索引条目
+type Entry struct {
+	ID         int     `json:"id"`         // TMDB ID
+	Title      string  `json:"title"`      // 原始标题
+	Popularity float64 `json:"popularity"` // 热度
+}
+
+// Result 搜索结果
+type Result struct {
+	Entry
+	Score float64 `json:"score"` // 相似度（0~1）
+}
+
+// Index 基于 n-gram 倒排表的标题索引，构建后只读，可并发搜索
+type Index struct {
+	entries  []Entry
+	sizes    []int32            // 每个条目的 gram 数
+	postings map[string][]int32 // gram -> 条目下标
+}
+
+// Build 构建标题索引，归一化后为空的标题会被忽略
+func Build(entries []Entry) *Index {
+	idx := &Index{
+		entries:  make([]Entry, 0, len(entries)),
+		sizes:    make([]int32, 0, len(entries)),
+		postings: make(map[string][]int32),
+	}
+	for _, entry := range entries {
+		grams := ngrams(Normalize(entry.Title))
+		if len(grams) == 0 {
+			continue
+		}
+		i := int32(len(idx.entries))
+		idx.entries = append(idx.entries, entry)
+		idx.sizes = append(idx.sizes, int32(len(grams)))
+		for _, g := range grams {
+			idx.postings[g] = append(idx.postings[g], i)
+		}
+	}
+	return idx
+}
+
+// Len 索引中的条目数
+func (idx *Index) Len() int {
+	if idx == nil {
+		return 0
+	}
+	return len(idx.entries)
+}
+
+// Search 搜索与 query 相似的标题
+// 相似度为 n-gram 的 Dice 系数，低于 minScore 的结果会被丢弃
+// 结果按相似度、热度降序排列，最多返回 limit 个（limit <= 0 时不限制）
+func (idx *Index) Search(query string, limit int, minScore float64) []Result {
+	if idx.Len() == 0 {
+		return nil
+	}
+	grams := ngrams(Normalize(query))
+	if len(grams) == 0 {
+		return nil
+	}
+	common := make(map[int32]int32)
+	for _, g := range grams {
+		for _, i := range idx.postings[g] {
+			common[i]++
+		}
+	}
+
+	results := make([]Result, 0, len(common))
+	for i, n := range common {
+		score := 2 * float64(n) / float64(len(grams)+int(idx.sizes[i]))
+		if score < minScore {
+			continue
+		}
+		results = append(results, Result{Entry: idx.entries[i], Score: score})
+	}
+	SortResults(results)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// SortResults 按相似度、热度降序排列搜索结果
+func SortResults(results []Result) {
+	slices.SortFunc(results, func(a, b Result) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Popularity, a.Popularity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
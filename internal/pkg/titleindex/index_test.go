@@ -0,0 +1,115 @@
+package titleindex_test
+
+import (
+	"MediaTools/internal/pkg/titleindex"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const movieExport = `{"adult":false,"id":603,"original_title":"The Matrix","popularity":80.5,"video":false}
+{"adult":false,"id":604,"original_title":"The Matrix Reloaded","popularity":40.2,"video":false}
+{"adult":false,"id":605,"original_title":"The Matrix Revolutions","popularity":35.1,"video":false}
+{"adult":true,"id":900,"original_title":"The Matrix XXX","popularity":1.0,"video":false}
+{"adult":false,"id":901,"original_title":"Matrix","popularity":0.6,"video":false}
+{"adult":false,"id":9475,"original_title":"英雄","popularity":20.0,"video":false}
+{"adult":false,"id":9476,"original_title":"英雄本色","popularity":15.0,"video":false}
+`
+
+func gzipData(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "thematrix", titleindex.Normalize("The Matrix"))
+	require.Equal(t, "spiderman2", titleindex.Normalize("Spider-Man 2"))
+	require.Equal(t, "abc123", titleindex.Normalize("ＡＢＣ　１２３"))
+	require.Equal(t, "你的名字", titleindex.Normalize("你的名字。"))
+}
+
+func TestLoad(t *testing.T) {
+	entries, err := titleindex.Load(bytes.NewReader(gzipData(t, movieExport)), 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 6) // 跳过成人内容
+	require.Equal(t, titleindex.Entry{ID: 603, Title: "The Matrix", Popularity: 80.5}, entries[0])
+
+	entries, err = titleindex.Load(strings.NewReader(movieExport), 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 5) // 跳过热度低于 1 的条目
+
+	entries, err = titleindex.Load(strings.NewReader(`{"id":1399,"original_name":"Game of Thrones","popularity":300}`), 0)
+	require.NoError(t, err)
+	require.Equal(t, "Game of Thrones", entries[0].Title)
+
+	_, err = titleindex.Load(strings.NewReader("{\"id\":1}\nnot json\n"), 0)
+	require.ErrorContains(t, err, "第 2 行")
+}
+
+func TestSearch(t *testing.T) {
+	entries, err := titleindex.Load(strings.NewReader(movieExport), 0)
+	require.NoError(t, err)
+	idx := titleindex.Build(entries)
+	require.Equal(t, 6, idx.Len())
+
+	results := idx.Search("the.matrix", 3, 0.5)
+	require.Len(t, results, 3)
+	require.Equal(t, 603, results[0].ID)
+	require.InDelta(t, 1.0, results[0].Score, 1e-9)
+	require.Equal(t, 901, results[1].ID)
+	require.Equal(t, 604, results[2].ID)
+
+	results = idx.Search("Matrix", 0, 0.7)
+	require.Len(t, results, 2)
+	require.Equal(t, 901, results[0].ID)
+	require.Equal(t, 603, results[1].ID)
+
+	results = idx.Search("英雄", 0, 0.7)
+	require.Len(t, results, 1)
+	require.Equal(t, 9475, results[0].ID)
+
+	// 相似度相同时按热度排序
+	results = titleindex.Build([]titleindex.Entry{
+		{ID: 1, Title: "Dune", Popularity: 5},
+		{ID: 438631, Title: "Dune", Popularity: 90},
+	}).Search("DUNE", 0, 0.5)
+	require.Equal(t, 438631, results[0].ID)
+	require.Equal(t, 1, results[1].ID)
+
+	require.Empty(t, idx.Search("Inception", 0, 0.5))
+	require.Empty(t, idx.Search("!!!", 0, 0.5))
+	require.Empty(t, (*titleindex.Index)(nil).Search("The Matrix", 0, 0.5))
+}
+
+func TestResolveExport(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"movie_ids_12_31_2023.json.gz",
+		"movie_ids_01_02_2024.json.gz",
+		"movie_ids_latest.json.gz",
+		"tv_series_ids_01_03_2024.json.gz",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), gzipData(t, movieExport), 0o644))
+	}
+
+	file, err := titleindex.ResolveExport(dir, titleindex.MovieExportPrefix)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "movie_ids_01_02_2024.json.gz"), file)
+
+	entries, file, err := titleindex.LoadFile(dir, titleindex.TVExportPrefix, 0)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "tv_series_ids_01_03_2024.json.gz"), file)
+	require.Len(t, entries, 6)
+
+	_, err = titleindex.ResolveExport(t.TempDir(), titleindex.MovieExportPrefix)
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package titleindex
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TMDB 每日 ID 导出文件名前缀，完整文件名如 movie_ids_05_15_2024.json.gz
+// https://developer.themoviedb.org/docs/daily-id-exports
+const (
+	MovieExportPrefix = "movie_ids_"
+	TVExportPrefix    = "tv_series_ids_"
+)
+
+// 导出文件名中的日期格式（月_日_年）
+const exportDateLayout = "01_02_2006"
+
+// exportLine 导出文件中的一行，电影使用 original_title，电视剧使用 original_name
+type exportLine struct {
+	ID            int     `json:"id"`
+	Adult         bool    `json:"adult"`
+	OriginalTitle string  `json:"original_title"`
+	OriginalName  string  `json:"original_name"`
+	Popularity    float64 `json:"popularity"`
+}
+
+// Load 读取 TMDB ID 导出数据（JSON Lines，可为 gzip 压缩）
+// 成人内容和热度低于 minPopularity 的条目会被跳过
+func Load(r io.Reader, minPopularity float64) ([]Entry, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("解压导出文件失败: %w", err)
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var item exportLine
+		if err := json.Unmarshal(line, &item); err != nil {
+			return nil, fmt.Errorf("解析导出文件第 %d 行失败: %w", lineNo, err)
+		}
+		if item.Adult || item.Popularity < minPopularity {
+			continue
+		}
+		title := item.OriginalTitle
+		if title == "" {
+			title = item.OriginalName
+		}
+		if item.ID <= 0 || title == "" {
+			continue
+		}
+		entries = append(entries, Entry{ID: item.ID, Title: title, Popularity: item.Popularity})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取导出文件失败: %w", err)
+	}
+	return entries, nil
+}
+
+// LoadFile 读取导出文件，path 为目录时使用其中日期最新的导出文件
+// 返回实际读取的文件路径
+func LoadFile(path string, prefix string, minPopularity float64) ([]Entry, string, error) {
+	file, err := ResolveExport(path, prefix)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, file, fmt.Errorf("打开导出文件失败: %w", err)
+	}
+	defer f.Close()
+	entries, err := Load(f, minPopularity)
+	if err != nil {
+		return nil, file, fmt.Errorf("读取「%s」失败: %w", file, err)
+	}
+	return entries, file, nil
+}
+
+// ResolveExport 解析导出文件路径，path 为文件时直接返回
+// path 为目录时返回其中以 prefix 开头、文件名日期最新的导出文件
+func ResolveExport(path string, prefix string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("导出文件路径无效: %w", err)
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return "", fmt.Errorf("读取目录「%s」失败: %w", path, err)
+	}
+	var (
+		latest     string
+		latestDate time.Time
+	)
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		date, ok := exportDate(e.Name(), prefix)
+		if !ok {
+			continue
+		}
+		if latest == "" || date.After(latestDate) {
+			latest, latestDate = e.Name(), date
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("目录「%s」中没有 %s*.json.gz 导出文件", path, prefix)
+	}
+	return filepath.Join(path, latest), nil
+}
+
+// exportDate 解析导出文件名中的日期
+func exportDate(name string, prefix string) (time.Time, bool) {
+	name = strings.TrimPrefix(name, prefix)
+	name = strings.TrimSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".json")
+	date, err := time.Parse(exportDateLayout, name)
+	return date, err == nil
+}
@@ -0,0 +1,59 @@
+package titleindex
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize 归一化标题：全角转半角、转小写，只保留字母和数字
+func Normalize(title string) string {
+	var b strings.Builder
+	b.Grow(len(title))
+	for _, r := range title {
+		switch {
+		case r == 0x3000: // 全角空格
+			continue
+		case r >= 0xFF01 && r <= 0xFF5E: // 全角 ASCII
+			r -= 0xFEE0
+		}
+		r = unicode.ToLower(r)
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ngrams 返回归一化标题去重后的 n-gram
+// 包含中日韩文字时使用二元组，否则使用三元组；长度不足 n 时整体作为一个 gram
+func ngrams(normalized string) []string {
+	runes := []rune(normalized)
+	if len(runes) == 0 {
+		return nil
+	}
+	n := 3
+	for _, r := range runes {
+		if isCJK(r) {
+			n = 2
+			break
+		}
+	}
+	if len(runes) <= n {
+		return []string{normalized}
+	}
+	seen := make(map[string]struct{}, len(runes))
+	grams := make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		g := string(runes[i : i+n])
+		if _, ok := seen[g]; ok {
+			continue
+		}
+		seen[g] = struct{}{}
+		grams = append(grams, g)
+	}
+	return grams
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"MediaTools/internal/controller/fanart_controller"
+	"MediaTools/internal/controller/provider_controller"
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/cache"
+	"MediaTools/internal/schemas"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /cache/stats [get]
+// @Summary 查询缓存统计信息
+// @Description 查询 TMDB、Fanart 和其他元数据来源（TheTVDB、Bangumi、豆瓣）的缓存统计信息
+// @Description 未启用的元数据来源（如未配置 API Key 的 TheTVDB）不返回
+// @Tags 缓存管理
+// @Produce json
+func GetCacheStats(ctx *gin.Context) {
+	var resp schemas.Response[map[string]*cache.Stats]
+
+	tmdbStats, err := tmdb_controller.CacheStats()
+	if err != nil {
+		resp.Message = "获取 TMDB 缓存统计信息失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	fanartStats, err := fanart_controller.CacheStats()
+	if err != nil {
+		resp.Message = "获取 Fanart 缓存统计信息失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	stats := map[string]*cache.Stats{
+		"tmdb":   tmdbStats,
+		"fanart": fanartStats,
+	}
+	for _, name := range []provider_controller.ProviderName{provider_controller.ProviderTVDB, provider_controller.ProviderBangumi, provider_controller.ProviderDouban} {
+		if s, err := provider_controller.CacheStats(name); err == nil {
+			stats[string(name)] = s
+		}
+	}
+	resp.RespondSuccessJSON(ctx, stats)
+}
+
+// @Router /cache/{target} [delete]
+// @Summary 清理缓存
+// @Description 清理 TMDB、Fanart 或其他元数据来源的缓存
+// @Tags 缓存管理
+// @Param target path string true "缓存目标, 可选值为 'tmdb'、'fanart'、'tvdb'、'bangumi'、'douban'"
+// @Param expired query bool false "是否只清理已过期的缓存, 默认值为 false"
+// @Produce json
+func PurgeCache(ctx *gin.Context) {
+	var (
+		resp        schemas.Response[int64]
+		expiredOnly bool
+		count       int64
+		err         error
+	)
+
+	if expiredStr := ctx.Query("expired"); expiredStr != "" {
+		expiredOnly, err = strconv.ParseBool(expiredStr)
+		if err != nil {
+			resp.Message = "解析 expired 参数失败: " + err.Error()
+			resp.RespondJSON(ctx, http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch target := ctx.Param("target"); target {
+	case "tmdb":
+		count, err = tmdb_controller.PurgeCache(expiredOnly)
+	case "fanart":
+		count, err = fanart_controller.PurgeCache(expiredOnly)
+	case "tvdb", "bangumi", "douban":
+		count, err = provider_controller.PurgeCache(provider_controller.ProviderName(target), expiredOnly)
+	default:
+		resp.Message = "不支持的缓存目标: " + target
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		resp.Message = "清理缓存失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, count)
+}
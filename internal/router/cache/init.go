@@ -0,0 +1,9 @@
+package cache
+
+import "github.com/gin-gonic/gin"
+
+// 注册缓存相关路由
+func RegisterCacheRouter(cacheRouter *gin.RouterGroup) {
+	cacheRouter.GET("/stats", GetCacheStats)   // 查询缓存统计信息
+	cacheRouter.DELETE("/:target", PurgeCache) // 清理缓存
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/provider_controller"
+	"MediaTools/internal/schemas"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// @Router /config/bangumi [get]
+// @Summary 获取 Bangumi 配置
+// @Description 获取 Bangumi 配置
+// @Tags 应用配置,Bangumi
+// @Produce json
+func Bangumi(ctx *gin.Context) {
+	var resp schemas.Response[*config.BangumiConfig]
+	resp.Data = &config.Bangumi
+	resp.RespondJSON(ctx, http.StatusOK)
+}
+
+// @Router /config/bangumi [post]
+// @Summary 更新 Bangumi 配置
+// @Description 更新 Bangumi 配置
+// @Tags 应用配置,Bangumi
+// @Accept json
+// @Produce json
+// @Param config body config.BangumiConfig true "Bangumi 配置"
+func UpdateBangumi(ctx *gin.Context) {
+	var (
+		req  config.BangumiConfig
+		resp schemas.Response[*config.BangumiConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	logrus.Debugf("开始更新 Bangumi 配置: %+v", req)
+
+	oldConfig := config.Bangumi
+	config.Bangumi = req
+	err = provider_controller.Init()
+	if err != nil {
+		resp.Message = "初始化 Provider 控制器失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		logrus.Debugf("开始恢复 Bangumi 配置: %+v", oldConfig)
+		config.Bangumi = oldConfig
+		provider_controller.Init()
+		logrus.Debug("恢复 Bangumi 配置成功")
+		return
+	}
+
+	logrus.Debugf("Provider 控制器初始化成功: %+v", config.Bangumi)
+
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	resp.RespondSuccessJSON(ctx, &config.Bangumi)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/provider_controller"
+	"MediaTools/internal/schemas"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// @Router /config/douban [get]
+// @Summary 获取 豆瓣 配置
+// @Description 获取 豆瓣 配置
+// @Tags 应用配置,豆瓣
+// @Produce json
+func Douban(ctx *gin.Context) {
+	var resp schemas.Response[*config.DoubanConfig]
+	resp.Data = &config.Douban
+	resp.RespondJSON(ctx, http.StatusOK)
+}
+
+// @Router /config/douban [post]
+// @Summary 更新 豆瓣 配置
+// @Description 更新 豆瓣 配置
+// @Tags 应用配置,豆瓣
+// @Accept json
+// @Produce json
+// @Param config body config.DoubanConfig true "豆瓣 配置"
+func UpdateDouban(ctx *gin.Context) {
+	var (
+		req  config.DoubanConfig
+		resp schemas.Response[*config.DoubanConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	logrus.Debugf("开始更新 豆瓣 配置: %+v", req)
+
+	oldConfig := config.Douban
+	config.Douban = req
+	err = provider_controller.Init()
+	if err != nil {
+		resp.Message = "初始化 Provider 控制器失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		logrus.Debugf("开始恢复 豆瓣 配置: %+v", oldConfig)
+		config.Douban = oldConfig
+		provider_controller.Init()
+		logrus.Debug("恢复 豆瓣 配置成功")
+		return
+	}
+
+	logrus.Debugf("Provider 控制器初始化成功: %+v", config.Douban)
+
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	resp.RespondSuccessJSON(ctx, &config.Douban)
+}
@@ -15,6 +15,15 @@ func RegisterConfigRouter(configRouter *gin.RouterGroup) {
 	configRouter.GET("/fanart", Fanart)
 	configRouter.POST("/fanart", UpdateFanart)
 
+	configRouter.GET("/tvdb", TVDB)
+	configRouter.POST("/tvdb", UpdateTVDB)
+
+	configRouter.GET("/bangumi", Bangumi)
+	configRouter.POST("/bangumi", UpdateBangumi)
+
+	configRouter.GET("/douban", Douban)
+	configRouter.POST("/douban", UpdateDouban)
+
 	mediaRouter := configRouter.Group("/media")
 	{
 		mediaRouter.GET("/libraries", MediaLibrary)
@@ -25,5 +34,17 @@ func RegisterConfigRouter(configRouter *gin.RouterGroup) {
 
 		mediaRouter.GET("/custom_word", CustomWord)
 		mediaRouter.POST("/custom_word", UpdateCustomWord)
+
+		mediaRouter.GET("/subtitle", SubtitleConfig)
+		mediaRouter.POST("/subtitle", UpdateSubtitleConfig)
+
+		mediaRouter.GET("/artwork", ArtworkConfig)
+		mediaRouter.POST("/artwork", UpdateArtworkConfig)
+
+		mediaRouter.GET("/actor_thumb", ActorThumbConfig)
+		mediaRouter.POST("/actor_thumb", UpdateActorThumbConfig)
+
+		mediaRouter.GET("/collection", CollectionConfig)
+		mediaRouter.POST("/collection", UpdateCollectionConfig)
 	}
 }
@@ -2,9 +2,13 @@ package config
 
 import (
 	"MediaTools/internal/config"
+	"MediaTools/internal/controller/provider_controller"
 	"MediaTools/internal/controller/recognize_controller"
+	"MediaTools/internal/controller/scrape_controller"
 	"MediaTools/internal/schemas"
 	"net/http"
+	pathlib "path"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -48,6 +52,19 @@ func UpdateMediaLibrary(ctx *gin.Context) {
 		return
 	}
 
+	for _, lib := range req {
+		if _, err := scrape_controller.GetProfile(lib.ScrapeProfile); err != nil {
+			resp.Message = "媒体库「" + lib.Name + "」配置错误: " + err.Error()
+			resp.RespondJSON(ctx, http.StatusBadRequest)
+			return
+		}
+		if err := provider_controller.CheckProviders(lib.Providers); err != nil {
+			resp.Message = "媒体库「" + lib.Name + "」配置错误: " + err.Error()
+			resp.RespondJSON(ctx, http.StatusBadRequest)
+			return
+		}
+	}
+
 	logrus.Debugf("开始更新媒体库配置: %+v", req)
 
 	oldConfig := config.Media.Libraries
@@ -189,3 +206,202 @@ func UpdateCustomWord(ctx *gin.Context) {
 	resp.Data = &config.Media.CustomWord
 	resp.RespondJSON(ctx, http.StatusOK)
 }
+
+// @Router /config/media/subtitle [get]
+// @Summary 获取字幕处理配置
+// @Description 获取整理时的字幕处理配置
+// @Tags 应用配置
+// @Produce json
+func SubtitleConfig(ctx *gin.Context) {
+	var resp schemas.Response[*config.SubtitleConfig]
+	resp.RespondSuccessJSON(ctx, &config.Media.Subtitle)
+}
+
+// @Router /config/media/subtitle [post]
+// @Summary 更新字幕处理配置
+// @Description 更新整理时的字幕处理配置
+// @Tags 应用配置
+// @Accept json
+// @Produce json
+// @Param config body config.SubtitleConfig true "字幕处理配置"
+func UpdateSubtitleConfig(ctx *gin.Context) {
+	var (
+		req  config.SubtitleConfig
+		resp schemas.Response[*config.SubtitleConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	switch req.Chinese {
+	case "", "s2t", "t2s":
+	default:
+		resp.Message = "不支持的简繁转换方式: " + req.Chinese
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	switch req.Format {
+	case "", "srt", "ass":
+	default:
+		resp.Message = "不支持的字幕格式: " + req.Format
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	config.Media.Subtitle = req
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, &config.Media.Subtitle)
+}
+
+// @Router /config/media/artwork [get]
+// @Summary 获取刮削图片配置
+// @Description 获取刮削图片的选择与处理配置
+// @Tags 应用配置
+// @Produce json
+func ArtworkConfig(ctx *gin.Context) {
+	var resp schemas.Response[*config.ArtworkConfig]
+	resp.RespondSuccessJSON(ctx, &config.Media.Artwork)
+}
+
+// @Router /config/media/artwork [post]
+// @Summary 更新刮削图片配置
+// @Description 更新刮削图片的语言优先级、最小分辨率、最大边长和重新编码格式
+// @Tags 应用配置
+// @Accept json
+// @Produce json
+// @Param config body config.ArtworkConfig true "刮削图片配置"
+func UpdateArtworkConfig(ctx *gin.Context) {
+	var (
+		req  config.ArtworkConfig
+		resp schemas.Response[*config.ArtworkConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	if req.MinWidth < 0 || req.MinHeight < 0 || req.MaxSize < 0 {
+		resp.Message = "图片尺寸不能为负数"
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	if req.Quality < 0 || req.Quality > 100 {
+		resp.Message = "图片质量需在 1-100 之间"
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	if err := scrape_controller.CheckImageFormat(req.Format); err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	config.Media.Artwork = req
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, &config.Media.Artwork)
+}
+
+// @Router /config/media/actor_thumb [get]
+// @Summary 获取演员头像配置
+// @Description 获取演员头像本地化配置
+// @Tags 应用配置
+// @Produce json
+func ActorThumbConfig(ctx *gin.Context) {
+	var resp schemas.Response[*config.ActorThumbConfig]
+	resp.RespondSuccessJSON(ctx, &config.Media.ActorThumb)
+}
+
+// @Router /config/media/actor_thumb [post]
+// @Summary 更新演员头像配置
+// @Description 更新演员头像本地化配置（保存位置、下载数量）
+// @Tags 应用配置
+// @Accept json
+// @Produce json
+// @Param config body config.ActorThumbConfig true "演员头像配置"
+func UpdateActorThumbConfig(ctx *gin.Context) {
+	var (
+		req  config.ActorThumbConfig
+		resp schemas.Response[*config.ActorThumbConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	if err := scrape_controller.CheckActorThumbConfig(req); err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	config.Media.ActorThumb = req
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, &config.Media.ActorThumb)
+}
+
+// @Router /config/media/collection [get]
+// @Summary 获取电影合集配置
+// @Description 获取电影合集图片配置
+// @Tags 应用配置
+// @Produce json
+func CollectionConfig(ctx *gin.Context) {
+	var resp schemas.Response[*config.CollectionConfig]
+	resp.RespondSuccessJSON(ctx, &config.Media.Collection)
+}
+
+// @Router /config/media/collection [post]
+// @Summary 更新电影合集配置
+// @Description 更新电影合集图片配置
+// @Tags 应用配置
+// @Accept json
+// @Produce json
+// @Param config body config.CollectionConfig true "电影合集配置"
+func UpdateCollectionConfig(ctx *gin.Context) {
+	var (
+		req  config.CollectionConfig
+		resp schemas.Response[*config.CollectionConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	if pathlib.IsAbs(req.Dir) || strings.HasPrefix(pathlib.Clean(req.Dir), "..") {
+		resp.Message = "合集目录需为媒体库目标路径下的相对路径"
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	config.Media.Collection = req
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, &config.Media.Collection)
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"MediaTools/internal/config"
+	"MediaTools/internal/controller/provider_controller"
+	"MediaTools/internal/schemas"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// @Router /config/tvdb [get]
+// @Summary 获取 TheTVDB 配置
+// @Description 获取 TheTVDB 配置
+// @Tags 应用配置,TheTVDB
+// @Produce json
+func TVDB(ctx *gin.Context) {
+	var resp schemas.Response[*config.TVDBConfig]
+	resp.Data = &config.TVDB
+	resp.RespondJSON(ctx, http.StatusOK)
+}
+
+// @Router /config/tvdb [post]
+// @Summary 更新 TheTVDB 配置
+// @Description 更新 TheTVDB 配置
+// @Description API Key 为空时不启用 TheTVDB 元数据来源
+// @Tags 应用配置,TheTVDB
+// @Accept json
+// @Produce json
+// @Param config body config.TVDBConfig true "TheTVDB 配置"
+func UpdateTVDB(ctx *gin.Context) {
+	var (
+		req  config.TVDBConfig
+		resp schemas.Response[*config.TVDBConfig]
+	)
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	logrus.Debugf("开始更新 TheTVDB 配置: %+v", req)
+
+	oldConfig := config.TVDB
+	config.TVDB = req
+	err = provider_controller.Init()
+	if err != nil {
+		resp.Message = "初始化 Provider 控制器失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		logrus.Debugf("开始恢复 TheTVDB 配置: %+v", oldConfig)
+		config.TVDB = oldConfig
+		provider_controller.Init()
+		logrus.Debug("恢复 TheTVDB 配置成功")
+		return
+	}
+
+	logrus.Debugf("Provider 控制器初始化成功: %+v", config.TVDB)
+
+	err = config.WriteConfig()
+	if err != nil {
+		resp.Message = "写入配置文件失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+
+	resp.RespondSuccessJSON(ctx, &config.TVDB)
+}
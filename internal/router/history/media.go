@@ -23,7 +23,7 @@ import (
 // @Param end_time query time.Time false "结束时间, 格式为 RFC3339"
 // @Param storage_type query string false "存储类型, 可选值为 'LocalStorage' 等"
 // @Param path query string false "路径, 模糊匹配"
-// @Param transfer_type query string false "转移类型, 可选值为 'Copy'、'Move'、'Link'、'SoftLink' 等"
+// @Param transfer_type query string false "转移类型, 可选值为 'Copy'、'Move'、'Link'、'SoftLink'、'Strm' 等"
 // @Param status query bool false "是否成功, true 或 false"
 // @Param count query int false "最大返回数量, 默认值为 50"
 // @Param page query int false "页码, 从 1 开始, 默认值为 1"
@@ -2,14 +2,17 @@ package router
 
 import (
 	"MediaTools/internal/info"
+	"MediaTools/internal/router/cache"
 	"MediaTools/internal/router/config"
 	"MediaTools/internal/router/history"
 	"MediaTools/internal/router/library"
 	"MediaTools/internal/router/log"
+	"MediaTools/internal/router/mapping"
 	"MediaTools/internal/router/recognize"
 	"MediaTools/internal/router/runtime"
 	"MediaTools/internal/router/scrape"
 	"MediaTools/internal/router/storage"
+	"MediaTools/internal/router/subtitle"
 	"MediaTools/internal/router/task"
 	"MediaTools/internal/router/tmdb"
 	"MediaTools/internal/schemas"
@@ -51,6 +54,9 @@ func InitRouter(isDev bool, noRouterHandler gin.HandlerFunc) *gin.Engine {
 	storage.RegisterStorageRouter(apiRouter.Group("/storage"))      // 存储相关接口
 	history.RegisterHistoryRouter(apiRouter.Group("/history"))      // 历史记录相关接口
 	task.RegisterTaskRouter(apiRouter.Group("/task"))               // 任务相关接口
+	cache.RegisterCacheRouter(apiRouter.Group("/cache"))            // 缓存相关接口
+	mapping.RegisterMappingRouter(apiRouter.Group("/mapping"))      // 映射相关接口
+	subtitle.RegisterSubtitleRouter(apiRouter.Group("/subtitle"))   // 字幕相关接口
 	if noRouterHandler != nil {
 		ginRouter.NoRoute(noRouterHandler)
 	}
@@ -0,0 +1,14 @@
+package mapping
+
+import "github.com/gin-gonic/gin"
+
+// 注册映射相关路由
+func RegisterMappingRouter(mappingRouter *gin.RouterGroup) {
+	seriesMappingRouter := mappingRouter.Group("/series") // 剧集映射相关路由
+	{
+		seriesMappingRouter.GET("", QuerySeriesMappings)            // 查询所有剧集映射
+		seriesMappingRouter.GET("/:tmdbid", QuerySeriesMapping)     // 查询剧集映射
+		seriesMappingRouter.POST("", UpdateSeriesMapping)           // 更新剧集映射
+		seriesMappingRouter.DELETE("/:tmdbid", DeleteSeriesMapping) // 删除剧集映射
+	}
+}
@@ -0,0 +1,122 @@
+package mapping
+
+import (
+	"MediaTools/internal/database"
+	"MediaTools/internal/models"
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// @Router /mapping/series [get]
+// @Summary 查询所有剧集映射
+// @Description 查询所有电视剧的剧集映射设置
+// @Tags 映射管理
+// @Produce json
+func QuerySeriesMappings(ctx *gin.Context) {
+	var resp schemas.Response[[]models.SeriesMapping]
+
+	mappings, err := database.QuerySeriesMappings(ctx)
+	if err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, mappings)
+}
+
+// @Router /mapping/series/{tmdbid} [get]
+// @Summary 查询剧集映射
+// @Description 根据 TMDB ID 查询电视剧的剧集映射设置
+// @Tags 映射管理
+// @Param tmdbid path int true "电视剧 TMDB ID"
+// @Produce json
+func QuerySeriesMapping(ctx *gin.Context) {
+	var resp schemas.Response[*models.SeriesMapping]
+
+	tmdbID, err := strconv.Atoi(ctx.Param("tmdbid"))
+	if err != nil {
+		resp.Message = "无效的 TMDB ID 参数: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	mapping, err := database.QuerySeriesMappingByTMDBID(ctx, tmdbID)
+	if err != nil {
+		resp.Message = "查询剧集映射失败: " + err.Error()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			resp.RespondJSON(ctx, http.StatusNotFound)
+		} else {
+			resp.RespondJSON(ctx, http.StatusInternalServerError)
+		}
+		return
+	}
+	resp.RespondSuccessJSON(ctx, mapping)
+}
+
+// @Router /mapping/series [post]
+// @Summary 更新剧集映射
+// @Description 设置电视剧的剧集编号方式，不存在时自动创建
+// @Tags 映射管理
+// @Accept json
+// @Produce json
+// @Param data body schemas.SeriesMappingRequest true "请求参数"
+func UpdateSeriesMapping(ctx *gin.Context) {
+	var (
+		req  schemas.SeriesMappingRequest
+		resp schemas.Response[*models.SeriesMapping]
+	)
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.Message = "解析请求体失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	ordering, ok := meta.ParseOrdering(req.Ordering)
+	if !ok {
+		resp.Message = "无效的剧集编号方式: " + req.Ordering
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	mapping := models.SeriesMapping{
+		TMDBID:   req.TMDBID,
+		Ordering: ordering,
+	}
+	if err := database.UpdateSeriesMapping(ctx, &mapping); err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, &mapping)
+}
+
+// @Router /mapping/series/{tmdbid} [delete]
+// @Summary 删除剧集映射
+// @Description 根据 TMDB ID 删除电视剧的剧集映射设置
+// @Tags 映射管理
+// @Param tmdbid path int true "电视剧 TMDB ID"
+// @Produce json
+func DeleteSeriesMapping(ctx *gin.Context) {
+	var resp schemas.Response[any]
+
+	tmdbID, err := strconv.Atoi(ctx.Param("tmdbid"))
+	if err != nil {
+		resp.Message = "无效的 TMDB ID 参数: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	if err := database.DeleteSeriesMapping(ctx, tmdbID); err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, nil)
+}
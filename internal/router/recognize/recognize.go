@@ -1,9 +1,12 @@
 package recognize
 
 import (
+	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/controller/provider_controller"
 	"MediaTools/internal/controller/recognize_controller"
-	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/meta"
 	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -12,23 +15,49 @@ import (
 
 // @Route /recognize/media [get]
 // @Summary 识别媒体信息
-// @Description 根据提供的标题识别媒体信息，并返回 MediaItem 对象
+// @Description 根据提供的标题或文件路径识别媒体信息，并返回 MediaItem 对象
+// @Description 提供路径时会结合父目录和祖父目录名称识别（不越过媒体库源目录），并返回各字段来源
 // @Tags 识别
-// @Param title query string true "媒体标题"
+// @Param title query string false "媒体标题"
+// @Param path query string false "媒体文件路径"
+// @Param storage_type query string false "存储类型, 默认值为 'LocalStorage'"
 // @Produce json
 func RecognizeMedia(ctx *gin.Context) {
-	var resp schemas.Response[*schemas.RecognizeMediaDetail]
+	var (
+		resp                 schemas.Response[*schemas.RecognizeMediaDetail]
+		videoMeta            *meta.VideoMeta
+		customRule, metaRule string
+		sources              map[string]string
+		providers            []string
+	)
 
 	title := ctx.Query("title")
-	if title == "" {
-		resp.Message = "标题不能为空"
+	path := ctx.Query("path")
+	switch {
+	case path != "":
+		storageType := storage.StorageLocal
+		if storageTypeStr := ctx.Query("storage_type"); storageTypeStr != "" {
+			storageType = storage.ParseStorageType(storageTypeStr)
+		}
+		var stopDir string
+		if lib := library_controller.MatchLibrary(storage.NewStoragePath(storageType, path)); lib != nil {
+			stopDir = lib.SrcPath
+			providers = lib.Providers
+		}
+		logrus.Infof("正在根据路径识别媒体：%s", path)
+		videoMeta, customRule, metaRule, sources = recognize_controller.ParseVideoMetaByPath(path, stopDir)
+
+	case title != "":
+		logrus.Infof("正在识别媒体：%s", title)
+		videoMeta, customRule, metaRule = recognize_controller.ParseVideoMeta(title)
+
+	default:
+		resp.Message = "标题和路径不能同时为空"
 		resp.RespondJSON(ctx, http.StatusBadRequest)
 		return
 	}
 
-	logrus.Infof("正在识别媒体：%s", title)
-	videoMeta, customRule, metaRule := recognize_controller.ParseVideoMeta(title)
-	mediaInfo, err := tmdb_controller.RecognizeAndEnrichMedia(ctx,videoMeta)
+	mediaInfo, err := provider_controller.RecognizeAndEnrichMedia(ctx, videoMeta, providers)
 	if err != nil {
 		resp.Message = "识别失败: " + err.Error()
 		resp.RespondJSON(ctx, http.StatusInternalServerError)
@@ -46,6 +75,7 @@ func RecognizeMedia(ctx *gin.Context) {
 		Item:       item,
 		CustomRule: customRule,
 		MetaRule:   metaRule,
+		Sources:    sources,
 	}
 	resp.RespondJSON(ctx, http.StatusOK)
 }
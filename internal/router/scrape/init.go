@@ -4,4 +4,11 @@ import "github.com/gin-gonic/gin"
 
 func RegisterScrapeRouter(scrapeRouter *gin.RouterGroup) {
 	scrapeRouter.POST("/video", Video) // 刮削视频
+
+	refreshRouter := scrapeRouter.Group("/refresh") // 媒体库刷新相关接口
+	{
+		refreshRouter.POST("", Refresh)             // 提交媒体库刷新任务
+		refreshRouter.GET("", GetAllRefreshReports) // 查询刷新报告列表
+		refreshRouter.GET("/:id", GetRefreshReport) // 获取刷新报告
+	}
 }
@@ -0,0 +1,91 @@
+package scrape
+
+import (
+	"MediaTools/internal/controller/scrape_controller"
+	"MediaTools/internal/controller/storage_controller"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /scrape/refresh [post]
+// @Summary 刷新媒体库
+// @Description 遍历媒体库目录或转移历史记录，复用已有的 TMDB ID 补全或重新生成 NFO 和图片
+// @Tags 刮削
+// @Accept json
+// @Produce json
+// @Param request body schemas.ScrapeRefreshRequest true "刷新请求参数"
+func Refresh(ctx *gin.Context) {
+	var (
+		req  schemas.ScrapeRefreshRequest
+		resp schemas.Response[*task.Task]
+	)
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	mode, err := scrape_controller.ParseRefreshMode(req.Mode)
+	if err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	var dir storage.StoragePath
+	if req.Dir != nil {
+		dir, err = storage_controller.GetPath(req.Dir.Path, req.Dir.StorageType)
+		if err != nil {
+			resp.Message = "获取目录失败: " + err.Error()
+			resp.RespondJSON(ctx, http.StatusBadRequest)
+			return
+		}
+	}
+
+	t, err := scrape_controller.RefreshLibrary(dir, req.FromHistory, mode)
+	if err != nil {
+		resp.Message = "提交刷新任务失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, t)
+}
+
+// @Router /scrape/refresh [get]
+// @Summary 查询刷新报告列表
+// @Description 查询媒体库刷新报告列表（按提交时间倒序）
+// @Tags 刮削
+// @Produce json
+func GetAllRefreshReports(ctx *gin.Context) {
+	var resp schemas.Response[[]*scrape_controller.RefreshReport]
+	resp.RespondSuccessJSON(ctx, scrape_controller.ListRefreshReports())
+}
+
+// @Router /scrape/refresh/{id} [get]
+// @Summary 获取刷新报告
+// @Description 获取媒体库刷新任务的进度和结果
+// @Tags 刮削
+// @Param id path string true "任务 ID"
+// @Produce json
+func GetRefreshReport(ctx *gin.Context) {
+	var resp schemas.Response[*scrape_controller.RefreshReport]
+	id := ctx.Param("id")
+	if id == "" {
+		resp.Message = "任务 ID 不能为空"
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	report, err := scrape_controller.GetRefreshReport(id)
+	if err != nil {
+		resp.Message = "获取刷新报告失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusNotFound)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, report)
+}
@@ -9,6 +9,7 @@ import (
 	"iter"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -375,6 +376,12 @@ func StorageDownloadFile(ctx *gin.Context) {
 	ctx.Header("Content-Disposition", "attachment; filename="+filePath.GetName())
 	ctx.Header("Content-Type", "application/octet-stream")
 
+	// 支持随机读取时处理 Range 请求，strm 文件通过该接口播放时可以拖动进度
+	if rs, ok := reader.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, filePath.GetName(), time.Time{}, rs)
+		return
+	}
+
 	// 流式传输文件内容
 	_, err = io.Copy(ctx.Writer, reader)
 	if err != nil {
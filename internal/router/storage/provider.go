@@ -56,7 +56,7 @@ func ProviderGet(ctx *gin.Context) {
 // @Description 注册一个新的存储器
 // @Tags 存储,存储器
 // @Param storage_type path string true "存储类型"
-// @Param body body map[string]string true "存储器配置"
+// @Param body body map[string]string true "存储器配置，strm_url 为 strm 播放地址模板（可选）"
 // @Accept json
 // @Products json
 func ProviderRegister(ctx *gin.Context) {
@@ -82,9 +82,11 @@ func ProviderRegister(ctx *gin.Context) {
 	}
 
 	c := config.StorageConfig{
-		Type: storageType,
-		Data: req,
+		Type:    storageType,
+		StrmURL: req["strm_url"],
+		Data:    req,
 	}
+	delete(req, "strm_url")
 
 	logrus.Debugf("注册存储器: %s, 配置: %+v", storageType, c)
 
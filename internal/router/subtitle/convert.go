@@ -0,0 +1,63 @@
+package subtitle
+
+import (
+	"MediaTools/internal/controller/library_controller"
+	"MediaTools/internal/pkg/subtitle"
+	"MediaTools/internal/schemas"
+	"MediaTools/internal/schemas/storage"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /subtitle/convert [post]
+// @Summary 转换字幕
+// @Description 将字幕转换为 UTF-8，并可进行简繁转换、SRT/ASS 格式转换和时间轴偏移
+// @Tags 字幕
+// @Accept json
+// @Produce json
+// @Param request body schemas.SubtitleConvertRequest true "字幕转换请求"
+func ConvertSubtitle(ctx *gin.Context) {
+	var (
+		req  schemas.SubtitleConvertRequest
+		resp schemas.Response[*schemas.SubtitleConvertResult]
+	)
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.Message = "请求参数错误: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	opts := subtitle.Options{
+		Chinese: subtitle.ChineseConvert(req.Chinese),
+		Offset:  time.Duration(req.OffsetMS) * time.Millisecond,
+	}
+	if req.Format != "" {
+		opts.Format = subtitle.ParseFormat(req.Format)
+		if opts.Format == "" {
+			resp.Message = "不支持的字幕格式: " + req.Format
+			resp.RespondJSON(ctx, http.StatusBadRequest)
+			return
+		}
+	}
+
+	srcFile := storage.NewStoragePath(req.SrcFile.StorageType, req.SrcFile.Path)
+	dstFile := srcFile
+	if req.DstFile != nil {
+		dstFile = storage.NewStoragePath(req.DstFile.StorageType, req.DstFile.Path)
+	}
+
+	dstFile, result, err := library_controller.ConvertSubtitle(srcFile, dstFile, opts)
+	if err != nil {
+		resp.Message = err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, &schemas.SubtitleConvertResult{
+		DstFile:  dstFile,
+		Encoding: string(result.Encoding),
+		Format:   string(result.Format),
+	})
+}
@@ -0,0 +1,8 @@
+package subtitle
+
+import "github.com/gin-gonic/gin"
+
+// 注册字幕相关路由
+func RegisterSubtitleRouter(subtitleRouter *gin.RouterGroup) {
+	subtitleRouter.POST("/convert", ConvertSubtitle) // 转换字幕编码、简繁、格式及时间轴
+}
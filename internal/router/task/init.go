@@ -11,4 +11,10 @@ func RegisterTaskRouter(taskRouter *gin.RouterGroup) {
 		transferRouter.DELETE("/:id", CancelTransferTask) // 取消转移任务
 	}
 
+	scrapeRouter := taskRouter.Group("/scrape") // 刮削任务相关接口
+	{
+		scrapeRouter.GET("", GetAllScrapeTasks)       // 查询刮削任务列表
+		scrapeRouter.GET("/:id", GetScrapeTask)       // 获取刮削任务状态
+		scrapeRouter.DELETE("/:id", CancelScrapeTask) // 取消刮削任务
+	}
 }
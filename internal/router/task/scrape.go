@@ -0,0 +1,73 @@
+package task
+
+import (
+	"MediaTools/internal/controller/task_controller"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/schemas"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Router /task/scrape [get]
+// @Summary 查询刮削任务列表
+// @Description 查询刮削任务列表
+// @Tags 任务管理
+// @Produces json
+func GetAllScrapeTasks(ctx *gin.Context) {
+	var resp schemas.Response[[]*task.Task]
+
+	tasks := make([]*task.Task, 0)
+	for task := range task_controller.IterScrapeTasks {
+		tasks = append(tasks, task)
+	}
+	resp.RespondSuccessJSON(ctx, tasks)
+}
+
+// @Router /task/scrape/{id} [get]
+// @Summary 获取刮削任务状态
+// @Description 获取刮削任务状态
+// @Tags 任务管理
+// @Param id path string true "任务 ID"
+// @Produces json
+func GetScrapeTask(ctx *gin.Context) {
+	var resp schemas.Response[*task.Task]
+	id := ctx.Param("id")
+	if id == "" {
+		resp.Message = "任务 ID 不能为空"
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	t, err := task_controller.GetScrapeTask(id)
+	if err != nil {
+		resp.Message = "获取任务失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, t)
+}
+
+// @Router /task/scrape/{id} [delete]
+// @Summary 取消刮削任务
+// @Description 取消刮削任务
+// @Tags 任务管理
+// @Param id path string true "任务 ID"
+// @Produces json
+func CancelScrapeTask(ctx *gin.Context) {
+	var resp schemas.Response[*task.Task]
+	id := ctx.Param("id")
+	if id == "" {
+		resp.Message = "任务 ID 不能为空"
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+
+	task, err := task_controller.CancelScrapeTask(id)
+	if err != nil {
+		resp.Message = "取消任务失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusInternalServerError)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, task)
+}
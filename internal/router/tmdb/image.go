@@ -38,7 +38,7 @@ func PosterImage(ctx *gin.Context) {
 		return
 
 	case meta.MediaTypeMovie: // 处理电影类型
-		imagesInfo, err := tmdb_controller.GetMovieImage(tmdbID)
+		imagesInfo, err := tmdb_controller.GetMovieImage(ctx, tmdbID)
 		if err != nil {
 			resp.Message = "获取电影图片信息失败: " + err.Error()
 			resp.RespondJSON(ctx, http.StatusInternalServerError)
@@ -52,7 +52,7 @@ func PosterImage(ctx *gin.Context) {
 		imgPath = imagesInfo.Posters[0].FilePath
 
 	case meta.MediaTypeTV: // 处理电视剧类型
-		imagesInfo, err := tmdb_controller.GetTVSerieImage(tmdbID)
+		imagesInfo, err := tmdb_controller.GetTVSerieImage(ctx, tmdbID)
 		if err != nil {
 			resp.Message = "获取电视剧详情失败: " + err.Error()
 			resp.RespondJSON(ctx, http.StatusInternalServerError)
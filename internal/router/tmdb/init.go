@@ -10,4 +10,10 @@ func RegisterTMDBRouter(tmdbRouter *gin.RouterGroup) {
 	}
 	tmdbRouter.GET("/overview/:media_type/:tmdb_id", Overview) // 获取概述
 
+	titleIndexRouter := tmdbRouter.Group("/title_index") // 本地标题索引相关接口
+	{
+		titleIndexRouter.GET("", TitleIndexStatus)           // 获取索引状态
+		titleIndexRouter.POST("/refresh", RefreshTitleIndex) // 重新加载索引
+	}
+
 }
@@ -62,7 +62,7 @@ func Overview(ctx *gin.Context) {
 		return
 
 	case meta.MediaTypeMovie: // 处理电影类型
-		movieInfo, err := tmdb_controller.GetMovieDetail(tmdbID)
+		movieInfo, err := tmdb_controller.GetMovieDetail(ctx, tmdbID)
 		if err != nil {
 			resp.Message = "获取电影详情失败: " + err.Error()
 			resp.RespondJSON(ctx, http.StatusInternalServerError)
@@ -73,7 +73,7 @@ func Overview(ctx *gin.Context) {
 	case meta.MediaTypeTV: // 处理电视剧类型
 		switch {
 		case season >= 0 && episode > 0: // 获取特定季集的概述
-			episodeInfo, err := tmdb_controller.GetTVEpisodeDetail(tmdbID, season, episode)
+			episodeInfo, err := tmdb_controller.GetTVEpisodeDetail(ctx, tmdbID, season, episode)
 			if err != nil {
 				resp.Message = "获取电视剧集详情失败: " + err.Error()
 				resp.RespondJSON(ctx, http.StatusInternalServerError)
@@ -82,7 +82,7 @@ func Overview(ctx *gin.Context) {
 			overview = episodeInfo.TMDBInfo.TVInfo.EpisodeInfo.Overview
 
 		case season >= 0: // 获取特定季的概述
-			seasonInfo, err := tmdb_controller.GetTVSeasonDetail(tmdbID, season)
+			seasonInfo, err := tmdb_controller.GetTVSeasonDetail(ctx, tmdbID, season)
 			if err != nil {
 				resp.Message = "获取电视剧季详情失败: " + err.Error()
 				resp.RespondJSON(ctx, http.StatusInternalServerError)
@@ -91,7 +91,7 @@ func Overview(ctx *gin.Context) {
 			overview = seasonInfo.TMDBInfo.TVInfo.SeasonInfo.Overview
 
 		default: // 获取整部剧的概述
-			tvInfo, err := tmdb_controller.GetTVSerieDetail(tmdbID)
+			tvInfo, err := tmdb_controller.GetTVSerieDetail(ctx, tmdbID)
 			if err != nil {
 				resp.Message = "获取电视剧详情失败: " + err.Error()
 				resp.RespondJSON(ctx, http.StatusInternalServerError)
@@ -0,0 +1,38 @@
+package tmdb
+
+import (
+	"MediaTools/internal/controller/tmdb_controller"
+	"MediaTools/internal/pkg/task"
+	"MediaTools/internal/schemas"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @BasePath /tmdb
+// @Router /title_index [get]
+// @Summary 获取本地标题索引状态
+// @Description 获取基于 TMDB 每日 ID 导出文件的本地标题索引的加载状态和条目数
+// @Tags TMDB
+// @Produce json
+func TitleIndexStatus(ctx *gin.Context) {
+	var resp schemas.Response[tmdb_controller.TitleIndexStatus]
+	resp.RespondSuccessJSON(ctx, tmdb_controller.GetTitleIndexStatus())
+}
+
+// @BasePath /tmdb
+// @Router /title_index/refresh [post]
+// @Summary 刷新本地标题索引
+// @Description 提交后台任务，从配置的导出文件（目录时使用其中日期最新的文件）重新加载本地标题索引
+// @Tags TMDB
+// @Produce json
+func RefreshTitleIndex(ctx *gin.Context) {
+	var resp schemas.Response[*task.Task]
+	t, err := tmdb_controller.SubmitTitleIndexRefresh()
+	if err != nil {
+		resp.Message = "提交刷新任务失败: " + err.Error()
+		resp.RespondJSON(ctx, http.StatusBadRequest)
+		return
+	}
+	resp.RespondSuccessJSON(ctx, t)
+}
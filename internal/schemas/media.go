@@ -3,10 +3,12 @@ package schemas
 import (
 	"MediaTools/encode"
 	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/pkg/probe"
 	"MediaTools/internal/pkg/themoviedb/v3"
 	"fmt"
 	pathlib "path"
 	"strconv"
+	"strings"
 )
 
 type TMDBTVInfo struct {
@@ -28,33 +30,70 @@ type MediaInfo struct {
 	TMDBID    int            // TMDB ID
 	TMDBInfo  TMDBInfo       // TMDB 相关信息
 
-	IMDBID string // IMDb ID
-	TVDBID int    // TVDB ID
+	IMDBID    string // IMDb ID
+	TVDBID    int    // TVDB ID
+	DoubanID  string // 豆瓣 ID（电视剧为对应季的条目）
+	BangumiID int    // 番组计划 ID（电视剧为对应季的条目）
 
-	// DoubanID    string // 豆瓣 ID
-	// BangumiID   string // 番组计划 ID
-	// DoubanInfo  any    // 豆瓣相关信息
-	// BangumiInfo any    // 番组计划相关信息
+	Titles map[string]string // 各语言标题，键为语言（如 en、zh-TW）
+
+	Providers []ProviderInfo // 其他元数据来源（TVDB、Bangumi、豆瓣等）的信息，按优先级排序
+
+	Probe *probe.Info // 源文件的容器探测结果，刮削时用于生成流信息，为空时探测目标文件
+}
+
+// ProviderInfo 元数据来源提供的媒体信息
+type ProviderInfo struct {
+	Provider      string   // 来源名称（tvdb、bangumi、douban）
+	ID            string   // 来源中的条目 ID
+	SeasonOnly    bool     // 条目只对应单季（Bangumi、豆瓣按季建立条目），标题、简介、评分不作为整部剧的信息
+	Fuzzy         bool     // 搜索结果中没有标题一致的条目，按年份选择的条目可能不准确，只填补 TMDB 缺失的文本
+	Title         string   // 标题
+	OriginalTitle string   // 原始标题
+	Overview      string   // 简介
+	Year          int      // 年份
+	Rating        float64  // 评分（满分 10）
+	Votes         int      // 评分人数
+	Genres        []string // 类型
+
+	Episode *ProviderEpisode // 单集信息，仅电视剧识别到集数时有值
+}
+
+// ProviderEpisode 元数据来源提供的单集信息
+type ProviderEpisode struct {
+	Season   int    // 季数
+	Episode  int    // 集数
+	Title    string // 集标题
+	Overview string // 简介
+	AirDate  string // 播出日期
 }
 
 // 用于媒体库整理重命名可选字段模板
 type MediaItem struct {
 	Title         string                 `json:"title"`          // 标题
 	OriginalTitle string                 `json:"original_title"` // 原始标题
+	Titles        map[string]string      `json:"titles"`         // 各语言标题，如 {{.Titles.en}}、{{index .Titles "zh-TW"}}
 	Year          int                    `json:"year"`           // 年份
 	MediaType     meta.MediaType         `json:"media_type"`     // 电影、电视剧
 	Part          string                 `json:"part"`           // 分段
 	Version       uint8                  `json:"version"`        // 版本号
+	Edition       string                 `json:"edition"`        // 剪辑版本（Director's Cut、Extended、IMAX 等）
 	ReleaseGroups []string               `json:"release_groups"` // 发布组
 	Platform      meta.StreamingPlatform `json:"platform"`       // 流媒体平台
 	FileExtension string                 `json:"file_extension"` // 文件扩展名
 
 	Customization []string `json:"customization"` // 自定义词
 
+	// 合集信息（仅电影）
+	Collection   string `json:"collection"`    // 所属合集名称
+	CollectionID int    `json:"collection_id"` // 所属合集 TMDB ID
+
 	// ID 信息
-	TMDBID int    `json:"tmdb_id"` // TMDB ID
-	IMDBID string `json:"imdb_id"` // IMDb ID
-	TVDBID int    `json:"tvdb_id"` // TVDB ID
+	TMDBID    int    `json:"tmdb_id"`    // TMDB ID
+	IMDBID    string `json:"imdb_id"`    // IMDb ID
+	TVDBID    int    `json:"tvdb_id"`    // TVDB ID
+	DoubanID  string `json:"douban_id"`  // 豆瓣 ID
+	BangumiID int    `json:"bangumi_id"` // 番组计划 ID
 
 	// 资源相关信息
 	ResourceType   meta.ResourceType     `json:"resource_type"`   // 资源类型
@@ -71,6 +110,59 @@ type MediaItem struct {
 	EpisodeStr   string `json:"episode_str"`   // 集 E12 E12-E15
 	EpisodeTitle string `json:"episode_title"` // 集标题
 	EpisodeDate  string `json:"episode_date"`  // 集发布日期
+
+	// 附加内容数据
+	ExtraType meta.ExtraType `json:"extra_type"` // 附加内容类型
+	ExtraName string         `json:"extra_name"` // 附加内容名称（原始文件名去掉扩展名）
+
+	// 音乐数据
+	Artist      string      `json:"artist"`       // 艺术家
+	AlbumArtist string      `json:"album_artist"` // 专辑艺术家，未知时使用艺术家
+	Album       string      `json:"album"`        // 专辑
+	Disc        int         `json:"disc"`         // 碟号，未知时为 1
+	Track       TrackNumber `json:"track"`        // 音轨号，模板中输出两位数字（如 01），0 表示未知
+	Genre       string      `json:"genre"`        // 流派
+}
+
+// TrackNumber 音轨号，在模板中输出为两位数字
+type TrackNumber int
+
+func (n TrackNumber) String() string {
+	return fmt.Sprintf("%02d", int(n))
+}
+
+// 音乐文件名中不能出现的路径分隔符
+var musicNameReplacer = strings.NewReplacer("/", "／", "\\", "＼")
+
+// musicName 将路径分隔符替换为全角字符，只由 . 组成的名称（如 ..）同样替换，避免跳出目标目录
+func musicName(name string) string {
+	name = musicNameReplacer.Replace(name)
+	if trimmed := strings.TrimSpace(name); trimmed != "" && strings.Trim(trimmed, ".") == "" {
+		return strings.ReplaceAll(name, ".", "．")
+	}
+	return name
+}
+
+// NewMusicItem 根据音乐元数据创建媒体项，艺术家、专辑等字段中的路径分隔符和 .、.. 替换为全角字符
+func NewMusicItem(musicMeta *meta.MusicMeta) *MediaItem {
+	disc := musicMeta.Disc
+	if disc == 0 {
+		disc = 1
+	}
+	return &MediaItem{
+		MediaType:     meta.MediaTypeMusic,
+		Title:         musicName(musicMeta.Title),
+		Year:          musicMeta.Year,
+		FileExtension: pathlib.Ext(musicMeta.OrginalTitle),
+		Artist:        musicName(musicMeta.Artist),
+		AlbumArtist:   musicName(musicMeta.GetAlbumArtist()),
+		Album:         musicName(musicMeta.Album),
+		Disc:          disc,
+		Track:         TrackNumber(musicMeta.Track),
+		Genre:         musicMeta.Genre,
+		Season:        -1,
+		Episode:       -1,
+	}
 }
 
 func NewMediaItem(videoMeta *meta.VideoMeta, info *MediaInfo) (*MediaItem, error) {
@@ -78,6 +170,7 @@ func NewMediaItem(videoMeta *meta.VideoMeta, info *MediaInfo) (*MediaItem, error
 		MediaType:      info.MediaType,
 		Part:           videoMeta.Part,
 		Version:        videoMeta.Version,
+		Edition:        videoMeta.Edition,
 		ReleaseGroups:  videoMeta.ReleaseGroups,
 		Platform:       videoMeta.Platform,
 		ResourceType:   videoMeta.ResourceType,
@@ -87,20 +180,30 @@ func NewMediaItem(videoMeta *meta.VideoMeta, info *MediaInfo) (*MediaItem, error
 		AudioEncode:    videoMeta.AudioEncode,
 		FileExtension:  pathlib.Ext(videoMeta.OrginalTitle),
 		Customization:  videoMeta.Customization,
+		ExtraType:      videoMeta.ExtraType,
 
-		TMDBID: info.TMDBID,
-		IMDBID: info.IMDBID,
-		TVDBID: info.TVDBID,
+		TMDBID:    info.TMDBID,
+		IMDBID:    info.IMDBID,
+		TVDBID:    info.TVDBID,
+		DoubanID:  info.DoubanID,
+		BangumiID: info.BangumiID,
+
+		Titles: info.Titles,
 
 		Season:  -1, // 先强制设置成-1
 		Episode: -1,
 	}
+	if videoMeta.ExtraType.IsExtra() {
+		item.ExtraName = strings.TrimSuffix(pathlib.Base(videoMeta.OrginalTitle), item.FileExtension)
+	}
 
 	switch info.MediaType {
 	case meta.MediaTypeMovie:
 		if info.TMDBInfo.MovieInfo != nil {
 			item.Title = info.TMDBInfo.MovieInfo.Title
 			item.OriginalTitle = info.TMDBInfo.MovieInfo.OriginalTitle
+			item.Collection = info.TMDBInfo.MovieInfo.BelongsToCollection.Name
+			item.CollectionID = info.TMDBInfo.MovieInfo.BelongsToCollection.ID
 			year, err := strconv.Atoi(info.TMDBInfo.MovieInfo.ReleaseDate[:4])
 			if err == nil {
 				item.Year = year
@@ -127,6 +230,7 @@ func NewMediaItem(videoMeta *meta.VideoMeta, info *MediaInfo) (*MediaItem, error
 		}
 		item.Episode = videoMeta.Episode
 		item.EpisodeStr = videoMeta.GetEpisodeStr()
+		item.EpisodeDate = videoMeta.AirDate
 
 		if info.TMDBInfo.TVInfo.EpisodeInfo != nil {
 			item.EpisodeTitle = info.TMDBInfo.TVInfo.EpisodeInfo.Name
@@ -169,4 +273,6 @@ type RecognizeMediaDetail struct {
 	Item       *MediaItem `json:"item"`        // 识别到的媒体项
 	CustomRule string     `json:"custom_rule"` // 匹配的自定义规则
 	MetaRule   string     `json:"meta_rule"`   // 应用的媒体规则
+
+	Sources map[string]string `json:"sources,omitempty"` // 各字段来源（file/parent/grandparent），按路径识别时返回
 }
@@ -0,0 +1,31 @@
+package schemas_test
+
+import (
+	"MediaTools/internal/pkg/meta"
+	"MediaTools/internal/schemas"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMusicItem(t *testing.T) {
+	item := schemas.NewMusicItem(&meta.MusicMeta{
+		OrginalTitle: "/music/01 - Track.flac",
+		Title:        "AC/DC\\Live",
+		Artist:       "..",
+		AlbumArtist:  ".",
+		Album:        "..",
+		Track:        1,
+	})
+	require.Equal(t, "AC／DC＼Live", item.Title)
+	require.Equal(t, "．．", item.Artist)
+	require.Equal(t, "．", item.AlbumArtist)
+	require.Equal(t, "．．", item.Album)
+	require.Equal(t, ".flac", item.FileExtension)
+	require.Equal(t, 1, item.Disc)
+
+	// 只有部分为 . 的名称保持不变
+	item = schemas.NewMusicItem(&meta.MusicMeta{Artist: "...And You Will Know Us", Album: "Vol. 1"})
+	require.Equal(t, "...And You Will Know Us", item.Artist)
+	require.Equal(t, "Vol. 1", item.Album)
+}
@@ -25,6 +25,12 @@ type ScrapeRequest struct {
 	TMDBID    int             `json:"tmdb_id,omitempty"`
 }
 
+type ScrapeRefreshRequest struct {
+	Dir         *FileInfoRequest `json:"dir"`          // 媒体库目录，使用历史记录时可为空
+	FromHistory bool             `json:"from_history"` // 是否使用转移历史记录
+	Mode        string           `json:"mode"`         // 刷新模式：missing、metadata、images、force
+}
+
 type LibraryArchiveMediaRequest struct {
 	SrcFile      FileInfoRequest      `json:"src_file" binding:"required"`
 	DstDir       FileInfoRequest      `json:"dst_dir" binding:"required"`
@@ -59,3 +65,16 @@ type DeleteMediaTransferHistoryRequest struct {
 	DeleteSrc bool `json:"delete_src"` // 删除源文件
 	DeleteDst bool `json:"delete_dst"` // 删除目标文件
 }
+
+type SeriesMappingRequest struct {
+	TMDBID   int    `json:"tmdb_id" binding:"required"`
+	Ordering string `json:"ordering" binding:"required"` // 剧集编号方式：aired、absolute 或 TMDB 剧集组 ID
+}
+
+type SubtitleConvertRequest struct {
+	SrcFile  FileInfoRequest  `json:"src_file" binding:"required"` // 源字幕文件
+	DstFile  *FileInfoRequest `json:"dst_file"`                    // 目标文件，为空时原地转换（格式变化时仅修改扩展名）
+	Chinese  string           `json:"chinese"`                     // 简繁转换：s2t、t2s，为空不转换
+	Format   string           `json:"format"`                      // 目标格式：srt、ass，为空保持原格式
+	OffsetMS int64            `json:"offset_ms"`                   // 时间偏移（毫秒），正数为延后
+}
@@ -13,6 +13,7 @@ const (
 	TransferMove                         // 移动
 	TransferLink                         // 硬链接
 	TransferSoftLink                     // 软链接
+	TransferStrm                         // 生成 strm 文件
 )
 
 func (t TransferType) String() string {
@@ -25,6 +26,8 @@ func (t TransferType) String() string {
 		return "Link"
 	case TransferSoftLink:
 		return "SoftLink"
+	case TransferStrm:
+		return "Strm"
 	default:
 		return "UnknownTransferType"
 	}
@@ -53,6 +56,8 @@ func ParseTransferType(s string) TransferType {
 		return TransferLink
 	case "softlink":
 		return TransferSoftLink
+	case "strm":
+		return TransferStrm
 	default:
 		return TransferUnknown
 	}
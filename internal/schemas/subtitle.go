@@ -0,0 +1,10 @@
+package schemas
+
+import "MediaTools/internal/schemas/storage"
+
+// 字幕转换结果
+type SubtitleConvertResult struct {
+	DstFile  storage.StoragePath `json:"dst_file"` // 实际写入的字幕文件
+	Encoding string              `json:"encoding"` // 原始文本编码
+	Format   string              `json:"format"`   // 转换后的字幕格式
+}